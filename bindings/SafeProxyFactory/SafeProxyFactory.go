@@ -1,48 +1,25 @@
 // This file was generated by seer: https://github.com/moonstream-to/seer.
 // seer version: 0.2.0
-// seer command: seer evm generate --package SafeProxyFactory --cli --struct SafeProxyFactory --output bindings/SafeProxyFactory/SafeProxyFactory.go
+// seer command: seer evm generate --package SafeProxyFactory --struct SafeProxyFactory --output bindings/SafeProxyFactory/SafeProxyFactory.go
 // Code generated - DO NOT EDIT.
 // This file is a generated binding and any manual changes will be lost.
 
 package SafeProxyFactory
 
 import (
-	"bytes"
-	"crypto/rand"
 	"errors"
 	"math/big"
-	"net/http"
 	"strings"
 
-	"context"
-
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
-
-	// Reference imports to suppress errors if they are not otherwise used.
-	"encoding/hex"
-	"encoding/json"
-	"fmt"
-	"os"
-	"time"
-
-	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/moonstream-to/seer/bindings/CreateCall"
-	"github.com/moonstream-to/seer/bindings/GnosisSafe"
-	"github.com/spf13/cobra"
-	"golang.org/x/term"
-
-	// SafeProxyFactoryMetaData contains all meta data concerning the SafeProxyFactory contract.
-	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// Reference imports to suppress errors if they are not otherwise used.
 var (
 	_ = errors.New
 	_ = big.NewInt
@@ -55,6 +32,7 @@ var (
 	_ = abi.ConvertType
 )
 
+// SafeProxyFactoryMetaData contains all meta data concerning the SafeProxyFactory contract.
 var SafeProxyFactoryMetaData = &bind.MetaData{
 	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"contractSafeProxy\",\"name\":\"proxy\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"singleton\",\"type\":\"address\"}],\"name\":\"ProxyCreation\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_singleton\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"initializer\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"saltNonce\",\"type\":\"uint256\"}],\"name\":\"createChainSpecificProxyWithNonce\",\"outputs\":[{\"internalType\":\"contractSafeProxy\",\"name\":\"proxy\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_singleton\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"initializer\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"saltNonce\",\"type\":\"uint256\"},{\"internalType\":\"contractIProxyCreationCallback\",\"name\":\"callback\",\"type\":\"address\"}],\"name\":\"createProxyWithCallback\",\"outputs\":[{\"internalType\":\"contractSafeProxy\",\"name\":\"proxy\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_singleton\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"initializer\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"saltNonce\",\"type\":\"uint256\"}],\"name\":\"createProxyWithNonce\",\"outputs\":[{\"internalType\":\"contractSafeProxy\",\"name\":\"proxy\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getChainId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proxyCreationCode\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"}]",
 	Bin: "0x608060405234801561001057600080fd5b50610bde806100206000396000f3fe608060405234801561001057600080fd5b50600436106100575760003560e01c80631688f0b91461005c5780633408e4701461016b57806353e5d93514610189578063d18af54d1461020c578063ec9e80bb1461033b575b600080fd5b61013f6004803603606081101561007257600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803590602001906401000000008111156100af57600080fd5b8201836020820111156100c157600080fd5b803590602001918460018302840111640100000000831117156100e357600080fd5b91908080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f8201169050808301925050505050505091929192908035906020019092919050505061044a565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b6101736104fe565b6040518082815260200191505060405180910390f35b61019161050b565b6040518080602001828103825283818151815260200191508051906020019080838360005b838110156101d15780820151818401526020810190506101b6565b50505050905090810190601f1680156101fe5780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b61030f6004803603608081101561022257600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff1690602001909291908035906020019064010000000081111561025f57600080fd5b82018360208201111561027157600080fd5b8035906020019184600183028401116401000000008311171561029357600080fd5b91908080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f82011690508083019250505050505050919291929080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610536565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b61041e6004803603606081101561035157600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff1690602001909291908035906020019064010000000081111561038e57600080fd5b8201836020820111156103a057600080fd5b803590602001918460018302840111640100000000831117156103c257600080fd5b91908080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f820116905080830192505050505050509192919290803590602001909291905050506106e5565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b60008083805190602001208360405160200180838152602001828152602001925050506040516020818303038152906040528051906020012090506104908585836107a8565b91508173ffffffffffffffffffffffffffffffffffffffff167f4f51faf6c4561ff95f067657e43439f0f856d97c04d9ec9070a6199ad418e23586604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390a2509392505050565b6000804690508091505090565b60606040518060200161051d906109c5565b6020820181038252601f19601f82011660405250905090565b6000808383604051602001808381526020018273ffffffffffffffffffffffffffffffffffffffff1660601b8152601401925050506040516020818303038152906040528051906020012060001c905061059186868361044a565b9150600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff16146106dc578273ffffffffffffffffffffffffffffffffffffffff16631e52b518838888886040518563ffffffff1660e01b8152600401808573ffffffffffffffffffffffffffffffffffffffff1681526020018473ffffffffffffffffffffffffffffffffffffffff16815260200180602001838152602001828103825284818151815260200191508051906020019080838360005b83811015610674578082015181840152602081019050610659565b50505050905090810190601f1680156106a15780820380516001836020036101000a031916815260200191505b5095505050505050600060405180830381600087803b1580156106c357600080fd5b505af11580156106d7573d6000803e3d6000fd5b505050505b50949350505050565b6000808380519060200120836106f96104fe565b60405160200180848152602001838152602001828152602001935050505060405160208183030381529060405280519060200120905061073a8585836107a8565b91508173ffffffffffffffffffffffffffffffffffffffff167f4f51faf6c4561ff95f067657e43439f0f856d97c04d9ec9070a6199ad418e23586604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390a2509392505050565b60006107b3846109b2565b610825576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601f8152602001807f53696e676c65746f6e20636f6e7472616374206e6f74206465706c6f7965640081525060200191505060405180910390fd5b600060405180602001610837906109c5565b6020820181038252601f19601f820116604052508573ffffffffffffffffffffffffffffffffffffffff166040516020018083805190602001908083835b602083106108985780518252602082019150602081019050602083039250610875565b6001836020036101000a038019825116818451168082178552505050505050905001828152602001925050506040516020818303038152906040529050828151826020016000f59150600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161415610984576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260138152602001807f437265617465322063616c6c206661696c65640000000000000000000000000081525060200191505060405180910390fd5b6000845111156109aa5760008060008651602088016000875af114156109a957600080fd5b5b509392505050565b600080823b905060008111915050919050565b6101d6806109d38339019056fe608060405234801561001057600080fd5b506040516101d63803806101d68339818101604052602081101561003357600080fd5b8101908080519060200190929190505050600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614156100ca576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260228152602001806101b46022913960400191505060405180910390fd5b806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050609b806101196000396000f3fe60806040526000547fa619486e00000000000000000000000000000000000000000000000000000000600035141560405780600c1b600c1c60005260206000f35b3660008037600080366000845af43d6000803e60008114156060573d6000fd5b3d6000f3fea2646970667358221220bfbe5e66dfccd59d80684323ec36a561ddc5ef3b39a33a941f25cabefff21eb964736f6c63430007060033496e76616c69642073696e676c65746f6e20616464726573732070726f7669646564a2646970667358221220149b0d7527b1b8b9ef516314484ca0dc26d512f1a355e1835863922f9dc5953564736f6c63430007060033",
@@ -496,1314 +474,3 @@ func (_SafeProxyFactory *SafeProxyFactoryFilterer) ParseProxyCreation(log types.
 	event.Raw = log
 	return event, nil
 }
-
-func CreateSafeProxyFactoryDeploymentCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc string
-	var gasLimit uint64
-	var simulate bool
-	var timeout uint
-	var safeAddress, safeApi, safeCreateCall, safeSaltRaw string
-	var safeOperationType uint8
-	var salt [32]byte
-
-	cmd := &cobra.Command{
-		Use:   "deploy",
-		Short: "Deploy a new SafeProxyFactory contract",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if keyfile == "" {
-				return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
-			}
-
-			if rpc == "" {
-				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
-			}
-
-			if safeAddress != "" {
-				if !common.IsHexAddress(safeAddress) {
-					return fmt.Errorf("--safe is not a valid Ethereum address")
-				}
-				if safeApi == "" {
-					client, clientErr := NewClient(rpc)
-					if clientErr != nil {
-						return clientErr
-					}
-					chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-					defer cancelChainIDCtx()
-					chainID, chainIDErr := client.ChainID(chainIDCtx)
-					if chainIDErr != nil {
-						return chainIDErr
-					}
-					safeApi = fmt.Sprintf("https://safe-client.safe.global/v1/chains/%s/transactions/%s/propose", chainID.String(), safeAddress)
-					fmt.Println("--safe-api not specified, using default (", safeApi, ")")
-				}
-
-				if safeCreateCall == "" {
-					fmt.Println("--safe-create-call not specified, using default (0x7cbB62EaA69F79e6873cD1ecB2392971036cFAa4)")
-					safeCreateCall = "0x7cbB62EaA69F79e6873cD1ecB2392971036cFAa4"
-				}
-				if !common.IsHexAddress(safeCreateCall) {
-					return fmt.Errorf("--safe-create-call is not a valid Ethereum address")
-				}
-
-				if SafeOperationType(safeOperationType).String() == "Unknown" {
-					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
-				}
-
-				if safeSaltRaw == "" {
-					fmt.Println("--safe-salt not specified, generating random salt")
-					_, err := rand.Read(salt[:])
-					if err != nil {
-						return fmt.Errorf("failed to generate random salt: %v", err)
-					}
-					// prompt user to accept random salt
-					fmt.Println("Generated salt:", common.Bytes2Hex(salt[:]))
-					fmt.Println("Please check the salt and confirm (y/n)")
-					var confirm string
-					fmt.Scanln(&confirm)
-					if confirm != "y" && confirm != "Y" && confirm != "\n" && confirm != "" {
-						return fmt.Errorf("salt not accepted, please specify a valid salt")
-					}
-				} else {
-					copy(salt[:], safeSaltRaw)
-				}
-			}
-
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, clientErr := NewClient(rpc)
-			if clientErr != nil {
-				return clientErr
-			}
-
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
-			}
-
-			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-			defer cancelChainIDCtx()
-			chainID, chainIDErr := client.ChainID(chainIDCtx)
-			if chainIDErr != nil {
-				return chainIDErr
-			}
-
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
-
-			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
-
-			if safeAddress != "" {
-				// Generate deploy bytecode with constructor arguments
-				deployBytecode, err := generateSafeProxyFactoryDeployBytecode()
-				if err != nil {
-					return fmt.Errorf("failed to generate deploy bytecode: %v", err)
-				}
-
-				// Create Safe proposal for deployment
-				value := transactionOpts.Value
-				if value == nil {
-					value = big.NewInt(0)
-				}
-
-				err = DeployWithSafe(client, key, common.HexToAddress(safeAddress), common.HexToAddress(safeCreateCall), value, safeApi, deployBytecode, SafeOperationType(safeOperationType), salt)
-				if err != nil {
-					return fmt.Errorf("failed to create Safe proposal: %v", err)
-				}
-
-				return nil
-			}
-
-			address, deploymentTransaction, _, deploymentErr := DeploySafeProxyFactory(
-				transactionOpts,
-				client,
-			)
-			if deploymentErr != nil {
-				return deploymentErr
-			}
-
-			cmd.Printf("Transaction hash: %s\nContract address: %s\n", deploymentTransaction.Hash().Hex(), address.Hex())
-			if transactionOpts.NoSend {
-				estimationMessage := ethereum.CallMsg{
-					From: transactionOpts.From,
-					Data: deploymentTransaction.Data(),
-				}
-
-				gasEstimationCtx, cancelGasEstimationCtx := NewChainContext(timeout)
-				defer cancelGasEstimationCtx()
-
-				gasEstimate, gasEstimateErr := client.EstimateGas(gasEstimationCtx, estimationMessage)
-				if gasEstimateErr != nil {
-					return gasEstimateErr
-				}
-
-				transactionBinary, transactionBinaryErr := deploymentTransaction.MarshalBinary()
-				if transactionBinaryErr != nil {
-					return transactionBinaryErr
-				}
-				transactionBinaryHex := hex.EncodeToString(transactionBinary)
-
-				cmd.Printf("Transaction: %s\nEstimated gas: %d\n", transactionBinaryHex, gasEstimate)
-			} else {
-				cmd.Println("Transaction submitted")
-			}
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
-	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
-	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
-	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
-	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
-	cmd.Flags().StringVar(&gasPrice, "gas-price", "", "Gas price to use for the transaction")
-	cmd.Flags().StringVar(&maxFeePerGas, "max-fee-per-gas", "", "Maximum fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().StringVar(&maxPriorityFeePerGas, "max-priority-fee-per-gas", "", "Maximum priority fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0, "Gas limit for the transaction")
-	cmd.Flags().BoolVar(&simulate, "simulate", false, "Simulate the transaction without sending it")
-	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
-	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
-	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
-	cmd.Flags().StringVar(&safeCreateCall, "safe-create-call", "", "Address of the CreateCall contract (optional)")
-	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 1, "Safe operation type: 0 (Call) or 1 (DelegateCall) - default is 1")
-	cmd.Flags().StringVar(&safeSaltRaw, "safe-salt", "", "Salt to use for the Safe transaction")
-
-	return cmd
-}
-
-func generateSafeProxyFactoryDeployBytecode() ([]byte, error) {
-	abiPacked, err := SafeProxyFactoryMetaData.GetAbi()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get ABI: %v", err)
-	}
-
-	constructorArguments, err := abiPacked.Pack("")
-	if err != nil {
-		return nil, fmt.Errorf("failed to pack constructor arguments: %v", err)
-	}
-
-	deployBytecode := append(common.FromHex(SafeProxyFactoryMetaData.Bin), constructorArguments...)
-	return deployBytecode, nil
-}
-
-func CreateGetChainIdCommand() *cobra.Command {
-	var contractAddressRaw, rpc string
-	var contractAddress common.Address
-	var timeout uint
-
-	var blockNumberRaw, fromAddressRaw string
-	var pending bool
-
-	var capture0 *big.Int
-
-	cmd := &cobra.Command{
-		Use:   "get-chain-id",
-		Short: "Call the GetChainId view method on a SafeProxyFactory contract",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if contractAddressRaw == "" {
-				return fmt.Errorf("--contract not specified")
-			} else if !common.IsHexAddress(contractAddressRaw) {
-				return fmt.Errorf("--contract is not a valid Ethereum address")
-			}
-			contractAddress = common.HexToAddress(contractAddressRaw)
-
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, clientErr := NewClient(rpc)
-			if clientErr != nil {
-				return clientErr
-			}
-
-			contract, contractErr := NewSafeProxyFactory(contractAddress, client)
-			if contractErr != nil {
-				return contractErr
-			}
-
-			callOpts := bind.CallOpts{}
-			SetCallParametersFromArgs(&callOpts, pending, fromAddressRaw, blockNumberRaw)
-
-			session := SafeProxyFactoryCallerSession{
-				Contract: &contract.SafeProxyFactoryCaller,
-				CallOpts: callOpts,
-			}
-
-			var callErr error
-			capture0, callErr = session.GetChainId()
-			if callErr != nil {
-				return callErr
-			}
-
-			cmd.Printf("0: %s\n", capture0.String())
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
-	cmd.Flags().StringVar(&blockNumberRaw, "block", "", "Block number at which to call the view method")
-	cmd.Flags().BoolVar(&pending, "pending", false, "Set this flag if it's ok to call the view method against pending state")
-	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
-	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
-	cmd.Flags().StringVar(&fromAddressRaw, "from", "", "Optional address for caller of the view method")
-
-	return cmd
-}
-func CreateProxyCreationCodeCommand() *cobra.Command {
-	var contractAddressRaw, rpc string
-	var contractAddress common.Address
-	var timeout uint
-
-	var blockNumberRaw, fromAddressRaw string
-	var pending bool
-
-	var capture0 []byte
-
-	cmd := &cobra.Command{
-		Use:   "proxy-creation-code",
-		Short: "Call the ProxyCreationCode view method on a SafeProxyFactory contract",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if contractAddressRaw == "" {
-				return fmt.Errorf("--contract not specified")
-			} else if !common.IsHexAddress(contractAddressRaw) {
-				return fmt.Errorf("--contract is not a valid Ethereum address")
-			}
-			contractAddress = common.HexToAddress(contractAddressRaw)
-
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, clientErr := NewClient(rpc)
-			if clientErr != nil {
-				return clientErr
-			}
-
-			contract, contractErr := NewSafeProxyFactory(contractAddress, client)
-			if contractErr != nil {
-				return contractErr
-			}
-
-			callOpts := bind.CallOpts{}
-			SetCallParametersFromArgs(&callOpts, pending, fromAddressRaw, blockNumberRaw)
-
-			session := SafeProxyFactoryCallerSession{
-				Contract: &contract.SafeProxyFactoryCaller,
-				CallOpts: callOpts,
-			}
-
-			var callErr error
-			capture0, callErr = session.ProxyCreationCode()
-			if callErr != nil {
-				return callErr
-			}
-
-			cmd.Printf("0: %v\n", capture0)
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
-	cmd.Flags().StringVar(&blockNumberRaw, "block", "", "Block number at which to call the view method")
-	cmd.Flags().BoolVar(&pending, "pending", false, "Set this flag if it's ok to call the view method against pending state")
-	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
-	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
-	cmd.Flags().StringVar(&fromAddressRaw, "from", "", "Optional address for caller of the view method")
-
-	return cmd
-}
-
-func CreateCreateChainSpecificProxyWithNonceCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw string
-	var gasLimit uint64
-	var simulate bool
-	var timeout uint
-	var contractAddress common.Address
-	var safeAddress, safeApi string
-	var safeOperationType uint8
-
-	var singleton common.Address
-	var singletonRaw string
-	var initializer []byte
-	var initializerRaw string
-	var saltNonce *big.Int
-	var saltNonceRaw string
-
-	cmd := &cobra.Command{
-		Use:   "create-chain-specific-proxy-with-nonce",
-		Short: "Execute the CreateChainSpecificProxyWithNonce method on a SafeProxyFactory contract",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if contractAddressRaw == "" {
-				return fmt.Errorf("--contract not specified")
-			} else if !common.IsHexAddress(contractAddressRaw) {
-				return fmt.Errorf("--contract is not a valid Ethereum address")
-			}
-			contractAddress = common.HexToAddress(contractAddressRaw)
-
-			if keyfile == "" {
-				return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
-			}
-
-			if rpc == "" {
-				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
-			}
-
-			if safeAddress != "" {
-				if !common.IsHexAddress(safeAddress) {
-					return fmt.Errorf("--safe is not a valid Ethereum address")
-				}
-				if safeApi == "" {
-					client, clientErr := NewClient(rpc)
-					if clientErr != nil {
-						return clientErr
-					}
-					chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-					defer cancelChainIDCtx()
-					chainID, chainIDErr := client.ChainID(chainIDCtx)
-					if chainIDErr != nil {
-						return chainIDErr
-					}
-					safeApi = fmt.Sprintf("https://safe-client.safe.global/v1/chains/%s/transactions/%s/propose", chainID.String(), safeAddress)
-					fmt.Println("--safe-api not specified, using default (", safeApi, ")")
-				}
-
-				if SafeOperationType(safeOperationType).String() == "Unknown" {
-					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
-				}
-			}
-
-			if singletonRaw == "" {
-				return fmt.Errorf("--singleton argument not specified")
-			} else if !common.IsHexAddress(singletonRaw) {
-				return fmt.Errorf("--singleton argument is not a valid Ethereum address")
-			}
-			singleton = common.HexToAddress(singletonRaw)
-
-			var initializerIntermediate []byte
-
-			var initializerIntermediateHexDecodeErr error
-			initializerIntermediate, initializerIntermediateHexDecodeErr = hex.DecodeString(initializerRaw)
-			if initializerIntermediateHexDecodeErr != nil {
-				return initializerIntermediateHexDecodeErr
-			}
-
-			copy(initializer[:], initializerIntermediate)
-
-			if saltNonceRaw == "" {
-				return fmt.Errorf("--salt-nonce argument not specified")
-			}
-			saltNonce = new(big.Int)
-			saltNonce.SetString(saltNonceRaw, 0)
-
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, clientErr := NewClient(rpc)
-			if clientErr != nil {
-				return clientErr
-			}
-
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
-			}
-
-			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-			defer cancelChainIDCtx()
-			chainID, chainIDErr := client.ChainID(chainIDCtx)
-			if chainIDErr != nil {
-				return chainIDErr
-			}
-
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
-
-			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
-
-			contract, contractErr := NewSafeProxyFactory(contractAddress, client)
-			if contractErr != nil {
-				return contractErr
-			}
-
-			session := SafeProxyFactoryTransactorSession{
-				Contract:     &contract.SafeProxyFactoryTransactor,
-				TransactOpts: *transactionOpts,
-			}
-
-			if safeAddress != "" {
-				// Generate transaction data
-				transaction, err := session.CreateChainSpecificProxyWithNonce(
-
-					singleton,
-					initializer,
-					saltNonce,
-				)
-
-				if err != nil {
-					return err
-				}
-
-				// Create Safe proposal for transaction
-				value := transactionOpts.Value
-				if value == nil {
-					value = big.NewInt(0)
-				}
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, transaction.Data(), value, safeApi, SafeOperationType(safeOperationType))
-				if err != nil {
-					return fmt.Errorf("failed to create Safe proposal: %v", err)
-				}
-
-				return nil
-			}
-
-			transaction, err := session.CreateChainSpecificProxyWithNonce(
-
-				singleton,
-				initializer,
-				saltNonce,
-			)
-			if err != nil {
-				return err
-			}
-
-			cmd.Printf("Transaction hash: %s\n", transaction.Hash().Hex())
-			if transactionOpts.NoSend {
-				estimationMessage := ethereum.CallMsg{
-					From: transactionOpts.From,
-					To:   &contractAddress,
-					Data: transaction.Data(),
-				}
-
-				gasEstimationCtx, cancelGasEstimationCtx := NewChainContext(timeout)
-				defer cancelGasEstimationCtx()
-
-				gasEstimate, gasEstimateErr := client.EstimateGas(gasEstimationCtx, estimationMessage)
-				if gasEstimateErr != nil {
-					return gasEstimateErr
-				}
-
-				transactionBinary, transactionBinaryErr := transaction.MarshalBinary()
-				if transactionBinaryErr != nil {
-					return transactionBinaryErr
-				}
-				transactionBinaryHex := hex.EncodeToString(transactionBinary)
-
-				cmd.Printf("Transaction: %s\nEstimated gas: %d\n", transactionBinaryHex, gasEstimate)
-			} else {
-				cmd.Println("Transaction submitted")
-			}
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
-	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
-	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
-	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
-	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
-	cmd.Flags().StringVar(&gasPrice, "gas-price", "", "Gas price to use for the transaction")
-	cmd.Flags().StringVar(&maxFeePerGas, "max-fee-per-gas", "", "Maximum fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().StringVar(&maxPriorityFeePerGas, "max-priority-fee-per-gas", "", "Maximum priority fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0, "Gas limit for the transaction")
-	cmd.Flags().BoolVar(&simulate, "simulate", false, "Simulate the transaction without sending it")
-	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
-	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
-	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
-	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
-	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
-
-	cmd.Flags().StringVar(&singletonRaw, "singleton", "", "singleton argument (common.Address)")
-	cmd.Flags().StringVar(&initializerRaw, "initializer", "", "initializer argument ([]byte)")
-	cmd.Flags().StringVar(&saltNonceRaw, "salt-nonce", "", "salt-nonce argument")
-
-	return cmd
-}
-func CreateCreateProxyWithCallbackCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw string
-	var gasLimit uint64
-	var simulate bool
-	var timeout uint
-	var contractAddress common.Address
-	var safeAddress, safeApi string
-	var safeOperationType uint8
-
-	var singleton common.Address
-	var singletonRaw string
-	var initializer []byte
-	var initializerRaw string
-	var saltNonce *big.Int
-	var saltNonceRaw string
-	var callback common.Address
-	var callbackRaw string
-
-	cmd := &cobra.Command{
-		Use:   "create-proxy-with-callback",
-		Short: "Execute the CreateProxyWithCallback method on a SafeProxyFactory contract",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if contractAddressRaw == "" {
-				return fmt.Errorf("--contract not specified")
-			} else if !common.IsHexAddress(contractAddressRaw) {
-				return fmt.Errorf("--contract is not a valid Ethereum address")
-			}
-			contractAddress = common.HexToAddress(contractAddressRaw)
-
-			if keyfile == "" {
-				return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
-			}
-
-			if rpc == "" {
-				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
-			}
-
-			if safeAddress != "" {
-				if !common.IsHexAddress(safeAddress) {
-					return fmt.Errorf("--safe is not a valid Ethereum address")
-				}
-				if safeApi == "" {
-					client, clientErr := NewClient(rpc)
-					if clientErr != nil {
-						return clientErr
-					}
-					chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-					defer cancelChainIDCtx()
-					chainID, chainIDErr := client.ChainID(chainIDCtx)
-					if chainIDErr != nil {
-						return chainIDErr
-					}
-					safeApi = fmt.Sprintf("https://safe-client.safe.global/v1/chains/%s/transactions/%s/propose", chainID.String(), safeAddress)
-					fmt.Println("--safe-api not specified, using default (", safeApi, ")")
-				}
-
-				if SafeOperationType(safeOperationType).String() == "Unknown" {
-					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
-				}
-			}
-
-			if singletonRaw == "" {
-				return fmt.Errorf("--singleton argument not specified")
-			} else if !common.IsHexAddress(singletonRaw) {
-				return fmt.Errorf("--singleton argument is not a valid Ethereum address")
-			}
-			singleton = common.HexToAddress(singletonRaw)
-
-			var initializerIntermediate []byte
-
-			var initializerIntermediateHexDecodeErr error
-			initializerIntermediate, initializerIntermediateHexDecodeErr = hex.DecodeString(initializerRaw)
-			if initializerIntermediateHexDecodeErr != nil {
-				return initializerIntermediateHexDecodeErr
-			}
-
-			copy(initializer[:], initializerIntermediate)
-
-			if saltNonceRaw == "" {
-				return fmt.Errorf("--salt-nonce argument not specified")
-			}
-			saltNonce = new(big.Int)
-			saltNonce.SetString(saltNonceRaw, 0)
-
-			if callbackRaw == "" {
-				return fmt.Errorf("--callback argument not specified")
-			} else if !common.IsHexAddress(callbackRaw) {
-				return fmt.Errorf("--callback argument is not a valid Ethereum address")
-			}
-			callback = common.HexToAddress(callbackRaw)
-
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, clientErr := NewClient(rpc)
-			if clientErr != nil {
-				return clientErr
-			}
-
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
-			}
-
-			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-			defer cancelChainIDCtx()
-			chainID, chainIDErr := client.ChainID(chainIDCtx)
-			if chainIDErr != nil {
-				return chainIDErr
-			}
-
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
-
-			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
-
-			contract, contractErr := NewSafeProxyFactory(contractAddress, client)
-			if contractErr != nil {
-				return contractErr
-			}
-
-			session := SafeProxyFactoryTransactorSession{
-				Contract:     &contract.SafeProxyFactoryTransactor,
-				TransactOpts: *transactionOpts,
-			}
-
-			if safeAddress != "" {
-				// Generate transaction data
-				transaction, err := session.CreateProxyWithCallback(
-
-					singleton,
-					initializer,
-					saltNonce,
-					callback,
-				)
-
-				if err != nil {
-					return err
-				}
-
-				// Create Safe proposal for transaction
-				value := transactionOpts.Value
-				if value == nil {
-					value = big.NewInt(0)
-				}
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, transaction.Data(), value, safeApi, SafeOperationType(safeOperationType))
-				if err != nil {
-					return fmt.Errorf("failed to create Safe proposal: %v", err)
-				}
-
-				return nil
-			}
-
-			transaction, err := session.CreateProxyWithCallback(
-
-				singleton,
-				initializer,
-				saltNonce,
-				callback,
-			)
-			if err != nil {
-				return err
-			}
-
-			cmd.Printf("Transaction hash: %s\n", transaction.Hash().Hex())
-			if transactionOpts.NoSend {
-				estimationMessage := ethereum.CallMsg{
-					From: transactionOpts.From,
-					To:   &contractAddress,
-					Data: transaction.Data(),
-				}
-
-				gasEstimationCtx, cancelGasEstimationCtx := NewChainContext(timeout)
-				defer cancelGasEstimationCtx()
-
-				gasEstimate, gasEstimateErr := client.EstimateGas(gasEstimationCtx, estimationMessage)
-				if gasEstimateErr != nil {
-					return gasEstimateErr
-				}
-
-				transactionBinary, transactionBinaryErr := transaction.MarshalBinary()
-				if transactionBinaryErr != nil {
-					return transactionBinaryErr
-				}
-				transactionBinaryHex := hex.EncodeToString(transactionBinary)
-
-				cmd.Printf("Transaction: %s\nEstimated gas: %d\n", transactionBinaryHex, gasEstimate)
-			} else {
-				cmd.Println("Transaction submitted")
-			}
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
-	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
-	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
-	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
-	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
-	cmd.Flags().StringVar(&gasPrice, "gas-price", "", "Gas price to use for the transaction")
-	cmd.Flags().StringVar(&maxFeePerGas, "max-fee-per-gas", "", "Maximum fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().StringVar(&maxPriorityFeePerGas, "max-priority-fee-per-gas", "", "Maximum priority fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0, "Gas limit for the transaction")
-	cmd.Flags().BoolVar(&simulate, "simulate", false, "Simulate the transaction without sending it")
-	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
-	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
-	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
-	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
-	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
-
-	cmd.Flags().StringVar(&singletonRaw, "singleton", "", "singleton argument (common.Address)")
-	cmd.Flags().StringVar(&initializerRaw, "initializer", "", "initializer argument ([]byte)")
-	cmd.Flags().StringVar(&saltNonceRaw, "salt-nonce", "", "salt-nonce argument")
-	cmd.Flags().StringVar(&callbackRaw, "callback", "", "callback argument (common.Address)")
-
-	return cmd
-}
-func CreateCreateProxyWithNonceCommand() *cobra.Command {
-	var keyfile, nonce, password, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, rpc, contractAddressRaw string
-	var gasLimit uint64
-	var simulate bool
-	var timeout uint
-	var contractAddress common.Address
-	var safeAddress, safeApi string
-	var safeOperationType uint8
-
-	var singleton common.Address
-	var singletonRaw string
-	var initializer []byte
-	var initializerRaw string
-	var saltNonce *big.Int
-	var saltNonceRaw string
-
-	cmd := &cobra.Command{
-		Use:   "create-proxy-with-nonce",
-		Short: "Execute the CreateProxyWithNonce method on a SafeProxyFactory contract",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if contractAddressRaw == "" {
-				return fmt.Errorf("--contract not specified")
-			} else if !common.IsHexAddress(contractAddressRaw) {
-				return fmt.Errorf("--contract is not a valid Ethereum address")
-			}
-			contractAddress = common.HexToAddress(contractAddressRaw)
-
-			if keyfile == "" {
-				return fmt.Errorf("--keystore not specified (this should be a path to an Ethereum account keystore file)")
-			}
-
-			if rpc == "" {
-				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
-			}
-
-			if safeAddress != "" {
-				if !common.IsHexAddress(safeAddress) {
-					return fmt.Errorf("--safe is not a valid Ethereum address")
-				}
-				if safeApi == "" {
-					client, clientErr := NewClient(rpc)
-					if clientErr != nil {
-						return clientErr
-					}
-					chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-					defer cancelChainIDCtx()
-					chainID, chainIDErr := client.ChainID(chainIDCtx)
-					if chainIDErr != nil {
-						return chainIDErr
-					}
-					safeApi = fmt.Sprintf("https://safe-client.safe.global/v1/chains/%s/transactions/%s/propose", chainID.String(), safeAddress)
-					fmt.Println("--safe-api not specified, using default (", safeApi, ")")
-				}
-
-				if SafeOperationType(safeOperationType).String() == "Unknown" {
-					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
-				}
-			}
-
-			if singletonRaw == "" {
-				return fmt.Errorf("--singleton argument not specified")
-			} else if !common.IsHexAddress(singletonRaw) {
-				return fmt.Errorf("--singleton argument is not a valid Ethereum address")
-			}
-			singleton = common.HexToAddress(singletonRaw)
-
-			var initializerIntermediate []byte
-
-			var initializerIntermediateHexDecodeErr error
-			initializerIntermediate, initializerIntermediateHexDecodeErr = hex.DecodeString(initializerRaw)
-			if initializerIntermediateHexDecodeErr != nil {
-				return initializerIntermediateHexDecodeErr
-			}
-
-			copy(initializer[:], initializerIntermediate)
-
-			if saltNonceRaw == "" {
-				return fmt.Errorf("--salt-nonce argument not specified")
-			}
-			saltNonce = new(big.Int)
-			saltNonce.SetString(saltNonceRaw, 0)
-
-			return nil
-		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, clientErr := NewClient(rpc)
-			if clientErr != nil {
-				return clientErr
-			}
-
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
-			}
-
-			chainIDCtx, cancelChainIDCtx := NewChainContext(timeout)
-			defer cancelChainIDCtx()
-			chainID, chainIDErr := client.ChainID(chainIDCtx)
-			if chainIDErr != nil {
-				return chainIDErr
-			}
-
-			transactionOpts, transactionOptsErr := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
-			if transactionOptsErr != nil {
-				return transactionOptsErr
-			}
-
-			SetTransactionParametersFromArgs(transactionOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas, gasLimit, simulate)
-
-			contract, contractErr := NewSafeProxyFactory(contractAddress, client)
-			if contractErr != nil {
-				return contractErr
-			}
-
-			session := SafeProxyFactoryTransactorSession{
-				Contract:     &contract.SafeProxyFactoryTransactor,
-				TransactOpts: *transactionOpts,
-			}
-
-			if safeAddress != "" {
-				// Generate transaction data
-				transaction, err := session.CreateProxyWithNonce(
-
-					singleton,
-					initializer,
-					saltNonce,
-				)
-
-				if err != nil {
-					return err
-				}
-
-				// Create Safe proposal for transaction
-				value := transactionOpts.Value
-				if value == nil {
-					value = big.NewInt(0)
-				}
-				err = CreateSafeProposal(client, key, common.HexToAddress(safeAddress), contractAddress, transaction.Data(), value, safeApi, SafeOperationType(safeOperationType))
-				if err != nil {
-					return fmt.Errorf("failed to create Safe proposal: %v", err)
-				}
-
-				return nil
-			}
-
-			transaction, err := session.CreateProxyWithNonce(
-
-				singleton,
-				initializer,
-				saltNonce,
-			)
-			if err != nil {
-				return err
-			}
-
-			cmd.Printf("Transaction hash: %s\n", transaction.Hash().Hex())
-			if transactionOpts.NoSend {
-				estimationMessage := ethereum.CallMsg{
-					From: transactionOpts.From,
-					To:   &contractAddress,
-					Data: transaction.Data(),
-				}
-
-				gasEstimationCtx, cancelGasEstimationCtx := NewChainContext(timeout)
-				defer cancelGasEstimationCtx()
-
-				gasEstimate, gasEstimateErr := client.EstimateGas(gasEstimationCtx, estimationMessage)
-				if gasEstimateErr != nil {
-					return gasEstimateErr
-				}
-
-				transactionBinary, transactionBinaryErr := transaction.MarshalBinary()
-				if transactionBinaryErr != nil {
-					return transactionBinaryErr
-				}
-				transactionBinaryHex := hex.EncodeToString(transactionBinary)
-
-				cmd.Printf("Transaction: %s\nEstimated gas: %d\n", transactionBinaryHex, gasEstimate)
-			} else {
-				cmd.Println("Transaction submitted")
-			}
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
-	cmd.Flags().StringVar(&keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
-	cmd.Flags().StringVar(&password, "password", "", "Password to use to unlock the keystore (if not specified, you will be prompted for the password when the command executes)")
-	cmd.Flags().StringVar(&nonce, "nonce", "", "Nonce to use for the transaction")
-	cmd.Flags().StringVar(&value, "value", "", "Value to send with the transaction")
-	cmd.Flags().StringVar(&gasPrice, "gas-price", "", "Gas price to use for the transaction")
-	cmd.Flags().StringVar(&maxFeePerGas, "max-fee-per-gas", "", "Maximum fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().StringVar(&maxPriorityFeePerGas, "max-priority-fee-per-gas", "", "Maximum priority fee per gas to use for the (EIP-1559) transaction")
-	cmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0, "Gas limit for the transaction")
-	cmd.Flags().BoolVar(&simulate, "simulate", false, "Simulate the transaction without sending it")
-	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
-	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
-	cmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe contract")
-	cmd.Flags().StringVar(&safeApi, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
-	cmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
-
-	cmd.Flags().StringVar(&singletonRaw, "singleton", "", "singleton argument (common.Address)")
-	cmd.Flags().StringVar(&initializerRaw, "initializer", "", "initializer argument ([]byte)")
-	cmd.Flags().StringVar(&saltNonceRaw, "salt-nonce", "", "salt-nonce argument")
-
-	return cmd
-}
-
-var ErrNoRPCURL error = errors.New("no RPC URL provided -- please pass an RPC URL from the command line or set the SAFE_PROXY_FACTORY_RPC_URL environment variable")
-
-// Generates an Ethereum client to the JSONRPC API at the given URL. If rpcURL is empty, then it
-// attempts to read the RPC URL from the SAFE_PROXY_FACTORY_RPC_URL environment variable. If that is empty,
-// too, then it returns an error.
-func NewClient(rpcURL string) (*ethclient.Client, error) {
-	if rpcURL == "" {
-		rpcURL = os.Getenv("SAFE_PROXY_FACTORY_RPC_URL")
-	}
-
-	if rpcURL == "" {
-		return nil, ErrNoRPCURL
-	}
-
-	client, err := ethclient.Dial(rpcURL)
-	return client, err
-}
-
-// Creates a new context to be used when interacting with the chain client.
-func NewChainContext(timeout uint) (context.Context, context.CancelFunc) {
-	baseCtx := context.Background()
-	parsedTimeout := time.Duration(timeout) * time.Second
-	ctx, cancel := context.WithTimeout(baseCtx, parsedTimeout)
-	return ctx, cancel
-}
-
-// Unlocks a key from a keystore (byte contents of a keystore file) with the given password.
-func UnlockKeystore(keystoreData []byte, password string) (*keystore.Key, error) {
-	key, err := keystore.DecryptKey(keystoreData, password)
-	return key, err
-}
-
-// Loads a key from file, prompting the user for the password if it is not provided as a function argument.
-func KeyFromFile(keystoreFile string, password string) (*keystore.Key, error) {
-	var emptyKey *keystore.Key
-	keystoreContent, readErr := os.ReadFile(keystoreFile)
-	if readErr != nil {
-		return emptyKey, readErr
-	}
-
-	// If password is "", prompt user for password.
-	if password == "" {
-		fmt.Printf("Please provide a password for keystore (%s): ", keystoreFile)
-		passwordRaw, inputErr := term.ReadPassword(int(os.Stdin.Fd()))
-		if inputErr != nil {
-			return emptyKey, fmt.Errorf("error reading password: %s", inputErr.Error())
-		}
-		fmt.Print("\n")
-		password = string(passwordRaw)
-	}
-
-	key, err := UnlockKeystore(keystoreContent, password)
-	return key, err
-}
-
-// This method is used to set the parameters on a view call from command line arguments (represented mostly as
-// strings).
-func SetCallParametersFromArgs(opts *bind.CallOpts, pending bool, fromAddress, blockNumber string) {
-	if pending {
-		opts.Pending = true
-	}
-
-	if fromAddress != "" {
-		opts.From = common.HexToAddress(fromAddress)
-	}
-
-	if blockNumber != "" {
-		opts.BlockNumber = new(big.Int)
-		opts.BlockNumber.SetString(blockNumber, 0)
-	}
-}
-
-// This method is used to set the parameters on a transaction from command line arguments (represented mostly as
-// strings).
-func SetTransactionParametersFromArgs(opts *bind.TransactOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas string, gasLimit uint64, noSend bool) {
-	if nonce != "" {
-		opts.Nonce = new(big.Int)
-		opts.Nonce.SetString(nonce, 0)
-	}
-
-	if value != "" {
-		opts.Value = new(big.Int)
-		opts.Value.SetString(value, 0)
-	}
-
-	if gasPrice != "" {
-		opts.GasPrice = new(big.Int)
-		opts.GasPrice.SetString(gasPrice, 0)
-	}
-
-	if maxFeePerGas != "" {
-		opts.GasFeeCap = new(big.Int)
-		opts.GasFeeCap.SetString(maxFeePerGas, 0)
-	}
-
-	if maxPriorityFeePerGas != "" {
-		opts.GasTipCap = new(big.Int)
-		opts.GasTipCap.SetString(maxPriorityFeePerGas, 0)
-	}
-
-	if gasLimit != 0 {
-		opts.GasLimit = gasLimit
-	}
-
-	opts.NoSend = noSend
-}
-
-func CreateSafeProxyFactoryCommand() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "safe-proxy-factory",
-		Short: "Interact with the SafeProxyFactory contract",
-		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Help()
-		},
-	}
-
-	cmd.SetOut(os.Stdout)
-
-	DeployGroup := &cobra.Group{
-		ID: "deploy", Title: "Commands which deploy contracts",
-	}
-	cmd.AddGroup(DeployGroup)
-	ViewGroup := &cobra.Group{
-		ID: "view", Title: "Commands which view contract state",
-	}
-	TransactGroup := &cobra.Group{
-		ID: "transact", Title: "Commands which submit transactions",
-	}
-	cmd.AddGroup(ViewGroup, TransactGroup)
-
-	cmdDeploySafeProxyFactory := CreateSafeProxyFactoryDeploymentCommand()
-	cmdDeploySafeProxyFactory.GroupID = DeployGroup.ID
-	cmd.AddCommand(cmdDeploySafeProxyFactory)
-
-	cmdViewGetChainId := CreateGetChainIdCommand()
-	cmdViewGetChainId.GroupID = ViewGroup.ID
-	cmd.AddCommand(cmdViewGetChainId)
-	cmdViewProxyCreationCode := CreateProxyCreationCodeCommand()
-	cmdViewProxyCreationCode.GroupID = ViewGroup.ID
-	cmd.AddCommand(cmdViewProxyCreationCode)
-
-	cmdTransactCreateChainSpecificProxyWithNonce := CreateCreateChainSpecificProxyWithNonceCommand()
-	cmdTransactCreateChainSpecificProxyWithNonce.GroupID = TransactGroup.ID
-	cmd.AddCommand(cmdTransactCreateChainSpecificProxyWithNonce)
-	cmdTransactCreateProxyWithCallback := CreateCreateProxyWithCallbackCommand()
-	cmdTransactCreateProxyWithCallback.GroupID = TransactGroup.ID
-	cmd.AddCommand(cmdTransactCreateProxyWithCallback)
-	cmdTransactCreateProxyWithNonce := CreateCreateProxyWithNonceCommand()
-	cmdTransactCreateProxyWithNonce.GroupID = TransactGroup.ID
-	cmd.AddCommand(cmdTransactCreateProxyWithNonce)
-
-	return cmd
-}
-
-// SafeOperationType represents the type of operation for a Safe transaction
-type SafeOperationType uint8
-
-const (
-	Call         SafeOperationType = 0
-	DelegateCall SafeOperationType = 1
-)
-
-// String returns the string representation of the SafeOperationType
-func (o SafeOperationType) String() string {
-	switch o {
-	case Call:
-		return "Call"
-	case DelegateCall:
-		return "DelegateCall"
-	default:
-		return "Unknown"
-	}
-}
-
-// SafeTransactionData represents the data for a Safe transaction
-type SafeTransactionData struct {
-	To             string            `json:"to"`
-	Value          string            `json:"value"`
-	Data           string            `json:"data"`
-	Operation      SafeOperationType `json:"operation"`
-	SafeTxGas      uint64            `json:"safeTxGas"`
-	BaseGas        uint64            `json:"baseGas"`
-	GasPrice       string            `json:"gasPrice"`
-	GasToken       string            `json:"gasToken"`
-	RefundReceiver string            `json:"refundReceiver"`
-	Nonce          uint64            `json:"nonce"`
-	SafeTxHash     string            `json:"safeTxHash"`
-	Sender         string            `json:"sender"`
-	Signature      string            `json:"signature"`
-	Origin         string            `json:"origin"`
-}
-
-const (
-	NativeTokenAddress = "0x0000000000000000000000000000000000000000"
-)
-
-func DeployWithSafe(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, factoryAddress common.Address, value *big.Int, safeApi string, deployBytecode []byte, safeOperationType SafeOperationType, salt [32]byte) error {
-	abi, err := CreateCall.CreateCallMetaData.GetAbi()
-	if err != nil {
-		return fmt.Errorf("failed to get ABI: %v", err)
-	}
-
-	safeCreateCallTxData, err := abi.Pack("performCreate2", value, deployBytecode, salt)
-	if err != nil {
-		return fmt.Errorf("failed to pack performCreate2 transaction: %v", err)
-	}
-
-	return CreateSafeProposal(client, key, safeAddress, factoryAddress, safeCreateCallTxData, value, safeApi, SafeOperationType(safeOperationType))
-}
-
-func CreateSafeProposal(client *ethclient.Client, key *keystore.Key, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeApi string, safeOperationType SafeOperationType) error {
-	chainID, err := client.ChainID(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %v", err)
-	}
-
-	// Create a new instance of the GnosisSafe contract
-	safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
-	if err != nil {
-		return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
-	}
-
-	// Fetch the current nonce from the Safe contract
-	nonce, err := safeInstance.Nonce(&bind.CallOpts{})
-	if err != nil {
-		return fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
-	}
-
-	safeTransactionData := SafeTransactionData{
-		To:             to.Hex(),
-		Value:          value.String(),
-		Data:           common.Bytes2Hex(data),
-		Operation:      safeOperationType,
-		SafeTxGas:      0,
-		BaseGas:        0,
-		GasPrice:       "0",
-		GasToken:       NativeTokenAddress,
-		RefundReceiver: NativeTokenAddress,
-		Nonce:          nonce.Uint64(),
-	}
-
-	// Calculate SafeTxHash
-	safeTxHash, err := CalculateSafeTxHash(safeAddress, safeTransactionData, chainID)
-	if err != nil {
-		return fmt.Errorf("failed to calculate SafeTxHash: %v", err)
-	}
-
-	// Sign the SafeTxHash
-	signature, err := crypto.Sign(safeTxHash.Bytes(), key.PrivateKey)
-	if err != nil {
-		return fmt.Errorf("failed to sign SafeTxHash: %v", err)
-	}
-
-	// Adjust V value for Ethereum's replay protection
-	signature[64] += 27
-
-	// Convert signature to hex
-	senderSignature := "0x" + common.Bytes2Hex(signature)
-
-	// Prepare the request body
-	requestBody := map[string]interface{}{
-		"to":             safeTransactionData.To,
-		"value":          safeTransactionData.Value,
-		"data":           "0x" + safeTransactionData.Data,
-		"operation":      int(safeTransactionData.Operation),
-		"safeTxGas":      fmt.Sprintf("%d", safeTransactionData.SafeTxGas),
-		"baseGas":        fmt.Sprintf("%d", safeTransactionData.BaseGas),
-		"gasPrice":       safeTransactionData.GasPrice,
-		"gasToken":       safeTransactionData.GasToken,
-		"refundReceiver": safeTransactionData.RefundReceiver,
-		"nonce":          fmt.Sprintf("%d", safeTransactionData.Nonce),
-		"safeTxHash":     safeTxHash.Hex(),
-		"sender":         key.Address.Hex(),
-		"signature":      senderSignature,
-		"origin":         fmt.Sprintf("{\"url\":\"%s\",\"name\":\"TokenSender Deployment\"}", safeApi),
-	}
-
-	// Marshal the request body to JSON
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %v", err)
-	}
-
-	// Send the request to the Safe Transaction Service
-	req, err := http.NewRequest("POST", safeApi, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	fmt.Println("Safe proposal created successfully")
-	return nil
-}
-
-func CalculateSafeTxHash(safeAddress common.Address, txData SafeTransactionData, chainID *big.Int) (common.Hash, error) {
-	domainSeparator := apitypes.TypedDataDomain{
-		ChainId:           (*math.HexOrDecimal256)(chainID),
-		VerifyingContract: safeAddress.Hex(),
-	}
-
-	typedData := apitypes.TypedData{
-		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{Name: "chainId", Type: "uint256"},
-				{Name: "verifyingContract", Type: "address"},
-			},
-			"SafeTx": []apitypes.Type{
-				{Name: "to", Type: "address"},
-				{Name: "value", Type: "uint256"},
-				{Name: "data", Type: "bytes"},
-				{Name: "operation", Type: "uint8"},
-				{Name: "safeTxGas", Type: "uint256"},
-				{Name: "baseGas", Type: "uint256"},
-				{Name: "gasPrice", Type: "uint256"},
-				{Name: "gasToken", Type: "address"},
-				{Name: "refundReceiver", Type: "address"},
-				{Name: "nonce", Type: "uint256"},
-			},
-		},
-		Domain:      domainSeparator,
-		PrimaryType: "SafeTx",
-		Message: apitypes.TypedDataMessage{
-			"to":             txData.To,
-			"value":          txData.Value,
-			"data":           "0x" + txData.Data,
-			"operation":      fmt.Sprintf("%d", txData.Operation),
-			"safeTxGas":      fmt.Sprintf("%d", txData.SafeTxGas),
-			"baseGas":        fmt.Sprintf("%d", txData.BaseGas),
-			"gasPrice":       txData.GasPrice,
-			"gasToken":       txData.GasToken,
-			"refundReceiver": txData.RefundReceiver,
-			"nonce":          fmt.Sprintf("%d", txData.Nonce),
-		},
-	}
-
-	typedDataHash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to hash typed data: %v", err)
-	}
-
-	return common.BytesToHash(typedDataHash), nil
-}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/signer"
+)
+
+func CreateBootstrapCmd() *cobra.Command {
+	var (
+		rpc          string
+		keyfile      string
+		password     string
+		artifactsDir string
+		dryRun       bool
+	)
+
+	bootstrapCmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Deploy the canonical Safe contracts on a chain",
+		Long: `Deploy the canonical Safe ` + bootstrapVersion + ` contracts (Safe, SafeL2, SafeProxyFactory, MultiSend, MultiSendCallOnly,
+CreateCall, SignMessageLib, CompatibilityFallbackHandler and SimulateTxAccessor) to their canonical
+addresses, through the Safe Singleton Factory at ` + SafeSingletonFactory.Hex() + `.
+
+The factory deploys with CREATE2, so each contract lands at an address which only depends on the salt and
+the contract's init code. The canonical contracts were deployed with a zero salt, so the init code must be
+byte-for-byte the canonical one. It is read from the Hardhat artifacts of the safe-smart-account
+submodule at v` + bootstrapVersion + ` (built with "make hardhat", or given with --artifacts), and refused unless every
+contract deploys to its canonical address in the builtin chain registry.
+
+The factory must already be deployed on the chain: Safe deploys it on request, with a pre-signed
+transaction of its own (see https://github.com/safe-global/safe-singleton-factory). Contracts which are
+already deployed are skipped. The code at every canonical address is checked against the code which the
+canonical init code deploys there. The deployment is simulated with eth_call state overrides, which the
+JSONRPC API must support.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if !dryRun && keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contracts, err := LoadCanonicalContracts(artifactsDir, SafeSingletonFactory, chains.Builtin().Versions[bootstrapVersion])
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, err := ethclient.Dial(rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			timeoutSeconds, _ := cmd.Flags().GetUint("timeout")
+			timeout := time.Duration(timeoutSeconds) * time.Second
+
+			var opts *bind.TransactOpts
+			if !dryRun {
+				txSigner, err := signer.Load(keyfile, password)
+				if err != nil {
+					return err
+				}
+				chainID, err := client.ChainID(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get chain ID: %v", err)
+				}
+				opts, err = txSigner.TransactOpts(chainID)
+				if err != nil {
+					return err
+				}
+			}
+
+			result, err := Bootstrap(ctx, client, opts, SafeSingletonFactory, contracts, timeout)
+			if err != nil {
+				return err
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	bootstrapCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	bootstrapCmd.Flags().StringVarP(&keyfile, "keyfile", "k", "", "Path to the keystore file of the account which pays for the deployments")
+	bootstrapCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the keystore file")
+	bootstrapCmd.Flags().StringVar(&artifactsDir, "artifacts", DefaultArtifactsDir, "Directory of the Hardhat artifacts of safe-smart-account v"+bootstrapVersion)
+	bootstrapCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report which contracts are missing without deploying them")
+
+	return bootstrapCmd
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+
+	"github.com/G7DAO/safes/chains"
+)
+
+// SafeSingletonFactory is the address of the Safe Singleton Factory
+// (https://github.com/safe-global/safe-singleton-factory), through which the canonical Safe 1.4.1
+// contracts were deployed. Calling it with a 32 byte salt followed by init code deploys the init code with
+// CREATE2, so the address of the contract only depends on the salt and the init code.
+var SafeSingletonFactory = common.HexToAddress("0x914d7Fec6aaC8cd542e72Bca78B30650d45643d7")
+
+// The runtime code of the Safe Singleton Factory, which is the one of the deterministic deployment proxy
+// (https://github.com/Arachnid/deterministic-deployment-proxy). Safe deploys the factory on each chain
+// with a pre-signed transaction of its own.
+var singletonFactoryCode = common.FromHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+// The salt with which the canonical Safe 1.4.1 contracts were deployed.
+var canonicalSalt [32]byte
+
+// The Safe version whose canonical contracts bootstrap deploys.
+const bootstrapVersion = "1.4.1"
+
+// DefaultArtifactsDir is where "make hardhat" builds the artifacts of the safe-smart-account submodule.
+const DefaultArtifactsDir = "safe-smart-account/build/artifacts"
+
+// BootstrapContract is a contract which bootstrap deploys.
+type BootstrapContract struct {
+	Name string
+	// Artifact is the path of the Hardhat artifact of the contract, relative to the artifacts directory.
+	Artifact string
+	// Canonical returns the canonical address of the contract in a set of registry contracts.
+	Canonical func(chains.Contracts) string
+}
+
+// The contracts which bootstrap deploys, in deployment order: the singletons, the proxy factory and the
+// supporting libraries.
+var bootstrapContracts = []BootstrapContract{
+	{"Safe", "contracts/Safe.sol/Safe.json", func(c chains.Contracts) string { return c.Singleton }},
+	{"SafeL2", "contracts/SafeL2.sol/SafeL2.json", func(c chains.Contracts) string { return c.SingletonL2 }},
+	{"SafeProxyFactory", "contracts/proxies/SafeProxyFactory.sol/SafeProxyFactory.json", func(c chains.Contracts) string { return c.ProxyFactory }},
+	{"MultiSend", "contracts/libraries/MultiSend.sol/MultiSend.json", func(c chains.Contracts) string { return c.MultiSend }},
+	{"MultiSendCallOnly", "contracts/libraries/MultiSendCallOnly.sol/MultiSendCallOnly.json", func(c chains.Contracts) string { return c.MultiSendCallOnly }},
+	{"CreateCall", "contracts/libraries/CreateCall.sol/CreateCall.json", func(c chains.Contracts) string { return c.CreateCall }},
+	{"SignMessageLib", "contracts/libraries/SignMessageLib.sol/SignMessageLib.json", func(c chains.Contracts) string { return c.SignMessageLib }},
+	{"CompatibilityFallbackHandler", "contracts/handler/CompatibilityFallbackHandler.sol/CompatibilityFallbackHandler.json", func(c chains.Contracts) string { return c.FallbackHandler }},
+	{"SimulateTxAccessor", "contracts/accessors/SimulateTxAccessor.sol/SimulateTxAccessor.json", func(c chains.Contracts) string { return c.SimulateTxAccessor }},
+}
+
+// CanonicalContract is a contract together with the init code which deploys it to its canonical address.
+type CanonicalContract struct {
+	Name     string
+	Address  common.Address
+	InitCode []byte
+}
+
+// Returns the address at which the factory deploys initCode with the given salt.
+func DeterministicAddress(factory common.Address, salt [32]byte, initCode []byte) common.Address {
+	return crypto.CreateAddress2(factory, salt, crypto.Keccak256(initCode))
+}
+
+// Loads the init code of the bootstrap contracts from the Hardhat artifacts in artifactsDir, and checks
+// that the factory deploys each of them to its canonical address with the canonical salt. The address is
+// derived from the hash of the init code, so init code which differs from the canonical one in a single
+// byte (a different compiler, settings or source path) is refused.
+func LoadCanonicalContracts(artifactsDir string, factory common.Address, canonical chains.Contracts) ([]CanonicalContract, error) {
+	var contracts []CanonicalContract
+	for _, contract := range bootstrapContracts {
+		canonicalAddress := contract.Canonical(canonical)
+		if !common.IsHexAddress(canonicalAddress) {
+			return nil, fmt.Errorf("no canonical address of %s for Safe %s", contract.Name, bootstrapVersion)
+		}
+		artifact, err := LoadArtifact(filepath.Join(artifactsDir, contract.Artifact))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v (build the Safe %s artifacts with \"make hardhat\", or pass --artifacts)", contract.Name, err, bootstrapVersion)
+		}
+		initCode, err := artifact.Link(nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", contract.Name, err)
+		}
+		address := DeterministicAddress(factory, canonicalSalt, initCode)
+		if address != common.HexToAddress(canonicalAddress) {
+			return nil, fmt.Errorf("the init code of %s is not the canonical Safe %s init code: it deploys to %s instead of %s", contract.Name, bootstrapVersion, address.Hex(), canonicalAddress)
+		}
+		contracts = append(contracts, CanonicalContract{Name: contract.Name, Address: address, InitCode: initCode})
+	}
+	return contracts, nil
+}
+
+// BootstrappedContract reports what bootstrap did for a single contract.
+type BootstrappedContract struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	// Status is "deployed", "exists" or, for dry runs, "missing".
+	Status          string `json:"status"`
+	CodeHash        string `json:"codeHash"`
+	TransactionHash string `json:"transactionHash,omitempty"`
+}
+
+// BootstrapResult is the result of the bootstrap command.
+type BootstrapResult struct {
+	Factory   string                 `json:"factory"`
+	Salt      string                 `json:"salt"`
+	Version   string                 `json:"version"`
+	Contracts []BootstrappedContract `json:"contracts"`
+}
+
+func (r BootstrapResult) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Safe %s through %s (salt %s)\n", r.Version, r.Factory, r.Salt)
+	for _, contract := range r.Contracts {
+		fmt.Fprintf(&b, "%s: %s (%s, code hash %s)\n", contract.Name, contract.Address, contract.Status, contract.CodeHash)
+		if contract.TransactionHash != "" {
+			fmt.Fprintf(&b, "\tTransaction: %s\n", contract.TransactionHash)
+		}
+	}
+	return b.String()
+}
+
+// Waits for a transaction to be mined and checks that it succeeded.
+func waitForTransaction(ctx context.Context, backend bind.DeployBackend, tx *types.Transaction, timeout time.Duration) (*types.Receipt, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(waitCtx, backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// Returns the runtime code which initCode deploys, by simulating its deployment at no particular address.
+// This is only the deployed code if the constructor does not depend on address(this) or msg.sender, as
+// is the case for the Safe singletons and proxies.
+func expectedRuntimeCode(ctx context.Context, backend bind.ContractCaller, initCode []byte) ([]byte, error) {
+	runtimeCode, err := backend.CallContract(ctx, ethereum.CallMsg{Data: initCode}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate deployment: %v", err)
+	}
+	return runtimeCode, nil
+}
+
+// Returns the runtime code which the factory deploys from initCode at address. The deployment is
+// simulated with a call from the factory to the address, whose code is overridden with the init code, so
+// that the constructor sees the same address(this) and msg.sender as in the deployment. MultiSend and
+// SimulateTxAccessor keep address(this) in an immutable, which is part of their runtime code.
+func deployedRuntimeCode(ctx context.Context, client *ethclient.Client, factory, address common.Address, initCode []byte) ([]byte, error) {
+	overrides := map[common.Address]gethclient.OverrideAccount{address: {Code: initCode}}
+	runtimeCode, err := gethclient.New(client.Client()).CallContract(ctx, ethereum.CallMsg{From: factory, To: &address}, nil, &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate deployment: %v", err)
+	}
+	return runtimeCode, nil
+}
+
+// Deploys the canonical Safe contracts through the Safe Singleton Factory at factory, skipping contracts
+// which are already deployed. The code at every canonical address is checked against the code which the
+// canonical init code deploys there. If opts is nil, nothing is deployed and missing contracts are only
+// reported.
+func Bootstrap(ctx context.Context, client *ethclient.Client, opts *bind.TransactOpts, factory common.Address, contracts []CanonicalContract, timeout time.Duration) (*BootstrapResult, error) {
+	factoryCode, err := client.CodeAt(ctx, factory, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code of the Safe Singleton Factory %s: %v", factory.Hex(), err)
+	}
+	if len(factoryCode) == 0 && opts != nil {
+		return nil, fmt.Errorf("no Safe Singleton Factory at %s (Safe deploys it on request, see https://github.com/safe-global/safe-singleton-factory)", factory.Hex())
+	}
+	if len(factoryCode) > 0 && !bytes.Equal(factoryCode, singletonFactoryCode) {
+		return nil, fmt.Errorf("the code at %s is not the code of the Safe Singleton Factory", factory.Hex())
+	}
+
+	result := &BootstrapResult{Factory: factory.Hex(), Salt: common.Hash(canonicalSalt).Hex(), Version: bootstrapVersion}
+	for _, contract := range contracts {
+		expectedCode, err := deployedRuntimeCode(ctx, client, factory, contract.Address, contract.InitCode)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", contract.Name, err)
+		}
+		deployed := BootstrappedContract{Name: contract.Name, Address: contract.Address.Hex(), Status: "exists", CodeHash: crypto.Keccak256Hash(expectedCode).Hex()}
+
+		code, err := client.CodeAt(ctx, contract.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get code of %s: %v", contract.Address.Hex(), err)
+		}
+		if len(code) == 0 {
+			if opts == nil {
+				deployed.Status = "missing"
+				result.Contracts = append(result.Contracts, deployed)
+				continue
+			}
+
+			data := append(append([]byte{}, canonicalSalt[:]...), contract.InitCode...)
+			tx, err := bind.NewBoundContract(factory, abi.ABI{}, client, client, client).RawTransact(opts, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deploy %s: %v", contract.Name, err)
+			}
+			if _, err := waitForTransaction(ctx, client, tx, timeout); err != nil {
+				return nil, fmt.Errorf("failed to deploy %s: %v", contract.Name, err)
+			}
+			deployed.Status = "deployed"
+			deployed.TransactionHash = tx.Hash().Hex()
+
+			code, err = client.CodeAt(ctx, contract.Address, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get code of %s: %v", contract.Address.Hex(), err)
+			}
+		}
+
+		if codeHash := crypto.Keccak256Hash(code); codeHash.Hex() != deployed.CodeHash {
+			return nil, fmt.Errorf("%s at %s has code hash %s, expected the canonical %s", contract.Name, contract.Address.Hex(), codeHash.Hex(), deployed.CodeHash)
+		}
+		result.Contracts = append(result.Contracts, deployed)
+	}
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/moonstream-to/seer/bindings/CreateCall"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/bindings/SafeProxyFactory"
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// Writes Hardhat artifacts for the bootstrap contracts to a temporary directory: the contracts in the
+// bindings, and stand-ins for the libraries which deploy a single byte of code. MultiSend and
+// SimulateTxAccessor keep address(this) in an immutable, so their stand-ins deploy their own address and
+// their deployer. Returns the directory and the addresses at which the factory deploys the contracts.
+func writeBootstrapArtifacts(t *testing.T, factory common.Address) (string, chains.Contracts) {
+	t.Helper()
+	initCodes := map[string][]byte{
+		"Safe":             common.FromHex(Safe.SafeBin),
+		"SafeL2":           common.FromHex(SafeL2.SafeL2Bin),
+		"SafeProxyFactory": common.FromHex(SafeProxyFactory.SafeProxyFactoryBin),
+		"CreateCall":       common.FromHex(CreateCall.CreateCallBin),
+	}
+	for i, contract := range bootstrapContracts {
+		switch _, ok := initCodes[contract.Name]; {
+		case ok:
+		case contract.Name == "MultiSend" || contract.Name == "SimulateTxAccessor":
+			// PUSH1 i POP ADDRESS PUSH1 0 MSTORE CALLER PUSH1 0x20 MSTORE PUSH1 0x40 PUSH1 0 RETURN
+			initCodes[contract.Name] = []byte{0x60, byte(i), 0x50, 0x30, 0x60, 0x00, 0x52, 0x33, 0x60, 0x20, 0x52, 0x60, 0x40, 0x60, 0x00, 0xf3}
+		default:
+			// PUSH1 i PUSH1 0 MSTORE8 PUSH1 1 PUSH1 0 RETURN
+			initCodes[contract.Name] = []byte{0x60, byte(i), 0x60, 0x00, 0x53, 0x60, 0x01, 0x60, 0x00, 0xf3}
+		}
+	}
+
+	dir := t.TempDir()
+	addresses := map[string]string{}
+	for _, contract := range bootstrapContracts {
+		path := filepath.Join(dir, contract.Artifact)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("could not create artifact directory: %v", err)
+		}
+		artifact, err := json.Marshal(map[string]interface{}{"abi": []interface{}{}, "bytecode": hexutil.Encode(initCodes[contract.Name])})
+		if err != nil {
+			t.Fatalf("could not encode artifact: %v", err)
+		}
+		if err := os.WriteFile(path, artifact, 0600); err != nil {
+			t.Fatalf("could not write artifact: %v", err)
+		}
+		addresses[contract.Name] = DeterministicAddress(factory, canonicalSalt, initCodes[contract.Name]).Hex()
+	}
+	return dir, chains.Contracts{
+		Singleton:          addresses["Safe"],
+		SingletonL2:        addresses["SafeL2"],
+		ProxyFactory:       addresses["SafeProxyFactory"],
+		MultiSend:          addresses["MultiSend"],
+		MultiSendCallOnly:  addresses["MultiSendCallOnly"],
+		CreateCall:         addresses["CreateCall"],
+		SignMessageLib:     addresses["SignMessageLib"],
+		FallbackHandler:    addresses["CompatibilityFallbackHandler"],
+		SimulateTxAccessor: addresses["SimulateTxAccessor"],
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	chain := simtest.New(t, 1)
+	chain.AutoCommit(t, 50*time.Millisecond)
+	ctx := context.Background()
+	client, err := ethclient.Dial(chain.Endpoint)
+	if err != nil {
+		t.Fatalf("could not connect to chain: %v", err)
+	}
+	defer client.Close()
+	factory := chain.DeployCode(t, singletonFactoryCode)
+	artifactsDir, addresses := writeBootstrapArtifacts(t, factory)
+
+	// Init code which does not deploy to the canonical addresses is refused.
+	if err := runCLI(t, nil, "bootstrap", "--rpc", chain.Endpoint, "--artifacts", artifactsDir, "--dry-run"); err == nil || !strings.Contains(err.Error(), "is not the canonical Safe 1.4.1 init code") {
+		t.Fatalf("expected non-canonical init code to be refused, got %v", err)
+	}
+	if err := runCLI(t, nil, "bootstrap", "--rpc", chain.Endpoint, "--artifacts", t.TempDir(), "--dry-run"); err == nil || !strings.Contains(err.Error(), "make hardhat") {
+		t.Fatalf("expected missing artifacts to be reported, got %v", err)
+	}
+
+	// The test treats the addresses at which its factory deploys the artifacts as canonical.
+	contracts, err := LoadCanonicalContracts(artifactsDir, factory, addresses)
+	if err != nil {
+		t.Fatalf("could not load contracts: %v", err)
+	}
+	if len(contracts) != len(bootstrapContracts) {
+		t.Fatalf("loaded %d contracts, expected %d", len(contracts), len(bootstrapContracts))
+	}
+	planned, err := Bootstrap(ctx, client, nil, factory, contracts, 10*time.Second)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	for i, contract := range planned.Contracts {
+		if contract.Status != "missing" || common.HexToAddress(contract.Address) != contracts[i].Address {
+			t.Fatalf("unexpected dry run result for %s: %+v", contracts[i].Name, contract)
+		}
+	}
+
+	// The first run deploys all the contracts.
+	opts := chain.TransactOpts(t, 0)
+	result, err := Bootstrap(ctx, client, opts, factory, contracts, 10*time.Second)
+	if err != nil {
+		t.Fatalf("bootstrap failed: %v", err)
+	}
+	for i, contract := range result.Contracts {
+		if contract.Status != "deployed" || contract.TransactionHash == "" || contract.Address != planned.Contracts[i].Address || contract.CodeHash != planned.Contracts[i].CodeHash {
+			t.Fatalf("unexpected bootstrap result for %s: %+v", contracts[i].Name, contract)
+		}
+	}
+	multiSendCode, err := client.CodeAt(ctx, contracts[3].Address, nil)
+	if err != nil || common.BytesToAddress(multiSendCode[:32]) != contracts[3].Address || common.BytesToAddress(multiSendCode[32:]) != factory {
+		t.Fatalf("MultiSend stand-in was not deployed by the factory at its address: %x (%v)", multiSendCode, err)
+	}
+	version, err := safetx.DetectVersion(ctx, client, contracts[0].Address)
+	if err != nil || version.String() != bootstrapVersion {
+		t.Fatalf("deployed singleton has version %s (%v)", version, err)
+	}
+
+	// The second run finds every contract in place.
+	rerun, err := Bootstrap(ctx, client, opts, factory, contracts, 10*time.Second)
+	if err != nil {
+		t.Fatalf("second bootstrap failed: %v", err)
+	}
+	for i, contract := range rerun.Contracts {
+		if contract.Status != "exists" || contract.TransactionHash != "" {
+			t.Fatalf("unexpected result of the second bootstrap for %s: %+v", contracts[i].Name, contract)
+		}
+	}
+
+	// Other code at a canonical address, or a factory with other code, is refused.
+	tampered := append([]CanonicalContract(nil), contracts...)
+	tampered[3].InitCode = []byte{0x60, 0xff, 0x60, 0x00, 0x53, 0x60, 0x01, 0x60, 0x00, 0xf3}
+	if _, err := Bootstrap(ctx, client, nil, factory, tampered, 10*time.Second); err == nil || !strings.Contains(err.Error(), "expected the canonical") {
+		t.Fatalf("expected other code at a canonical address to be refused, got %v", err)
+	}
+	if _, err := Bootstrap(ctx, client, opts, contracts[3].Address, contracts, 10*time.Second); err == nil || !strings.Contains(err.Error(), "not the code of the Safe Singleton Factory") {
+		t.Fatalf("expected a factory with other code to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+)
+
+func CreateChainsCmd() *cobra.Command {
+	chainsCmd := &cobra.Command{
+		Use:   "chains",
+		Short: "Inspect the chain registry",
+		Long: `Inspect the chain registry, which maps chain IDs to their Safe Transaction Service and Safe contract addresses.
+
+The registry is built into safes. You can add chains or override entries by creating a registry file at
+the path shown by "chains path" (or by pointing the SAFES_CHAINS_FILE environment variable at one). The file
+has the same shape as the builtin registry, for example:
+
+	{
+		"chains": {
+			"13746": {
+				"name": "game7-testnet",
+				"transactionService": "https://safe-transaction.example.com",
+				"contracts": {
+					"1.3.0": {"createCall": "0x..."}
+				}
+			}
+		}
+	}`,
+	}
+
+	chainsCmd.AddCommand(createListChainsCmd())
+	chainsCmd.AddCommand(createShowChainCmd())
+	chainsCmd.AddCommand(createChainsPathCmd())
+
+	return chainsCmd
+}
+
+func createListChainsCmd() *cobra.Command {
+	listChainsCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the chains in the registry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registry := chains.Default()
+			result := ChainsResult{}
+			for _, chainID := range registry.ChainIDs() {
+				chain, _ := registry.Chain(chainID)
+				result = append(result, ChainResult{
+					ChainID:            chainID.String(),
+					Name:               chain.Name,
+					Known:              true,
+					TransactionService: registry.TransactionService(chainID),
+					Versions:           chain.Versions,
+				})
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	return listChainsCmd
+}
+
+func createShowChainCmd() *cobra.Command {
+	var (
+		chainIDRaw string
+		version    string
+	)
+
+	showChainCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the registry entry for a chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID, ok := new(big.Int).SetString(chainIDRaw, 0)
+			if !ok {
+				return fmt.Errorf("invalid chain ID: %s", chainIDRaw)
+			}
+
+			registry := chains.Default()
+			chain, known := registry.Chain(chainID)
+			result := ChainResult{
+				ChainID:            chainID.String(),
+				Name:               chain.Name,
+				Known:              known,
+				TransactionService: registry.TransactionService(chainID),
+				Versions:           chain.Versions,
+				Contracts:          map[string]chains.Contracts{},
+			}
+
+			versions := []string{version}
+			if version == "" {
+				versions = chain.Versions
+				if len(versions) == 0 {
+					for v := range registry.Versions {
+						versions = append(versions, v)
+					}
+					sort.Strings(versions)
+				}
+			}
+
+			for _, v := range versions {
+				contracts, err := registry.Contracts(chainID, v)
+				if err != nil {
+					return err
+				}
+				result.Contracts[v] = contracts
+			}
+
+			return output.Print(cmd, result)
+		},
+	}
+
+	showChainCmd.Flags().StringVar(&chainIDRaw, "chain-id", "", "Chain ID")
+	showChainCmd.Flags().StringVar(&version, "version", "", "Only show contracts for this Safe version")
+	showChainCmd.MarkFlagRequired("chain-id")
+
+	return showChainCmd
+}
+
+func createChainsPathCmd() *cobra.Command {
+	chainsPathCmd := &cobra.Command{
+		Use:   "path",
+		Short: "Print the path of the user chain registry file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return output.Print(cmd, PathResult{Path: chains.RegistryFile()})
+		},
+	}
+
+	return chainsPathCmd
+}
+
+// ChainResult describes a chain registry entry.
+type ChainResult struct {
+	ChainID            string                      `json:"chainId"`
+	Name               string                      `json:"name"`
+	Known              bool                        `json:"known"`
+	TransactionService string                      `json:"transactionService"`
+	Versions           []string                    `json:"versions,omitempty"`
+	Contracts          map[string]chains.Contracts `json:"contracts,omitempty"`
+}
+
+func (r ChainResult) Text() string {
+	var builder strings.Builder
+	if !r.Known {
+		fmt.Fprintf(&builder, "Chain %s is not in the registry, canonical addresses are shown\n", r.ChainID)
+	} else {
+		fmt.Fprintf(&builder, "Chain: %s (%s)\n", r.ChainID, r.Name)
+		fmt.Fprintf(&builder, "Transaction Service: %s\n", r.TransactionService)
+	}
+
+	versions := make([]string, 0, len(r.Contracts))
+	for v := range r.Contracts {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+
+	for _, v := range versions {
+		contracts := r.Contracts[v]
+		fmt.Fprintf(&builder, "\nSafe %s\n", v)
+		fmt.Fprintf(&builder, "  Singleton:            %s\n", contracts.Singleton)
+		fmt.Fprintf(&builder, "  SingletonL2:          %s\n", contracts.SingletonL2)
+		fmt.Fprintf(&builder, "  ProxyFactory:         %s\n", contracts.ProxyFactory)
+		fmt.Fprintf(&builder, "  MultiSend:            %s\n", contracts.MultiSend)
+		fmt.Fprintf(&builder, "  MultiSendCallOnly:    %s\n", contracts.MultiSendCallOnly)
+		fmt.Fprintf(&builder, "  CreateCall:           %s\n", contracts.CreateCall)
+		fmt.Fprintf(&builder, "  FallbackHandler:      %s\n", contracts.FallbackHandler)
+		fmt.Fprintf(&builder, "  SignMessageLib:       %s\n", contracts.SignMessageLib)
+		fmt.Fprintf(&builder, "  SimulateTxAccessor:   %s\n", contracts.SimulateTxAccessor)
+		fmt.Fprintf(&builder, "  SafeMigration:        %s\n", contracts.SafeMigration)
+	}
+
+	return builder.String()
+}
+
+// ChainsResult is the list of chains in the registry.
+type ChainsResult []ChainResult
+
+func (r ChainsResult) Text() string {
+	var builder strings.Builder
+	for _, chain := range r {
+		fmt.Fprintf(&builder, "%s\t%s\t%s\n", chain.ChainID, chain.Name, chain.TransactionService)
+	}
+	return builder.String()
+}
+
+// PathResult holds the path of a file used by safes.
+type PathResult struct {
+	Path string `json:"path"`
+}
+
+func (r PathResult) Text() string {
+	return r.Path + "\n"
+}
@@ -0,0 +1,414 @@
+// Package chains contains the registry of chains known to safes: their Safe Transaction Service
+// endpoints and the addresses of the Safe contracts deployed on them, for each Safe version.
+//
+// A registry is built into the binary (see chains.json). Users can extend or override it with a
+// registry file of the same shape, located at $XDG_CONFIG_HOME/safes/chains.json by default or at the
+// path given by the SAFES_CHAINS_FILE environment variable.
+package chains
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed chains.json
+var builtinRegistry []byte
+
+// DefaultVersion is the Safe version whose contracts are used when a command needs a contract address
+// and the user has not specified one, on chains on which it is deployed.
+const DefaultVersion = "1.3.0"
+
+// RegistryFileEnvVar is the environment variable which may be used to override the location of the
+// user registry file.
+const RegistryFileEnvVar = "SAFES_CHAINS_FILE"
+
+// ClientGatewayURL is the Safe Client Gateway, which is used to talk to the Safe Transaction Service of
+// chains which do not specify a transactionService in the registry.
+const ClientGatewayURL = "https://safe-client.safe.global"
+
+// Contracts holds the addresses of the Safe contracts for a single Safe version.
+type Contracts struct {
+	Singleton          string `json:"singleton,omitempty"`
+	SingletonL2        string `json:"singletonL2,omitempty"`
+	ProxyFactory       string `json:"proxyFactory,omitempty"`
+	MultiSend          string `json:"multiSend,omitempty"`
+	MultiSendCallOnly  string `json:"multiSendCallOnly,omitempty"`
+	CreateCall         string `json:"createCall,omitempty"`
+	FallbackHandler    string `json:"fallbackHandler,omitempty"`
+	SignMessageLib     string `json:"signMessageLib,omitempty"`
+	SimulateTxAccessor string `json:"simulateTxAccessor,omitempty"`
+	SafeMigration      string `json:"safeMigration,omitempty"`
+}
+
+// Chain describes a single chain in the registry.
+type Chain struct {
+	Name               string `json:"name,omitempty"`
+	TransactionService string `json:"transactionService,omitempty"`
+	// Versions lists the Safe versions deployed on the chain. An empty list means that the chain makes
+	// no claims about which versions are deployed on it.
+	Versions []string `json:"versions,omitempty"`
+	// Contracts overrides the canonical contract addresses for the chain, keyed by Safe version.
+	Contracts map[string]Contracts `json:"contracts,omitempty"`
+}
+
+// Registry maps chain IDs (as decimal strings) to chains, and Safe versions to their canonical
+// contract addresses.
+type Registry struct {
+	Versions map[string]Contracts `json:"versions"`
+	Chains   map[string]Chain     `json:"chains"`
+
+	// transactionService overrides the Safe Transaction Service of every chain.
+	transactionService string
+}
+
+var (
+	defaultRegistry     *Registry
+	defaultRegistryErr  error
+	defaultRegistryOnce sync.Once
+)
+
+// Returns the path of the user registry file.
+func RegistryFile() string {
+	if path := os.Getenv(RegistryFileEnvVar); path != "" {
+		return path
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "safes", "chains.json")
+}
+
+// Parses a registry from its JSON representation.
+func Parse(data []byte) (*Registry, error) {
+	registry := &Registry{}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, err
+	}
+	if registry.Versions == nil {
+		registry.Versions = map[string]Contracts{}
+	}
+	if registry.Chains == nil {
+		registry.Chains = map[string]Chain{}
+	}
+	for chainID := range registry.Chains {
+		if _, ok := new(big.Int).SetString(chainID, 10); !ok {
+			return nil, fmt.Errorf("invalid chain ID in registry: %s", chainID)
+		}
+	}
+	return registry, nil
+}
+
+// Builtin returns the registry that is built into the binary.
+func Builtin() *Registry {
+	registry, err := Parse(builtinRegistry)
+	if err != nil {
+		panic(fmt.Sprintf("invalid builtin chain registry: %v", err))
+	}
+	return registry
+}
+
+// Loads the builtin registry and merges the registry file at the given path into it. If path is
+// empty, the default registry file is used. It is not an error for the registry file not to exist.
+func Load(path string) (*Registry, error) {
+	registry := Builtin()
+
+	if path == "" {
+		path = RegistryFile()
+	}
+	if path == "" {
+		return registry, nil
+	}
+
+	data, readErr := os.ReadFile(path)
+	if errors.Is(readErr, os.ErrNotExist) {
+		return registry, nil
+	} else if readErr != nil {
+		return registry, fmt.Errorf("could not read chain registry file (%s): %v", path, readErr)
+	}
+
+	userRegistry, parseErr := Parse(data)
+	if parseErr != nil {
+		return registry, fmt.Errorf("could not parse chain registry file (%s): %v", path, parseErr)
+	}
+
+	registry.Merge(userRegistry)
+	return registry, nil
+}
+
+// Default returns the registry that commands use to pick their defaults. It is loaded once, from the
+// default registry file. If the file cannot be loaded, a warning is printed and the builtin registry
+// is used.
+func Default() *Registry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistry, defaultRegistryErr = Load("")
+		if defaultRegistryErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v -- using builtin chain registry\n", defaultRegistryErr)
+		}
+	})
+	return defaultRegistry
+}
+
+// Merges other into the registry. Non-empty values in other take precedence.
+func (r *Registry) Merge(other *Registry) {
+	for version, contracts := range other.Versions {
+		r.Versions[version] = r.Versions[version].merge(contracts)
+	}
+
+	for chainID, chain := range other.Chains {
+		current := r.Chains[chainID]
+		if chain.Name != "" {
+			current.Name = chain.Name
+		}
+		if chain.TransactionService != "" {
+			current.TransactionService = chain.TransactionService
+		}
+		if len(chain.Versions) > 0 {
+			current.Versions = chain.Versions
+		}
+		if len(chain.Contracts) > 0 {
+			merged := map[string]Contracts{}
+			for version, contracts := range current.Contracts {
+				merged[version] = contracts
+			}
+			for version, contracts := range chain.Contracts {
+				merged[version] = merged[version].merge(contracts)
+			}
+			current.Contracts = merged
+		}
+		r.Chains[chainID] = current
+	}
+}
+
+// Chain returns the registry entry for the given chain ID.
+func (r *Registry) Chain(chainID *big.Int) (Chain, bool) {
+	chain, ok := r.Chains[chainID.String()]
+	return chain, ok
+}
+
+// ChainIDs returns the IDs of all the chains in the registry, in ascending order.
+func (r *Registry) ChainIDs() []*big.Int {
+	chainIDs := make([]*big.Int, 0, len(r.Chains))
+	for chainID := range r.Chains {
+		parsed, _ := new(big.Int).SetString(chainID, 10)
+		chainIDs = append(chainIDs, parsed)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i].Cmp(chainIDs[j]) < 0 })
+	return chainIDs
+}
+
+// Contracts returns the addresses of the Safe contracts of the given version on the given chain.
+// Chains which are not in the registry are assumed to have the canonical deployments.
+func (r *Registry) Contracts(chainID *big.Int, version string) (Contracts, error) {
+	contracts, ok := r.Versions[version]
+	chain, chainOK := r.Chain(chainID)
+	if chainOK {
+		if len(chain.Versions) > 0 && !containsString(chain.Versions, version) {
+			return Contracts{}, fmt.Errorf("Safe version %s is not deployed on chain %s (%s) according to the chain registry", version, chainID.String(), chain.Name)
+		}
+		if override, overrideOK := chain.Contracts[version]; overrideOK {
+			contracts = contracts.merge(override)
+			ok = true
+		}
+	}
+
+	if !ok {
+		return Contracts{}, fmt.Errorf("unknown Safe version: %s", version)
+	}
+	return contracts, nil
+}
+
+// DefaultVersion returns the Safe version whose contracts are used on the given chain when the user has
+// not specified one: DefaultVersion, unless the registry lists the versions deployed on the chain without
+// it, in which case the last of them.
+func (r *Registry) DefaultVersion(chainID *big.Int) string {
+	chain, ok := r.Chain(chainID)
+	if !ok || len(chain.Versions) == 0 || containsString(chain.Versions, DefaultVersion) {
+		return DefaultVersion
+	}
+	return chain.Versions[len(chain.Versions)-1]
+}
+
+// DelegateCallTargets returns the library contracts of every Safe version on the given chain which Safe
+// transactions are meant to delegatecall: MultiSend, MultiSendCallOnly, CreateCall and SignMessageLib.
+func (r *Registry) DelegateCallTargets(chainID *big.Int) []common.Address {
+	versions := map[string]bool{}
+	for version := range r.Versions {
+		versions[version] = true
+	}
+	if chain, ok := r.Chain(chainID); ok {
+		for version := range chain.Contracts {
+			versions[version] = true
+		}
+	}
+
+	var targets []common.Address
+	for version := range versions {
+		contracts, err := r.Contracts(chainID, version)
+		if err != nil {
+			continue
+		}
+		for _, target := range []string{contracts.MultiSend, contracts.MultiSendCallOnly, contracts.CreateCall, contracts.SignMessageLib} {
+			if common.IsHexAddress(target) {
+				targets = append(targets, common.HexToAddress(target))
+			}
+		}
+	}
+	return targets
+}
+
+// MigrationContracts returns the SafeMigration contracts of every Safe version on the given chain, which
+// Safes delegatecall to move to a new singleton.
+func (r *Registry) MigrationContracts(chainID *big.Int) []common.Address {
+	var migrations []common.Address
+	for version := range r.Versions {
+		contracts, err := r.Contracts(chainID, version)
+		if err != nil {
+			continue
+		}
+		if common.IsHexAddress(contracts.SafeMigration) {
+			migrations = append(migrations, common.HexToAddress(contracts.SafeMigration))
+		}
+	}
+	return migrations
+}
+
+// Overrides the Safe Transaction Service of every chain with the given base URL, from which the
+// proposal and delegate URLs are then derived.
+func (r *Registry) OverrideTransactionService(baseURL string) {
+	r.transactionService = strings.TrimSuffix(baseURL, "/")
+}
+
+// TransactionService returns the base URL of the Safe Transaction Service for the given chain, or
+// the empty string if the registry does not know of one.
+func (r *Registry) TransactionService(chainID *big.Int) string {
+	if r.transactionService != "" {
+		return r.transactionService
+	}
+	chain, ok := r.Chain(chainID)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSuffix(chain.TransactionService, "/")
+}
+
+// ProposeURL returns the URL to which transactions for the given Safe should be proposed.
+func (r *Registry) ProposeURL(chainID *big.Int, safeAddress common.Address) string {
+	if service := r.TransactionService(chainID); service != "" {
+		return fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/", service, safeAddress.Hex())
+	}
+	return fmt.Sprintf("%s/v1/chains/%s/transactions/%s/propose", ClientGatewayURL, chainID.String(), safeAddress.Hex())
+}
+
+// DelegatesURL returns the URL from which delegates can be listed and to which they can be added.
+func (r *Registry) DelegatesURL(chainID *big.Int) string {
+	if service := r.TransactionService(chainID); service != "" {
+		return fmt.Sprintf("%s/api/v2/delegates/", service)
+	}
+	return fmt.Sprintf("%s/v2/chains/%s/delegates/", ClientGatewayURL, chainID.String())
+}
+
+// DelegateURL returns the URL of a single delegate, which is used to remove it.
+func (r *Registry) DelegateURL(chainID *big.Int, delegateAddress common.Address) string {
+	return fmt.Sprintf("%s%s/", r.DelegatesURL(chainID), delegateAddress.Hex())
+}
+
+// MultisigTransactionURL returns the URL of a proposed Safe transaction on the given Safe Transaction
+// Service.
+func MultisigTransactionURL(transactionService string, safeTxHash common.Hash) string {
+	return fmt.Sprintf("%s/api/v1/multisig-transactions/%s/", strings.TrimSuffix(transactionService, "/"), safeTxHash.Hex())
+}
+
+// ConfirmationsURL returns the URL to which confirmations of a proposed Safe transaction are posted on
+// the given Safe Transaction Service.
+func ConfirmationsURL(transactionService string, safeTxHash common.Hash) string {
+	return MultisigTransactionURL(transactionService, safeTxHash) + "confirmations/"
+}
+
+// Returns the address of the CreateCall contract of the default version of the given chain, using the
+// default registry.
+func DefaultCreateCall(chainID *big.Int) (common.Address, error) {
+	registry := Default()
+	version := registry.DefaultVersion(chainID)
+	contracts, err := registry.Contracts(chainID, version)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(contracts.CreateCall) {
+		return common.Address{}, fmt.Errorf("no CreateCall address for Safe version %s on chain %s in the chain registry", version, chainID.String())
+	}
+	return common.HexToAddress(contracts.CreateCall), nil
+}
+
+// Returns the proposal URL for the given Safe using the default registry.
+func ProposeURL(chainID *big.Int, safeAddress common.Address) string {
+	return Default().ProposeURL(chainID, safeAddress)
+}
+
+// Returns the contracts which Safe transactions on the given chain are meant to delegatecall, using the
+// default registry.
+func DelegateCallTargets(chainID *big.Int) []common.Address {
+	return Default().DelegateCallTargets(chainID)
+}
+
+// Returns the SafeMigration contracts on the given chain, using the default registry.
+func MigrationContracts(chainID *big.Int) []common.Address {
+	return Default().MigrationContracts(chainID)
+}
+
+// Returns the Safe Transaction Service for the given chain using the default registry.
+func TransactionService(chainID *big.Int) string {
+	return Default().TransactionService(chainID)
+}
+
+// Returns the delegates URL for the given chain using the default registry.
+func DelegatesURL(chainID *big.Int) string {
+	return Default().DelegatesURL(chainID)
+}
+
+// Returns the URL of a single delegate on the given chain using the default registry.
+func DelegateURL(chainID *big.Int, delegateAddress common.Address) string {
+	return Default().DelegateURL(chainID, delegateAddress)
+}
+
+func (c Contracts) merge(other Contracts) Contracts {
+	pick := func(current, override string) string {
+		if override != "" {
+			return override
+		}
+		return current
+	}
+
+	return Contracts{
+		Singleton:          pick(c.Singleton, other.Singleton),
+		SingletonL2:        pick(c.SingletonL2, other.SingletonL2),
+		ProxyFactory:       pick(c.ProxyFactory, other.ProxyFactory),
+		MultiSend:          pick(c.MultiSend, other.MultiSend),
+		MultiSendCallOnly:  pick(c.MultiSendCallOnly, other.MultiSendCallOnly),
+		CreateCall:         pick(c.CreateCall, other.CreateCall),
+		FallbackHandler:    pick(c.FallbackHandler, other.FallbackHandler),
+		SignMessageLib:     pick(c.SignMessageLib, other.SignMessageLib),
+		SimulateTxAccessor: pick(c.SimulateTxAccessor, other.SimulateTxAccessor),
+		SafeMigration:      pick(c.SafeMigration, other.SafeMigration),
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -3,12 +3,17 @@ package main
 import (
 	"os"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/spf13/cobra"
 
 	"github.com/G7DAO/safes/bindings/Safe"
 	"github.com/G7DAO/safes/bindings/SafeL2"
 	"github.com/G7DAO/safes/bindings/SafeProxy"
 	"github.com/G7DAO/safes/bindings/SafeProxyFactory"
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
 )
 
 var SAFES_VERSION string = "0.0.1"
@@ -21,26 +26,94 @@ func CreateRootCommand() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Help()
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := ApplyConfigDefaults(cmd); err != nil {
+				return err
+			}
+
+			signerSpec, _ := cmd.Flags().GetString("signer")
+			hdPath, _ := cmd.Flags().GetString("hd-path")
+			signerAddress, _ := cmd.Flags().GetString("signer-address")
+			keystoreDir, _ := cmd.Flags().GetString("keystore-dir")
+			passwordFile, _ := cmd.Flags().GetString("password-file")
+			passwordEnv, _ := cmd.Flags().GetString("password-env")
+			passwordCommand, _ := cmd.Flags().GetString("password-command")
+			signer.SetDefaults(signer.Options{
+				Spec:        signerSpec,
+				HDPath:      hdPath,
+				Address:     signerAddress,
+				KeystoreDir: keystoreDir,
+				Passwords:   signer.PasswordSources{File: passwordFile, Env: passwordEnv, Command: passwordCommand},
+			})
+
+			transactionService, _ := cmd.Flags().GetString("transaction-service")
+			chains.Default().OverrideTransactionService(transactionService)
+
+			override, _ := cmd.Flags().GetBool("i-know-what-im-doing")
+			safetx.SetPolicy(safetx.Policy{Override: override})
+
+			yes, _ := cmd.Flags().GetBool("yes")
+			signer.SetAssumeYes(yes)
+
+			outputFormat, _ := cmd.Flags().GetString("output")
+			if err := output.SetFormat(outputFormat); err != nil {
+				return err
+			}
+			if output.IsJSON() {
+				// Errors are reported as JSON by main.
+				cmd.Root().SilenceErrors = true
+				cmd.Root().SilenceUsage = true
+			}
+
+			return nil
+		},
+	}
+
+	rootCmd.PersistentFlags().String("config", "", "Path to the safes configuration file (default: $XDG_CONFIG_HOME/safes/config.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "Name of the configuration profile to use for default flag values")
+	for _, setting := range ProfileSettings {
+		if setting.Flag == "timeout" {
+			rootCmd.PersistentFlags().Uint(setting.Flag, 60, setting.Usage)
+			continue
+		}
+		rootCmd.PersistentFlags().String(setting.Flag, setting.Default, setting.Usage)
 	}
 
+	rootCmd.PersistentFlags().Bool("yes", false, "Sign without confirmation after the typed data to be signed has been shown")
+	rootCmd.PersistentFlags().Bool("i-know-what-im-doing", false, "Propose dangerous Safe transactions (delegatecalls to unknown contracts, thresholds lowered to 1, removals of the proposer, guards and fallback handlers without code) with a warning instead of refusing them")
+
+	registerBindingABIs()
+
 	completionCmd := CreateCompletionCommand(rootCmd)
 	versionCmd := CreateVersionCommand()
 
-	singletonCmd := Safe.CreateSafeCommand()
-	singletonCmd.Use = "singleton"
+	singletonCmd := CreateContractCmd("singleton", "Safe", Safe.SafeMetaData)
 
-	singletonL2Cmd := SafeL2.CreateSafeL2Command()
-	singletonL2Cmd.Use = "singleton-l2"
+	singletonL2Cmd := CreateContractCmd("singleton-l2", "SafeL2", SafeL2.SafeL2MetaData)
 
-	proxyCmd := SafeProxy.CreateSafeProxyCommand()
-	proxyCmd.Use = "proxy"
+	proxyCmd := CreateContractCmd("proxy", "SafeProxy", SafeProxy.SafeProxyMetaData)
 
-	factoryCmd := SafeProxyFactory.CreateSafeProxyFactoryCommand()
-	factoryCmd.Use = "factory"
+	factoryCmd := CreateContractCmd("factory", "SafeProxyFactory", SafeProxyFactory.SafeProxyFactoryMetaData)
 
 	delegateCmd := CreateDelegateCmd()
 
-	rootCmd.AddCommand(completionCmd, versionCmd, singletonCmd, singletonL2Cmd, proxyCmd, factoryCmd, delegateCmd)
+	chainsCmd := CreateChainsCmd()
+
+	configCmd := CreateConfigCmd()
+
+	confirmCmd := CreateConfirmCmd()
+
+	keysCmd := CreateKeysCmd()
+
+	safeCmd := CreateSafeCmd()
+
+	bootstrapCmd := CreateBootstrapCmd()
+
+	deployArtifactCmd := CreateDeployArtifactCmd()
+
+	exporterCmd := CreateExporterCmd()
+
+	rootCmd.AddCommand(completionCmd, versionCmd, singletonCmd, singletonL2Cmd, proxyCmd, factoryCmd, delegateCmd, confirmCmd, safeCmd, bootstrapCmd, deployArtifactCmd, exporterCmd, keysCmd, chainsCmd, configCmd)
 
 	// By default, cobra Command objects write to stderr. We have to forcibly set them to output to
 	// stdout.
@@ -49,6 +122,16 @@ func CreateRootCommand() *cobra.Command {
 	return rootCmd
 }
 
+// Registers the ABIs of the Safe contracts with safetx, so that calls of them are decoded when Safe
+// transactions are reviewed before signing.
+func registerBindingABIs() {
+	for _, metadata := range []*bind.MetaData{Safe.SafeMetaData, SafeL2.SafeL2MetaData, SafeProxyFactory.SafeProxyFactoryMetaData} {
+		if contractABI, err := metadata.GetAbi(); err == nil {
+			safetx.RegisterABI(*contractABI)
+		}
+	}
+}
+
 func CreateCompletionCommand(rootCmd *cobra.Command) *cobra.Command {
 	completionCmd := &cobra.Command{
 		Use:   "completion",
@@ -107,10 +190,18 @@ func CreateVersionCommand() *cobra.Command {
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the version of game7 that you are currently using",
-		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Println(SAFES_VERSION)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return output.Print(cmd, VersionResult{Version: SAFES_VERSION})
 		},
 	}
 
 	return versionCmd
 }
+
+type VersionResult struct {
+	Version string `json:"version"`
+}
+
+func (r VersionResult) Text() string {
+	return r.Version + "\n"
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/output"
+)
+
+func CreateConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the safes configuration file and its profiles",
+		Long: `Inspect the safes configuration file and its profiles.
+
+The configuration file (by default $XDG_CONFIG_HOME/safes/config.yaml, or the file given by --config or the
+SAFES_CONFIG environment variable) defines named profiles. The flags of the active profile are used as
+defaults by every command, including the generated contract commands and the delegate commands:
+
+	default_profile: game7-mainnet-treasury
+	profiles:
+	  game7-mainnet-treasury:
+	    rpc: https://mainnet-rpc.game7.io
+	    keyfile: ~/.safes/treasury-owner.json
+	    safe: "0x..."
+	    timeout: 120
+
+Select a profile with --profile or the SAFES_PROFILE environment variable. Each setting can also be
+provided through an environment variable (SAFES_RPC, SAFES_KEYFILE, SAFES_PASSWORD, SAFES_PASSWORD_FILE,
+SAFES_PASSWORD_ENV, SAFES_PASSWORD_COMMAND, SAFES_SAFE, SAFES_TRANSACTION_SERVICE, SAFES_TIMEOUT,
+SAFES_KEYSTORE_DIR, SAFES_SIGNER, SAFES_HD_PATH, SAFES_SIGNER_ADDRESS). Flags passed on the command line
+take precedence over environment variables, which take precedence over the profile.`,
+	}
+
+	configCmd.AddCommand(createConfigPathCmd())
+	configCmd.AddCommand(createListProfilesCmd())
+	configCmd.AddCommand(createShowProfileCmd())
+
+	return configCmd
+}
+
+func createConfigPathCmd() *cobra.Command {
+	configPathCmd := &cobra.Command{
+		Use:   "path",
+		Short: "Print the path of the configuration file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath, _ := cmd.Flags().GetString("config")
+			if configPath == "" {
+				configPath = os.Getenv(ConfigFileEnvVar)
+			}
+			if configPath == "" {
+				configPath = DefaultConfigFile()
+			}
+			return output.Print(cmd, PathResult{Path: configPath})
+		},
+	}
+
+	return configPathCmd
+}
+
+func createListProfilesCmd() *cobra.Command {
+	listProfilesCmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "List the profiles in the configuration file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, activeProfile, _, err := ResolveConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			return output.Print(cmd, ProfilesResult{Profiles: config.ProfileNames(), Active: activeProfile})
+		},
+	}
+
+	return listProfilesCmd
+}
+
+func createShowProfileCmd() *cobra.Command {
+	showProfileCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the values the active profile and environment provide for each flag",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, activeProfile, _, err := ResolveConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			result := ProfileResult{Profile: activeProfile, Flags: map[string]string{}}
+			for _, setting := range ProfileSettings {
+				value := cmd.Flags().Lookup(setting.Flag).Value.String()
+				if setting.Flag == "password" && value != "" {
+					value = "********"
+				}
+				result.Flags[setting.Flag] = value
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	return showProfileCmd
+}
+
+// ProfilesResult lists the profiles in the configuration file.
+type ProfilesResult struct {
+	Profiles []string `json:"profiles"`
+	Active   string   `json:"active"`
+}
+
+func (r ProfilesResult) Text() string {
+	var builder strings.Builder
+	for _, name := range r.Profiles {
+		marker := " "
+		if name == r.Active {
+			marker = "*"
+		}
+		fmt.Fprintf(&builder, "%s %s\n", marker, name)
+	}
+	return builder.String()
+}
+
+// ProfileResult shows the values which the active profile and the environment provide for each flag.
+type ProfileResult struct {
+	Profile string            `json:"profile"`
+	Flags   map[string]string `json:"flags"`
+}
+
+func (r ProfileResult) Text() string {
+	var builder strings.Builder
+	if r.Profile == "" {
+		builder.WriteString("Profile: (none)\n")
+	} else {
+		fmt.Fprintf(&builder, "Profile: %s\n", r.Profile)
+	}
+	for _, setting := range ProfileSettings {
+		fmt.Fprintf(&builder, "%s: %s\n", setting.Flag, r.Flags[setting.Flag])
+	}
+	return builder.String()
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+// Profile holds default values for command-line flags. Any flag which is not explicitly set on the
+// command line (or through its environment variable) takes its value from the active profile.
+type Profile struct {
+	RPC                string `yaml:"rpc,omitempty"`
+	Keyfile            string `yaml:"keyfile,omitempty"`
+	Password           string `yaml:"password,omitempty"`
+	Safe               string `yaml:"safe,omitempty"`
+	TransactionService string `yaml:"transaction_service,omitempty"`
+	Timeout            string `yaml:"timeout,omitempty"`
+	Output             string `yaml:"output,omitempty"`
+
+	PasswordFile    string `yaml:"password_file,omitempty"`
+	PasswordEnv     string `yaml:"password_env,omitempty"`
+	PasswordCommand string `yaml:"password_command,omitempty"`
+
+	KeystoreDir   string `yaml:"keystore_dir,omitempty"`
+	Signer        string `yaml:"signer,omitempty"`
+	HDPath        string `yaml:"hd_path,omitempty"`
+	SignerAddress string `yaml:"signer_address,omitempty"`
+}
+
+// Config is the contents of the safes configuration file.
+type Config struct {
+	DefaultProfile string             `yaml:"default_profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// ProfileSetting ties a field of a Profile to the flag it provides a default for and the environment
+// variable which overrides it.
+type ProfileSetting struct {
+	Flag    string
+	EnvVar  string
+	Usage   string
+	Default string
+	Value   func(profile Profile) string
+}
+
+const (
+	ConfigFileEnvVar = "SAFES_CONFIG"
+	ProfileEnvVar    = "SAFES_PROFILE"
+)
+
+// ProfileSettings lists the flags which can be set from the environment or from a profile. These flags
+// are also registered as persistent flags on the root command.
+var ProfileSettings = []ProfileSetting{
+	{Flag: "rpc", EnvVar: "SAFES_RPC", Usage: "URL of the JSONRPC API to use", Value: func(p Profile) string { return p.RPC }},
+	{Flag: "keyfile", EnvVar: "SAFES_KEYFILE", Usage: "Keystore file to use for transactions and signatures: a path, or the address or alias of a key in --keystore-dir", Value: func(p Profile) string { return expandHome(p.Keyfile) }},
+	{Flag: "password", EnvVar: "SAFES_PASSWORD", Usage: "Password to use to unlock the keystore", Value: func(p Profile) string { return p.Password }},
+	{Flag: "password-file", EnvVar: "SAFES_PASSWORD_FILE", Usage: "File containing the password to unlock the keystore", Value: func(p Profile) string { return expandHome(p.PasswordFile) }},
+	{Flag: "password-env", EnvVar: "SAFES_PASSWORD_ENV", Usage: "Name of an environment variable containing the password to unlock the keystore", Value: func(p Profile) string { return p.PasswordEnv }},
+	{Flag: "password-command", EnvVar: "SAFES_PASSWORD_COMMAND", Usage: "Shell command which prints the password to unlock the keystore (e.g. \"pass show safes/deployer\")", Value: func(p Profile) string { return p.PasswordCommand }},
+	{Flag: "safe", EnvVar: "SAFES_SAFE", Usage: "Address of the Safe contract", Value: func(p Profile) string { return p.Safe }},
+	{Flag: "transaction-service", EnvVar: "SAFES_TRANSACTION_SERVICE", Usage: "Base URL of the Safe Transaction Service, from which the proposal and delegate URLs are derived (default: from the chain registry)", Value: func(p Profile) string { return p.TransactionService }},
+	{Flag: "timeout", EnvVar: "SAFES_TIMEOUT", Usage: "Timeout (in seconds) for interactions with the JSONRPC API", Default: "60", Value: func(p Profile) string { return p.Timeout }},
+	{Flag: "keystore-dir", EnvVar: "SAFES_KEYSTORE_DIR", Usage: "Directory in which keys given to --keyfile by address or alias are looked up (default: $XDG_CONFIG_HOME/safes/keystore)", Value: func(p Profile) string { return expandHome(p.KeystoreDir) }},
+	{Flag: "signer", EnvVar: "SAFES_SIGNER", Usage: "Signer to use instead of --keyfile, as <backend>:<parameter> (backends: keystore, privatekey-env, privatekey-file, mnemonic-env, mnemonic-file, clef, external or web3signer)", Value: func(p Profile) string { return p.Signer }},
+	{Flag: "hd-path", EnvVar: "SAFES_HD_PATH", Usage: "BIP-32 derivation path for mnemonic signers", Default: signer.DefaultHDPath, Value: func(p Profile) string { return p.HDPath }},
+	{Flag: "signer-address", EnvVar: "SAFES_SIGNER_ADDRESS", Usage: "Address of the account to sign with, for signers which manage several accounts", Value: func(p Profile) string { return p.SignerAddress }},
+	{Flag: "output", EnvVar: "SAFES_OUTPUT", Usage: "Output format: text or json", Default: "text", Value: func(p Profile) string { return p.Output }},
+}
+
+// Returns the default location of the configuration file: $XDG_CONFIG_HOME/safes/config.yaml.
+func DefaultConfigFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "safes", "config.yaml")
+}
+
+// Loads the configuration file at the given path. If required is false, a missing file results in an
+// empty configuration rather than an error.
+func LoadConfig(path string, required bool) (*Config, error) {
+	config := &Config{Profiles: map[string]Profile{}}
+	if path == "" {
+		return config, nil
+	}
+
+	data, readErr := os.ReadFile(path)
+	if errors.Is(readErr, os.ErrNotExist) && !required {
+		return config, nil
+	} else if readErr != nil {
+		return nil, fmt.Errorf("could not read config file (%s): %v", path, readErr)
+	}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("could not parse config file (%s): %v", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]Profile{}
+	}
+
+	return config, nil
+}
+
+// Returns the names of the profiles in the configuration, in alphabetical order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the profile with the given name. The empty name refers to the default profile, if one is
+// configured, and to an empty profile otherwise.
+func (c *Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile not found in config file: %s", name)
+	}
+	return profile, nil
+}
+
+// Resolves the configuration file and profile selected by the --config and --profile flags (or their
+// environment variables) for the given command.
+func ResolveConfig(cmd *cobra.Command) (*Config, string, Profile, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	required := configPath != ""
+	if configPath == "" {
+		configPath = os.Getenv(ConfigFileEnvVar)
+		required = configPath != ""
+	}
+	if configPath == "" {
+		configPath = DefaultConfigFile()
+	}
+
+	config, configErr := LoadConfig(configPath, required)
+	if configErr != nil {
+		return nil, "", Profile{}, configErr
+	}
+
+	profileName, _ := cmd.Flags().GetString("profile")
+	if profileName == "" {
+		profileName = os.Getenv(ProfileEnvVar)
+	}
+	if profileName == "" {
+		profileName = config.DefaultProfile
+	}
+
+	profile, profileErr := config.Profile(profileName)
+	if profileErr != nil {
+		return nil, "", Profile{}, profileErr
+	}
+
+	return config, profileName, profile, nil
+}
+
+// Fills in the flags of the given command which were not set on the command line. Values are taken
+// from the environment first and then from the active profile. This applies both to the persistent
+// flags of the root command and to the flags which the generated commands declare themselves.
+func ApplyConfigDefaults(cmd *cobra.Command) error {
+	_, _, profile, err := ResolveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	for _, setting := range ProfileSettings {
+		flag := cmd.Flags().Lookup(setting.Flag)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		value := os.Getenv(setting.EnvVar)
+		if value == "" {
+			value = setting.Value(profile)
+		}
+		if value == "" {
+			continue
+		}
+
+		if setErr := cmd.Flags().Set(setting.Flag, value); setErr != nil {
+			return fmt.Errorf("invalid value for --%s from environment or profile: %v", setting.Flag, setErr)
+		}
+		cmd.Flags().SetAnnotation(setting.Flag, configDefaultAnnotation, []string{setting.EnvVar})
+	}
+
+	return nil
+}
+
+// Marks the flags whose values ApplyConfigDefaults took from the environment or the active profile.
+const configDefaultAnnotation = "safes_config_default"
+
+// Returns true if the flag with the given name was set on the command line, rather than filled in from
+// the environment or the active profile.
+func SetOnCommandLine(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flags().Lookup(name)
+	return flag != nil && flag.Changed && flag.Annotations[configDefaultAnnotation] == nil
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+	"github.com/spf13/cobra"
+)
+
+func CreateConfirmCmd() *cobra.Command {
+	var (
+		safeTxHash string
+		rpc        string
+		keyfile    string
+		password   string
+	)
+
+	confirmCmd := &cobra.Command{
+		Use:   "confirm",
+		Short: "Confirm a transaction proposed to the Safe Transaction Service",
+		Long: `Confirm a transaction proposed to the Safe Transaction Service.
+
+The proposed transaction is fetched from the service and its SafeTxHash is recomputed locally before the
+signer is asked to sign it. The signer must be an owner of the Safe. The service is the one of the chain
+in the chain registry, unless --transaction-service is passed.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(common.FromHex(safeTxHash)) != common.HashLength {
+				return fmt.Errorf("invalid SafeTxHash: %s", safeTxHash)
+			}
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := ethclient.Dial(rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+
+			chainID, err := client.ChainID(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+
+			// The root command's --transaction-service overrides the service of the chain registry.
+			transactionService := chains.TransactionService(chainID)
+			if transactionService == "" {
+				return fmt.Errorf("no Safe Transaction Service known for chain %s -- pass --transaction-service", chainID.String())
+			}
+
+			proposal, err := GetProposal(transactionService, common.HexToHash(safeTxHash))
+			if err != nil {
+				return fmt.Errorf("error retrieving proposed transaction: %v", err)
+			}
+
+			owner, err := signer.Load(keyfile, password)
+			if err != nil {
+				return err
+			}
+
+			safeInstance, err := GnosisSafe.NewGnosisSafe(common.HexToAddress(proposal.Safe), client)
+			if err != nil {
+				return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+			}
+			isOwner, err := safeInstance.IsOwner(&bind.CallOpts{}, owner.Address())
+			if err != nil {
+				return fmt.Errorf("failed to check Safe owners: %v", err)
+			}
+			if !isOwner {
+				return fmt.Errorf("%s is not an owner of Safe %s", owner.Address().Hex(), proposal.Safe)
+			}
+
+			result, err := ConfirmProposal(client, transactionService, proposal, chainID, owner)
+			if err != nil {
+				return fmt.Errorf("error confirming transaction: %v", err)
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	confirmCmd.Flags().StringVar(&safeTxHash, "safe-tx-hash", "", "SafeTxHash of the proposed transaction")
+	confirmCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	confirmCmd.Flags().StringVarP(&keyfile, "keyfile", "k", "", "Path to the keystore file")
+	confirmCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the keystore file")
+	confirmCmd.MarkFlagRequired("safe-tx-hash")
+
+	return confirmCmd
+}
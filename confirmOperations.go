@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ServiceConfirmation is a confirmation of a proposed transaction as returned by the Safe Transaction
+// Service.
+type ServiceConfirmation struct {
+	Owner     string `json:"owner"`
+	Signature string `json:"signature"`
+}
+
+// ServiceTransaction is a proposed Safe transaction as returned by the Safe Transaction Service.
+type ServiceTransaction struct {
+	Safe                  string                `json:"safe"`
+	To                    string                `json:"to"`
+	Value                 json.Number           `json:"value"`
+	Data                  *string               `json:"data"`
+	Operation             uint8                 `json:"operation"`
+	SafeTxGas             json.Number           `json:"safeTxGas"`
+	BaseGas               json.Number           `json:"baseGas"`
+	GasPrice              json.Number           `json:"gasPrice"`
+	GasToken              string                `json:"gasToken"`
+	RefundReceiver        string                `json:"refundReceiver"`
+	Nonce                 json.Number           `json:"nonce"`
+	SafeTxHash            string                `json:"safeTxHash"`
+	IsExecuted            bool                  `json:"isExecuted"`
+	ConfirmationsRequired int                   `json:"confirmationsRequired"`
+	Confirmations         []ServiceConfirmation `json:"confirmations"`
+}
+
+// ConfirmationResult is the result of confirming a proposed transaction.
+type ConfirmationResult struct {
+	Safe                  string `json:"safe"`
+	SafeTxHash            string `json:"safeTxHash"`
+	Owner                 string `json:"owner"`
+	Signature             string `json:"signature"`
+	Confirmations         int    `json:"confirmations"`
+	ConfirmationsRequired int    `json:"confirmationsRequired"`
+}
+
+func (r ConfirmationResult) Text() string {
+	return fmt.Sprintf("Safe transaction confirmed successfully\nSafeTxHash: %s\nOwner: %s\nConfirmations: %d/%d\n", r.SafeTxHash, r.Owner, r.Confirmations, r.ConfirmationsRequired)
+}
+
+// Fetches a proposed transaction from the Safe Transaction Service.
+func GetProposal(transactionService string, safeTxHash common.Hash) (*ServiceTransaction, error) {
+	resp, err := http.Get(chains.MultisigTransactionURL(transactionService, safeTxHash))
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no proposed transaction with SafeTxHash %s", safeTxHash.Hex())
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var proposal ServiceTransaction
+	if err := json.Unmarshal(body, &proposal); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return &proposal, nil
+}
+
+// Returns the Safe transaction described by a proposal from the Safe Transaction Service.
+func (p *ServiceTransaction) TransactionData() (safetx.TransactionData, error) {
+	data := ""
+	if p.Data != nil {
+		data = strings.TrimPrefix(*p.Data, "0x")
+	}
+
+	safeTxGas, err := parseServiceUint(p.SafeTxGas, "safeTxGas")
+	if err != nil {
+		return safetx.TransactionData{}, err
+	}
+	baseGas, err := parseServiceUint(p.BaseGas, "baseGas")
+	if err != nil {
+		return safetx.TransactionData{}, err
+	}
+	nonce, err := parseServiceUint(p.Nonce, "nonce")
+	if err != nil {
+		return safetx.TransactionData{}, err
+	}
+
+	return safetx.TransactionData{
+		To:             p.To,
+		Value:          p.Value.String(),
+		Data:           data,
+		Operation:      safetx.OperationType(p.Operation),
+		SafeTxGas:      safeTxGas,
+		BaseGas:        baseGas,
+		GasPrice:       p.GasPrice.String(),
+		GasToken:       p.GasToken,
+		RefundReceiver: p.RefundReceiver,
+		Nonce:          nonce,
+	}, nil
+}
+
+func parseServiceUint(value json.Number, field string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	parsed, ok := new(big.Int).SetString(value.String(), 10)
+	if !ok || !parsed.IsUint64() {
+		return 0, fmt.Errorf("invalid %s in proposed transaction: %s", field, value)
+	}
+	return parsed.Uint64(), nil
+}
+
+// Signs a proposed transaction and posts the signature to the Safe Transaction Service. The SafeTxHash
+// is recomputed from the proposed transaction (and checked against the Safe through client), so that
+// the signer only signs what the service shows.
+func ConfirmProposal(client bind.ContractCaller, transactionService string, proposal *ServiceTransaction, chainID *big.Int, owner signer.Signer) (*ConfirmationResult, error) {
+	if proposal.IsExecuted {
+		return nil, fmt.Errorf("transaction %s has already been executed", proposal.SafeTxHash)
+	}
+	for _, confirmation := range proposal.Confirmations {
+		if common.HexToAddress(confirmation.Owner) == owner.Address() {
+			return nil, fmt.Errorf("transaction %s has already been confirmed by %s", proposal.SafeTxHash, owner.Address().Hex())
+		}
+	}
+
+	safeAddress := common.HexToAddress(proposal.Safe)
+	txData, err := proposal.TransactionData()
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := safetx.PrepareTransaction(context.Background(), client, safeAddress, txData, chainID)
+	if err != nil {
+		return nil, err
+	}
+	safeTxHash := prepared.SafeTxHash
+	if safeTxHash != common.HexToHash(proposal.SafeTxHash) {
+		return nil, fmt.Errorf("SafeTxHash of the proposed transaction (%s) does not match the transaction the service returned (%s)", proposal.SafeTxHash, safeTxHash.Hex())
+	}
+
+	if err := signer.ConfirmTypedData(prepared.TypedData, safetx.ReviewDetails(txData)...); err != nil {
+		return nil, err
+	}
+	signature, err := owner.SignTypedData(prepared.TypedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign SafeTxHash: %v", err)
+	}
+	senderSignature := "0x" + common.Bytes2Hex(signature)
+
+	jsonData, err := json.Marshal(map[string]string{"signature": senderSignature})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling payload: %w", err)
+	}
+
+	resp, err := http.Post(chains.ConfirmationsURL(transactionService, safeTxHash), "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return &ConfirmationResult{
+		Safe:                  safeAddress.Hex(),
+		SafeTxHash:            safeTxHash.Hex(),
+		Owner:                 owner.Address().Hex(),
+		Signature:             senderSignature,
+		Confirmations:         len(proposal.Confirmations) + 1,
+		ConfirmationsRequired: proposal.ConfirmationsRequired,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+	"github.com/G7DAO/safes/signer/signertest"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// A stand-in for the Safe Transaction Service which serves a single proposed transaction and records the
+// confirmations posted for it.
+func newTransactionService(t *testing.T, proposal ServiceTransaction, confirmations *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		transactionPath := fmt.Sprintf("/api/v1/multisig-transactions/%s/", proposal.SafeTxHash)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == transactionPath:
+			json.NewEncoder(w).Encode(proposal)
+		case r.Method == http.MethodPost && r.URL.Path == transactionPath+"confirmations/":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("could not decode confirmation: %v", err)
+			}
+			*confirmations = append(*confirmations, body["signature"])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+// Starts a simulated chain with a Safe owned by the account with index 1, which needs two confirmations.
+func newTestSafe(t *testing.T) (*simtest.Chain, common.Address) {
+	chain := simtest.New(t, 3)
+	deployment := chain.Deploy(t)
+	return chain, chain.SetupSafe(t, deployment.SafeL2, 2, 1, 2)
+}
+
+func testProposal(t *testing.T, safeAddress common.Address, chainID *big.Int) ServiceTransaction {
+	data := "0x610b5925000000000000000000000000000000000000000000000000000000000000dead"
+	proposal := ServiceTransaction{
+		Safe:                  safeAddress.Hex(),
+		To:                    safeAddress.Hex(),
+		Value:                 "0",
+		Data:                  &data,
+		Operation:             0,
+		SafeTxGas:             "0",
+		BaseGas:               "0",
+		GasPrice:              "0",
+		GasToken:              safetx.NativeTokenAddress,
+		RefundReceiver:        safetx.NativeTokenAddress,
+		Nonce:                 "7",
+		ConfirmationsRequired: 2,
+	}
+	txData, err := proposal.TransactionData()
+	if err != nil {
+		t.Fatalf("could not build transaction data: %v", err)
+	}
+	safeTxHash, err := safetx.CalculateSafeTxHash(safeAddress, txData, chainID)
+	if err != nil {
+		t.Fatalf("could not calculate SafeTxHash: %v", err)
+	}
+	proposal.SafeTxHash = safeTxHash.Hex()
+	return proposal
+}
+
+func TestConfirmProposalWithExternalSigner(t *testing.T) {
+	chain, safeAddress := newTestSafe(t)
+	chainID := simtest.ChainID
+	key := chain.Keys[1]
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	clef := signertest.NewClefAPI(key)
+	clefServer := clef.StartHTTP()
+	defer clefServer.Close()
+
+	var confirmations []string
+	proposal := testProposal(t, safeAddress, chainID)
+	service := newTransactionService(t, proposal, &confirmations)
+	defer service.Close()
+
+	fetched, err := GetProposal(service.URL, common.HexToHash(proposal.SafeTxHash))
+	if err != nil {
+		t.Fatalf("could not fetch proposal: %v", err)
+	}
+
+	externalSigner, err := signer.NewExternalSigner(clefServer.URL, owner)
+	if err != nil {
+		t.Fatalf("could not create external signer: %v", err)
+	}
+
+	// The owner confirms the reviewed typed data.
+	setStdin(t, "y\n")
+	result, err := ConfirmProposal(chain.Client, service.URL, fetched, chainID, externalSigner)
+	if err != nil {
+		t.Fatalf("could not confirm proposal: %v", err)
+	}
+	if result.Owner != owner.Hex() || result.Confirmations != 1 || result.ConfirmationsRequired != 2 {
+		t.Fatalf("unexpected confirmation result: %+v", result)
+	}
+	if len(confirmations) != 1 || confirmations[0] != result.Signature {
+		t.Fatalf("unexpected confirmations posted to the service: %v", confirmations)
+	}
+
+	signature := common.FromHex(result.Signature)
+	signature[64] -= 27
+	publicKey, err := crypto.SigToPub(common.HexToHash(proposal.SafeTxHash).Bytes(), signature)
+	if err != nil {
+		t.Fatalf("could not recover signer: %v", err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != owner {
+		t.Fatalf("confirmation was not signed by the owner")
+	}
+	if requests := clef.Requests(); len(requests) != 1 || requests[0] != "account_signTypedData" {
+		t.Fatalf("unexpected requests to external signer: %v", requests)
+	}
+}
+
+func TestConfirmProposalRejectsMismatchedHash(t *testing.T) {
+	chain, safeAddress := newTestSafe(t)
+	chainID := simtest.ChainID
+
+	proposal := testProposal(t, safeAddress, chainID)
+	tamperedData := "0x"
+	proposal.Data = &tamperedData
+
+	_, err := ConfirmProposal(chain.Client, "http://127.0.0.1:0", &proposal, chainID, signer.NewKeySigner(chain.Keys[1]))
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected SafeTxHash mismatch, got %v", err)
+	}
+}
+
+func TestConfirmProposalRejectsDuplicateConfirmation(t *testing.T) {
+	chain, safeAddress := newTestSafe(t)
+	chainID := simtest.ChainID
+	owner := chain.Address(1)
+
+	proposal := testProposal(t, safeAddress, chainID)
+	proposal.Confirmations = []ServiceConfirmation{{Owner: owner.Hex()}}
+
+	_, err := ConfirmProposal(chain.Client, "http://127.0.0.1:0", &proposal, chainID, signer.NewKeySigner(chain.Keys[1]))
+	if err == nil || !strings.Contains(err.Error(), "already been confirmed") {
+		t.Fatalf("expected duplicate confirmation error, got %v", err)
+	}
+}
+
+func TestTransactionServiceSetting(t *testing.T) {
+	chain, safeAddress := newTestSafe(t)
+
+	var confirmations []string
+	proposal := testProposal(t, safeAddress, simtest.ChainID)
+	transactions := newTransactionService(t, proposal, &confirmations)
+	defer transactions.Close()
+
+	// The service answers proposals to the Safe, and everything else as the stand-in service does.
+	var proposed map[string]interface{}
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", safeAddress.Hex()) {
+			if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+				t.Errorf("could not decode proposal: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		transactions.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer service.Close()
+
+	// A single base URL in a profile serves both proposals and confirmations.
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("default_profile: test\nprofiles:\n  test:\n    transaction_service: "+service.URL+"\n"), 0600); err != nil {
+		t.Fatalf("could not write config file: %v", err)
+	}
+	keyArgs := []string{"--config", configFile, "--rpc", chain.Endpoint, "--keyfile", chain.Keystore(t, 1), "--password", simtest.KeystorePassword}
+
+	mustRunCLI(t, nil, append([]string{"safe", "message", "sign", "--onchain", "--safe", safeAddress.Hex(), "--message", "hello"}, keyArgs...)...)
+	if proposed == nil || common.HexToAddress(proposed["sender"].(string)) != chain.Address(1) {
+		t.Fatalf("unexpected proposal: %v", proposed)
+	}
+
+	var result ConfirmationResult
+	mustRunCLI(t, &result, append([]string{"confirm", "--safe-tx-hash", proposal.SafeTxHash}, keyArgs...)...)
+	if len(confirmations) != 1 || confirmations[0] != result.Signature {
+		t.Fatalf("unexpected confirmations posted to the service: %v", confirmations)
+	}
+}
@@ -0,0 +1,538 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+)
+
+// Creates the command which interacts with a contract through its ABI: deploy deploys the contract, and
+// there is a command for each of its methods (named after the method in kebab case), as well as for its
+// fallback and receive functions. Transactions are either sent from the signer's account or, with --safe,
+// proposed to (or simulated on) a Safe.
+func CreateContractCmd(use, contractName string, metadata *bind.MetaData) *cobra.Command {
+	contractCmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("Interact with the %s contract", contractName),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	contractABI, err := metadata.GetAbi()
+	if err != nil {
+		contractCmd.Run = nil
+		contractCmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("failed to parse the ABI of %s: %v", contractName, err)
+		}
+		return contractCmd
+	}
+	envVar := contractRPCEnvVar(contractName)
+
+	deployGroup := &cobra.Group{ID: "deploy", Title: "Commands which deploy contracts"}
+	viewGroup := &cobra.Group{ID: "view", Title: "Commands which view contract state"}
+	transactGroup := &cobra.Group{ID: "transact", Title: "Commands which submit transactions"}
+	contractCmd.AddGroup(deployGroup, viewGroup, transactGroup)
+
+	deployCmd := createContractDeployCmd(contractName, envVar, metadata, contractABI)
+	deployCmd.GroupID = deployGroup.ID
+	contractCmd.AddCommand(deployCmd)
+
+	names := make([]string, 0, len(contractABI.Methods))
+	for name := range contractABI.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		method := contractABI.Methods[name]
+		if method.IsConstant() {
+			viewCmd := createContractViewCmd(contractName, envVar, contractABI, method)
+			viewCmd.GroupID = viewGroup.ID
+			contractCmd.AddCommand(viewCmd)
+			continue
+		}
+		arguments := ContractArguments(method.Inputs)
+		transactCmd := createContractTransactCmd(contractName, envVar, abi.ToCamelCase(method.Name), arguments, func(values []interface{}) ([]byte, error) {
+			return contractABI.Pack(method.Name, values...)
+		})
+		transactCmd.GroupID = transactGroup.ID
+		contractCmd.AddCommand(transactCmd)
+	}
+
+	if contractABI.HasFallback() {
+		bytesType, _ := abi.NewType("bytes", "", nil)
+		arguments := []ContractArgument{{Flag: "calldata", Input: abi.Argument{Name: "calldata", Type: bytesType}}}
+		fallbackCmd := createContractTransactCmd(contractName, envVar, "Fallback", arguments, func(values []interface{}) ([]byte, error) {
+			return values[0].([]byte), nil
+		})
+		fallbackCmd.GroupID = transactGroup.ID
+		contractCmd.AddCommand(fallbackCmd)
+	}
+	if contractABI.HasReceive() {
+		// Calldata is not allowed for the receive function.
+		receiveCmd := createContractTransactCmd(contractName, envVar, "Receive", nil, func(values []interface{}) ([]byte, error) {
+			return nil, nil
+		})
+		receiveCmd.GroupID = transactGroup.ID
+		contractCmd.AddCommand(receiveCmd)
+	}
+
+	return contractCmd
+}
+
+func createContractViewCmd(contractName, envVar string, contractABI *abi.ABI, method abi.Method) *cobra.Command {
+	var contractAddressRaw, rpc, blockNumberRaw, fromAddressRaw string
+	var pending bool
+	var timeout uint
+	var contractAddress common.Address
+	var values []interface{}
+
+	name := abi.ToCamelCase(method.Name)
+	arguments := ContractArguments(method.Inputs)
+
+	cmd := &cobra.Command{
+		Use:   kebabCase(name),
+		Short: fmt.Sprintf("Call the %s view method on a %s contract", name, contractName),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if contractAddressRaw == "" {
+				return fmt.Errorf("--contract not specified")
+			} else if !common.IsHexAddress(contractAddressRaw) {
+				return fmt.Errorf("--contract is not a valid Ethereum address")
+			}
+			contractAddress = common.HexToAddress(contractAddressRaw)
+
+			var err error
+			values, err = parseContractArguments(cmd, arguments)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newContractClient(rpc, envVar)
+			if err != nil {
+				return err
+			}
+
+			callOpts := bind.CallOpts{}
+			if err := setCallParameters(&callOpts, pending, fromAddressRaw, blockNumberRaw); err != nil {
+				return err
+			}
+			ctx, cancel := newChainContext(timeout)
+			defer cancel()
+			callOpts.Context = ctx
+
+			var results []interface{}
+			contract := bind.NewBoundContract(contractAddress, *contractABI, client, nil, nil)
+			if err := contract.Call(&callOpts, &results, method.Name, values...); err != nil {
+				return err
+			}
+
+			return output.Print(cmd, output.ViewResult{Outputs: ViewOutputs(method, results)})
+		},
+	}
+
+	cmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	cmd.Flags().StringVar(&blockNumberRaw, "block", "", "Block number at which to call the view method")
+	cmd.Flags().BoolVar(&pending, "pending", false, "Set this flag if it's ok to call the view method against pending state")
+	cmd.Flags().UintVar(&timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
+	cmd.Flags().StringVar(&fromAddressRaw, "from", "", "Optional address for caller of the view method")
+	addArgumentFlags(cmd, arguments)
+
+	return cmd
+}
+
+// Creates the command which sends a transaction calling a method (or the fallback or receive function) of
+// the contract. calldata encodes the arguments of the method.
+func createContractTransactCmd(contractName, envVar, name string, arguments []ContractArgument, calldata func(values []interface{}) ([]byte, error)) *cobra.Command {
+	var flags contractTransactionFlags
+	var contractAddressRaw string
+	var contractAddress common.Address
+	var values []interface{}
+
+	cmd := &cobra.Command{
+		Use:   kebabCase(name),
+		Short: fmt.Sprintf("Execute the %s method on a %s contract", name, contractName),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if contractAddressRaw == "" {
+				return fmt.Errorf("--contract not specified")
+			} else if !common.IsHexAddress(contractAddressRaw) {
+				return fmt.Errorf("--contract is not a valid Ethereum address")
+			}
+			contractAddress = common.HexToAddress(contractAddressRaw)
+
+			if err := flags.validate(envVar); err != nil {
+				return err
+			}
+
+			var err error
+			values, err = parseContractArguments(cmd, arguments)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := calldata(values)
+			if err != nil {
+				return err
+			}
+
+			client, txSigner, transactionOpts, err := flags.transactor(envVar)
+			if err != nil {
+				return err
+			}
+
+			if flags.safeAddress != "" {
+				value := transactionOpts.Value
+				if value == nil {
+					value = big.NewInt(0)
+				}
+				if flags.simulate {
+					simulation, err := safetx.SimulateProposal(client, common.HexToAddress(flags.safeAddress), contractAddress, data, value, safetx.OperationType(flags.safeOperation), flags.safeGasOptions)
+					if err != nil {
+						return fmt.Errorf("failed to simulate Safe transaction: %v", err)
+					}
+					return output.Print(cmd, simulation)
+				}
+
+				proposal, err := safetx.Propose(client, txSigner, common.HexToAddress(flags.safeAddress), contractAddress, data, value, flags.safeAPI, safetx.OperationType(flags.safeOperation), flags.safeGasOptions)
+				if err != nil {
+					return fmt.Errorf("failed to create Safe proposal: %v", err)
+				}
+				return output.Print(cmd, proposal)
+			}
+
+			contract := bind.NewBoundContract(contractAddress, abi.ABI{}, client, client, client)
+			transaction, err := contract.RawTransact(transactionOpts, data)
+			if err != nil {
+				return err
+			}
+			estimation := ethereum.CallMsg{From: transactionOpts.From, To: &contractAddress, Value: transactionOpts.Value, Data: data}
+			return flags.printTransaction(cmd, client, transactionOpts, transaction, "", estimation)
+		},
+	}
+
+	flags.add(cmd, 0, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
+	cmd.Flags().StringVar(&contractAddressRaw, "contract", "", "Address of the contract to interact with")
+	addArgumentFlags(cmd, arguments)
+
+	return cmd
+}
+
+func createContractDeployCmd(contractName, envVar string, metadata *bind.MetaData, contractABI *abi.ABI) *cobra.Command {
+	var flags contractTransactionFlags
+	var safeCreateCall, safeSaltRaw string
+	var safeWait bool
+	var safeWaitTimeout uint
+	var salt [32]byte
+	var values []interface{}
+
+	arguments := ContractArguments(contractABI.Constructor.Inputs)
+
+	cmd := &cobra.Command{
+		Use:   "deploy",
+		Short: fmt.Sprintf("Deploy a new %s contract", contractName),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := flags.validate(envVar); err != nil {
+				return err
+			}
+
+			if flags.safeAddress != "" {
+				if safeCreateCall == "" {
+					chainID, err := flags.chainID(envVar)
+					if err != nil {
+						return err
+					}
+					createCallAddress, err := chains.DefaultCreateCall(chainID)
+					if err != nil {
+						return fmt.Errorf("--safe-create-call not specified and no default is available: %v", err)
+					}
+					safeCreateCall = createCallAddress.Hex()
+					output.Infoln("--safe-create-call not specified, using default (", safeCreateCall, ")")
+				}
+				if !common.IsHexAddress(safeCreateCall) {
+					return fmt.Errorf("--safe-create-call is not a valid Ethereum address")
+				}
+
+				if safeSaltRaw == "" {
+					output.Infoln("--safe-salt not specified, generating random salt")
+					if _, err := rand.Read(salt[:]); err != nil {
+						return fmt.Errorf("failed to generate random salt: %v", err)
+					}
+					// prompt user to accept random salt
+					output.Infoln("Generated salt:", hexutil.Encode(salt[:]))
+					output.Infoln("Please check the salt and confirm (y/n)")
+					var confirm string
+					fmt.Scanln(&confirm)
+					if confirm != "y" && confirm != "Y" && confirm != "" {
+						return fmt.Errorf("salt not accepted, please specify a valid salt")
+					}
+				} else {
+					parsedSalt, err := safetx.ParseSalt(safeSaltRaw)
+					if err != nil {
+						return fmt.Errorf("--safe-salt: %v", err)
+					}
+					salt = parsedSalt
+				}
+			}
+
+			var err error
+			values, err = parseContractArguments(cmd, arguments)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deployBytecode, err := ContractDeploymentData(metadata, values)
+			if err != nil {
+				return fmt.Errorf("failed to generate deploy bytecode: %v", err)
+			}
+
+			client, txSigner, transactionOpts, err := flags.transactor(envVar)
+			if err != nil {
+				return err
+			}
+
+			if flags.safeAddress != "" {
+				safeAddress := common.HexToAddress(flags.safeAddress)
+				createCall := common.HexToAddress(safeCreateCall)
+				operation := safetx.OperationType(flags.safeOperation)
+				value := transactionOpts.Value
+				if value == nil {
+					value = big.NewInt(0)
+				}
+
+				contractAddress := safetx.Create2Address(safetx.CreateCallDeployer(safeAddress, createCall, operation), salt, deployBytecode)
+				output.Infoln("The contract will be deployed at", contractAddress.Hex(), "once the Safe transaction is executed")
+
+				if flags.simulate {
+					deployData, err := safetx.PerformCreate2Data(value, deployBytecode, salt)
+					if err != nil {
+						return err
+					}
+					simulation, err := safetx.SimulateProposal(client, safeAddress, createCall, deployData, value, operation, flags.safeGasOptions)
+					if err != nil {
+						return fmt.Errorf("failed to simulate Safe transaction: %v", err)
+					}
+					return output.Print(cmd, simulation)
+				}
+
+				proposal, err := safetx.DeployWithSafe(client, txSigner, safeAddress, createCall, value, flags.safeAPI, deployBytecode, operation, salt, flags.safeGasOptions)
+				if err != nil {
+					return fmt.Errorf("failed to create Safe proposal: %v", err)
+				}
+
+				if safeWait {
+					output.Infoln("Waiting for the Safe transaction with nonce", proposal.Nonce, "to be executed")
+					waitCtx, cancelWait := newChainContext(safeWaitTimeout)
+					defer cancelWait()
+					if err := safetx.WaitForDeployment(waitCtx, client, safeAddress, proposal.Nonce, contractAddress, 5*time.Second); err != nil {
+						return err
+					}
+				}
+
+				return output.Print(cmd, proposal)
+			}
+
+			// The constructor arguments are already encoded, so bind gets an empty ABI.
+			address, transaction, _, err := bind.DeployContract(transactionOpts, abi.ABI{}, deployBytecode, client)
+			if err != nil {
+				return err
+			}
+			estimation := ethereum.CallMsg{From: transactionOpts.From, Value: transactionOpts.Value, Data: deployBytecode}
+			return flags.printTransaction(cmd, client, transactionOpts, transaction, address.Hex(), estimation)
+		},
+	}
+
+	flags.add(cmd, 1, "Safe operation type: 0 (Call) or 1 (DelegateCall) - default is 1")
+	cmd.Flags().StringVar(&safeCreateCall, "safe-create-call", "", "Address of the CreateCall contract (optional)")
+	cmd.Flags().StringVar(&safeSaltRaw, "safe-salt", "", "CREATE2 salt for the deployment through the Safe, as 0x-prefixed hex (at most 32 bytes) or a decimal number (default: random)")
+	cmd.Flags().BoolVar(&safeWait, "safe-wait", false, "After proposing the deployment, wait for the Safe transaction to be executed and check that the contract was deployed")
+	cmd.Flags().UintVar(&safeWaitTimeout, "safe-wait-timeout", 3600, "Time (in seconds) for which --safe-wait waits for the Safe transaction to be executed")
+	addArgumentFlags(cmd, arguments)
+
+	return cmd
+}
+
+// The flags of the commands which send transactions, from the signer's account or through a Safe.
+type contractTransactionFlags struct {
+	rpc, keyfile, password                                     string
+	nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas string
+	gasLimit                                                   uint64
+	simulate                                                   bool
+	timeout                                                    uint
+	safeAddress, safeAPI                                       string
+	safeOperation                                              uint8
+	safeGasPriceRaw, safeGasTokenRaw, safeRefundReceiverRaw    string
+	safeGasOptions                                             safetx.GasOptions
+}
+
+func (f *contractTransactionFlags) add(cmd *cobra.Command, defaultOperation uint8, operationUsage string) {
+	cmd.Flags().StringVar(&f.rpc, "rpc", "", "URL of the JSONRPC API to use")
+	cmd.Flags().StringVar(&f.keyfile, "keyfile", "", "Path to the keystore file to use for the transaction")
+	cmd.Flags().StringVar(&f.password, "password", "", "Password to use to unlock the keystore (if not specified, it is read from --password-file, --password-env or --password-command, or you will be prompted for it when the command executes)")
+	cmd.Flags().StringVar(&f.nonce, "nonce", "", "Nonce to use for the transaction")
+	cmd.Flags().StringVar(&f.value, "value", "", "Value to send with the transaction")
+	cmd.Flags().StringVar(&f.gasPrice, "gas-price", "", "Gas price to use for the transaction")
+	cmd.Flags().StringVar(&f.maxFeePerGas, "max-fee-per-gas", "", "Maximum fee per gas to use for the (EIP-1559) transaction")
+	cmd.Flags().StringVar(&f.maxPriorityFeePerGas, "max-priority-fee-per-gas", "", "Maximum priority fee per gas to use for the (EIP-1559) transaction")
+	cmd.Flags().Uint64Var(&f.gasLimit, "gas-limit", 0, "Gas limit for the transaction")
+	cmd.Flags().BoolVar(&f.simulate, "simulate", false, "Simulate the transaction without sending it (with --safe, simulate the Safe transaction instead of proposing it)")
+	cmd.Flags().UintVar(&f.timeout, "timeout", 60, "Timeout (in seconds) for interactions with the JSONRPC API")
+	cmd.Flags().StringVar(&f.safeAddress, "safe", "", "Address of the Safe contract")
+	cmd.Flags().StringVar(&f.safeAPI, "safe-api", "", "Safe API for the Safe Transaction Service (optional)")
+	cmd.Flags().Uint8Var(&f.safeOperation, "safe-operation", defaultOperation, operationUsage)
+	cmd.Flags().StringVar(&f.safeGasPriceRaw, "safe-gas-price", "", "Gas price at which the Safe refunds the executor of the Safe transaction, in units of --safe-gas-token (optional, no refund by default)")
+	cmd.Flags().StringVar(&f.safeGasTokenRaw, "safe-gas-token", "", "Token in which the Safe refunds the executor of the Safe transaction (optional, the native currency by default)")
+	cmd.Flags().StringVar(&f.safeRefundReceiverRaw, "safe-refund-receiver", "", "Account to which the Safe pays the refund (optional, the executor of the Safe transaction by default)")
+}
+
+// Checks the signer, the RPC URL and the Safe flags, and fills in the default --safe-api.
+func (f *contractTransactionFlags) validate(envVar string) error {
+	if f.keyfile == "" && !signer.Configured() {
+		return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
+	}
+	if f.rpc == "" {
+		return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+	}
+
+	if f.safeAddress != "" {
+		if !common.IsHexAddress(f.safeAddress) {
+			return fmt.Errorf("--safe is not a valid Ethereum address")
+		}
+		if f.safeAPI == "" {
+			chainID, err := f.chainID(envVar)
+			if err != nil {
+				return err
+			}
+			f.safeAPI = chains.ProposeURL(chainID, common.HexToAddress(f.safeAddress))
+			output.Infoln("--safe-api not specified, using default (", f.safeAPI, ")")
+		}
+
+		if safetx.OperationType(f.safeOperation).String() == "Unknown" {
+			return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
+		}
+
+		var err error
+		f.safeGasOptions, err = safetx.ParseGasOptions(f.safeGasPriceRaw, f.safeGasTokenRaw, f.safeRefundReceiverRaw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *contractTransactionFlags) chainID(envVar string) (*big.Int, error) {
+	client, err := newContractClient(f.rpc, envVar)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := newChainContext(f.timeout)
+	defer cancel()
+	return client.ChainID(ctx)
+}
+
+// Connects to the JSONRPC API and loads the signer, with the transaction options set from the flags.
+func (f *contractTransactionFlags) transactor(envVar string) (*ethclient.Client, signer.Signer, *bind.TransactOpts, error) {
+	client, err := newContractClient(f.rpc, envVar)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	txSigner, err := signer.Load(f.keyfile, f.password)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx, cancel := newChainContext(f.timeout)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	transactionOpts, err := txSigner.TransactOpts(chainID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := setTransactionParameters(transactionOpts, f.nonce, f.value, f.gasPrice, f.maxFeePerGas, f.maxPriorityFeePerGas, f.gasLimit, f.simulate); err != nil {
+		return nil, nil, nil, err
+	}
+	return client, txSigner, transactionOpts, nil
+}
+
+// Prints a transaction which was sent or, when simulating, only built, with its estimated gas.
+func (f *contractTransactionFlags) printTransaction(cmd *cobra.Command, client *ethclient.Client, transactionOpts *bind.TransactOpts, transaction *types.Transaction, contractAddress string, estimation ethereum.CallMsg) error {
+	result := output.TransactionResult{Hash: transaction.Hash().Hex(), ContractAddress: contractAddress}
+	if !transactionOpts.NoSend {
+		result.Submitted = true
+		return output.Print(cmd, result)
+	}
+
+	ctx, cancel := newChainContext(f.timeout)
+	defer cancel()
+	gasEstimate, err := client.EstimateGas(ctx, estimation)
+	if err != nil {
+		return err
+	}
+	transactionBinary, err := transaction.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	result.RawTransaction = hex.EncodeToString(transactionBinary)
+	result.EstimatedGas = gasEstimate
+	return output.Print(cmd, result)
+}
+
+// Adds a flag for each argument. Integers which fit in 64 bits get flags of their type, all other
+// arguments string flags.
+func addArgumentFlags(cmd *cobra.Command, arguments []ContractArgument) {
+	for _, argument := range arguments {
+		usage := argument.Usage()
+		switch argument.Input.Type.GetType().Kind() {
+		case reflect.Uint8:
+			cmd.Flags().Uint8(argument.Flag, 0, usage)
+		case reflect.Uint16:
+			cmd.Flags().Uint16(argument.Flag, 0, usage)
+		case reflect.Uint32:
+			cmd.Flags().Uint32(argument.Flag, 0, usage)
+		case reflect.Uint64:
+			cmd.Flags().Uint64(argument.Flag, 0, usage)
+		case reflect.Int8:
+			cmd.Flags().Int8(argument.Flag, 0, usage)
+		case reflect.Int16:
+			cmd.Flags().Int16(argument.Flag, 0, usage)
+		case reflect.Int32:
+			cmd.Flags().Int32(argument.Flag, 0, usage)
+		case reflect.Int64:
+			cmd.Flags().Int64(argument.Flag, 0, usage)
+		default:
+			cmd.Flags().String(argument.Flag, "", usage)
+		}
+	}
+}
+
+func parseContractArguments(cmd *cobra.Command, arguments []ContractArgument) ([]interface{}, error) {
+	values := make([]interface{}, len(arguments))
+	for i, argument := range arguments {
+		value, err := argument.Parse(cmd.Flags().Lookup(argument.Flag).Value.String())
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
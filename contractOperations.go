@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ContractArgument is an input of a contract method (or constructor) together with the name of the
+// command line flag which sets it.
+type ContractArgument struct {
+	Flag  string
+	Input abi.Argument
+}
+
+// Names which contract arguments cannot take, because flags of the contract commands already use them.
+// An argument with one of these names gets a numeric suffix: the to argument of execTransaction is set
+// with --to-0, for example.
+var reservedArgumentNames = []string{
+	"rpc", "keystore", "keyfile", "value", "gasPrice", "nonce", "from", "fromAddress", "to", "toAddress",
+	"block", "pending", "maxFeePerGas", "maxPriorityFeePerGas", "gasLimit", "noSend", "timeout", "password",
+	"contract", "method", "args", "output", "chainId", "network", "simulate", "contractAddress", "name",
+	"safe", "safeApi", "safeOperation", "safeGasPrice", "safeGasToken", "safeRefundReceiver",
+	"safeCreateCall", "safeSalt", "safeWait",
+}
+
+// Returns the arguments of a contract method with the names of their flags. The names are those of the
+// inputs, as in the Go bindings (unnamed inputs and Go keywords become arg<index>), in kebab case.
+func ContractArguments(inputs abi.Arguments) []ContractArgument {
+	assigned := map[string]bool{}
+	for _, name := range reservedArgumentNames {
+		assigned[name] = true
+	}
+
+	arguments := make([]ContractArgument, len(inputs))
+	for i, input := range inputs {
+		bindingName := input.Name
+		if bindingName == "" || token.IsKeyword(bindingName) {
+			bindingName = fmt.Sprintf("arg%d", i)
+		}
+		name := strings.Trim(bindingName, "-_")
+		for j := 0; assigned[name]; j++ {
+			name = fmt.Sprintf("%s%d", bindingName, j)
+		}
+		assigned[name] = true
+		arguments[i] = ContractArgument{Flag: kebabCase(name), Input: input}
+	}
+	return arguments
+}
+
+// Returns the usage of the flag of a contract argument.
+func (a ContractArgument) Usage() string {
+	typ := a.Input.Type
+	switch {
+	case typ.T == abi.BoolTy:
+		return fmt.Sprintf("%s argument (true, t, y, yes, 1 OR false, f, n, no, 0)", a.Flag)
+	case typ.T == abi.StringTy, typ.T == abi.IntTy, typ.T == abi.UintTy:
+		return fmt.Sprintf("%s argument", a.Flag)
+	}
+	return fmt.Sprintf("%s argument (%s)", a.Flag, goTypeName(typ))
+}
+
+// Parses the value of a contract argument from its flag. Addresses, integers which do not fit in 64 bits,
+// arrays and tuples must be given. Arrays and tuples may be read from a JSON file with @<path>.
+func (a ContractArgument) Parse(raw string) (interface{}, error) {
+	typ := a.Input.Type
+	switch typ.T {
+	case abi.AddressTy:
+		if raw == "" {
+			return nil, fmt.Errorf("--%s argument not specified", a.Flag)
+		} else if !common.IsHexAddress(raw) {
+			return nil, fmt.Errorf("--%s argument is not a valid Ethereum address", a.Flag)
+		}
+
+	case abi.IntTy, abi.UintTy:
+		if raw == "" {
+			return nil, fmt.Errorf("--%s argument not specified", a.Flag)
+		}
+
+	case abi.BoolTy:
+		switch strings.ToLower(raw) {
+		case "true", "t", "y", "yes", "1":
+			raw = "true"
+		case "false", "f", "n", "no", "0":
+			raw = "false"
+		default:
+			return nil, fmt.Errorf("--%s argument is not valid (value: %s)", a.Flag, raw)
+		}
+
+	case abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		if raw == "" {
+			return nil, fmt.Errorf("--%s argument not specified", a.Flag)
+		}
+		if filename, ok := strings.CutPrefix(raw, "@"); ok {
+			contents, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			raw = string(contents)
+		}
+	}
+
+	value, err := parseArgument(typ, raw)
+	if err != nil {
+		return nil, fmt.Errorf("--%s argument: %v", a.Flag, err)
+	}
+	return value, nil
+}
+
+// Returns the name of the Go type of an ABI type, as shown in the usage of the flags of arguments.
+func goTypeName(typ abi.Type) string {
+	switch typ.T {
+	case abi.AddressTy:
+		return "common.Address"
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", typ.Size)
+	case abi.SliceTy:
+		return "[]" + goTypeName(*typ.Elem)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]%s", typ.Size, goTypeName(*typ.Elem))
+	case abi.TupleTy:
+		return typ.String()
+	}
+	return typ.GetType().String()
+}
+
+// Returns the outputs of a view method as the Go bindings do: a single return value as is, and several
+// in a struct with a field for each of them.
+func ViewOutputs(method abi.Method, results []interface{}) []interface{} {
+	if len(results) < 2 {
+		return results
+	}
+	fields := make([]reflect.StructField, len(results))
+	for i, result := range results {
+		name := method.Outputs[i].Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		fields[i] = reflect.StructField{Name: abi.ToCamelCase(name), Type: reflect.TypeOf(result)}
+	}
+	outputs := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, result := range results {
+		outputs.Field(i).Set(reflect.ValueOf(result))
+	}
+	return []interface{}{outputs.Interface()}
+}
+
+// Returns the creation code of a contract followed by its ABI encoded constructor arguments.
+func ContractDeploymentData(metadata *bind.MetaData, arguments []interface{}) ([]byte, error) {
+	contractABI, err := metadata.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ABI: %v", err)
+	}
+	encoded, err := contractABI.Pack("", arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack constructor arguments: %v", err)
+	}
+	return append(common.FromHex(metadata.Bin), encoded...), nil
+}
+
+// Returns the environment variable from which the contract commands of the named contract read the RPC
+// URL when --rpc is not given, e.g. SAFE_PROXY_FACTORY_RPC_URL.
+func contractRPCEnvVar(contractName string) string {
+	return delimitedCase(contractName, '_', true) + "_RPC_URL"
+}
+
+// Connects to the JSONRPC API at rpcURL, or at the URL in envVar if rpcURL is empty.
+func newContractClient(rpcURL, envVar string) (*ethclient.Client, error) {
+	if rpcURL == "" {
+		rpcURL = os.Getenv(envVar)
+	}
+	if rpcURL == "" {
+		return nil, errors.New("no RPC URL provided -- please pass an RPC URL from the command line or set the " + envVar + " environment variable")
+	}
+	return ethclient.Dial(rpcURL)
+}
+
+// Creates a new context for an interaction with the JSONRPC API which times out after timeout seconds.
+func newChainContext(timeout uint) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+}
+
+// Sets the parameters of a view call from their command line representation.
+func setCallParameters(opts *bind.CallOpts, pending bool, fromAddress, blockNumber string) error {
+	opts.Pending = pending
+	if fromAddress != "" {
+		if !common.IsHexAddress(fromAddress) {
+			return fmt.Errorf("--from is not a valid Ethereum address")
+		}
+		opts.From = common.HexToAddress(fromAddress)
+	}
+	if blockNumber != "" {
+		opts.BlockNumber = new(big.Int)
+		if _, ok := opts.BlockNumber.SetString(blockNumber, 0); !ok {
+			return fmt.Errorf("invalid block number: %s", blockNumber)
+		}
+	}
+	return nil
+}
+
+// Sets the parameters of a transaction from their command line representation.
+func setTransactionParameters(opts *bind.TransactOpts, nonce, value, gasPrice, maxFeePerGas, maxPriorityFeePerGas string, gasLimit uint64, noSend bool) error {
+	for _, parameter := range []struct {
+		flag  string
+		raw   string
+		value **big.Int
+	}{
+		{"nonce", nonce, &opts.Nonce},
+		{"value", value, &opts.Value},
+		{"gas-price", gasPrice, &opts.GasPrice},
+		{"max-fee-per-gas", maxFeePerGas, &opts.GasFeeCap},
+		{"max-priority-fee-per-gas", maxPriorityFeePerGas, &opts.GasTipCap},
+	} {
+		if parameter.raw == "" {
+			continue
+		}
+		number, ok := new(big.Int).SetString(parameter.raw, 0)
+		if !ok {
+			return fmt.Errorf("--%s is not a valid number: %s", parameter.flag, parameter.raw)
+		}
+		*parameter.value = number
+	}
+	if gasLimit != 0 {
+		opts.GasLimit = gasLimit
+	}
+	opts.NoSend = noSend
+	return nil
+}
+
+// Converts a camel case name (of a method, argument or contract) to kebab case: getOwners becomes
+// get-owners, and checkNSignatures0 becomes check-n-signatures-0.
+func kebabCase(name string) string {
+	return delimitedCase(strings.Trim(name, "-_"), '-', false)
+}
+
+// Splits a camel case name into words, separated by delimiter, in lower case or (if screaming) upper
+// case. Acronyms are kept as one word, and numbers are words of their own.
+func delimitedCase(name string, delimiter byte, screaming bool) string {
+	isUpper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	isLower := func(c byte) bool { return c >= 'a' && c <= 'z' }
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+
+	var builder strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		converted := c
+		if screaming && isLower(c) {
+			converted = c - 'a' + 'A'
+		} else if !screaming && isUpper(c) {
+			converted = c - 'A' + 'a'
+		}
+
+		if i+1 < len(name) {
+			next := name[i+1]
+			if (isUpper(c) && (isLower(next) || isDigit(next))) || (isLower(c) && (isUpper(next) || isDigit(next))) || (isDigit(c) && (isUpper(next) || isLower(next))) {
+				if isUpper(c) && isLower(next) && i > 0 && isUpper(name[i-1]) {
+					builder.WriteByte(delimiter)
+				}
+				builder.WriteByte(converted)
+				if isLower(c) || isDigit(c) || isDigit(next) {
+					builder.WriteByte(delimiter)
+				}
+				continue
+			}
+		}
+
+		if c == ' ' || c == '_' || c == '-' || c == '.' {
+			builder.WriteByte(delimiter)
+		} else {
+			builder.WriteByte(converted)
+		}
+	}
+	return builder.String()
+}
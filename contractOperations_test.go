@@ -0,0 +1,103 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/output"
+)
+
+func TestContractArguments(t *testing.T) {
+	safeABI, err := Safe.SafeMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("could not parse Safe ABI: %v", err)
+	}
+
+	// Arguments named like the flags of the commands get a numeric suffix, and unnamed ones their index.
+	for method, expected := range map[string][]string{
+		"execTransaction":       {"to-0", "value-0", "data", "operation", "safe-tx-gas", "base-gas", "gas-price-0", "gas-token", "refund-receiver", "signatures"},
+		"getTransactionHash":    {"to-0", "value-0", "data", "operation", "safe-tx-gas", "base-gas", "gas-price-0", "gas-token", "refund-receiver", "nonce-0"},
+		"approvedHashes":        {"arg-0", "arg-1"},
+		"checkNSignatures0":     {"executor", "data-hash", "signatures", "required-signatures"},
+		"addOwnerWithThreshold": {"owner", "threshold"},
+	} {
+		arguments := ContractArguments(safeABI.Methods[method].Inputs)
+		flags := make([]string, len(arguments))
+		for i, argument := range arguments {
+			flags[i] = argument.Flag
+		}
+		if strings.Join(flags, " ") != strings.Join(expected, " ") {
+			t.Errorf("flags of %s are %v, expected %v", method, flags, expected)
+		}
+	}
+
+	for name, expected := range map[string]string{
+		"getOwners":         "get-owners",
+		"checkNSignatures0": "check-n-signatures-0",
+		"VERSION":           "version",
+		"_singleton":        "singleton",
+	} {
+		if actual := kebabCase(name); actual != expected {
+			t.Errorf("kebab case of %s is %s, expected %s", name, actual, expected)
+		}
+	}
+	if envVar := contractRPCEnvVar("SafeProxyFactory"); envVar != "SAFE_PROXY_FACTORY_RPC_URL" {
+		t.Errorf("unexpected RPC URL environment variable: %s", envVar)
+	}
+}
+
+func TestParseContractArgument(t *testing.T) {
+	safeABI, err := Safe.SafeMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("could not parse Safe ABI: %v", err)
+	}
+	setup := ContractArguments(safeABI.Methods["setup"].Inputs)
+	owners, threshold, data := setup[0], setup[1], setup[3]
+
+	ownersFile := filepath.Join(t.TempDir(), "owners.json")
+	if err := os.WriteFile(ownersFile, []byte(`["0x00000000000000000000000000000000000000aa"]`), 0600); err != nil {
+		t.Fatalf("could not write owners: %v", err)
+	}
+	value, err := owners.Parse("@" + ownersFile)
+	if err != nil || len(value.([]common.Address)) != 1 || value.([]common.Address)[0] != common.HexToAddress("0xaa") {
+		t.Fatalf("could not read the owners from a file: %v, %v", value, err)
+	}
+	if value, err := threshold.Parse("0x10"); err != nil || value.(*big.Int).Int64() != 16 {
+		t.Fatalf("could not parse a hex threshold: %v, %v", value, err)
+	}
+	if value, err := data.Parse(""); err != nil || len(value.([]byte)) != 0 {
+		t.Fatalf("could not parse empty data: %v, %v", value, err)
+	}
+
+	for _, c := range []struct {
+		argument ContractArgument
+		raw      string
+		error    string
+	}{
+		{owners, "", "--owners argument not specified"},
+		{threshold, "", "--threshold argument not specified"},
+		{threshold, "two", "--threshold argument: invalid integer"},
+		{setup[2], "0x1234", "--to-0 argument is not a valid Ethereum address"},
+		{data, "xyz", "--data argument: invalid hex"},
+	} {
+		if _, err := c.argument.Parse(c.raw); err == nil || !strings.Contains(err.Error(), c.error) {
+			t.Errorf("--%s %q: expected an error containing %q, got %v", c.argument.Flag, c.raw, c.error, err)
+		}
+	}
+
+	// Several return values are combined into a struct, as in the Go bindings.
+	outputs := ViewOutputs(safeABI.Methods["getModulesPaginated"], []interface{}{[]common.Address{}, common.HexToAddress("0x1")})
+	if len(outputs) != 1 {
+		t.Fatalf("unexpected outputs of getModulesPaginated: %v", outputs)
+	}
+	normalized, ok := output.Normalize(outputs[0]).(map[string]interface{})
+	if !ok || normalized["Next"] != common.HexToAddress("0x1").Hex() || normalized["Array"] == nil {
+		t.Fatalf("unexpected outputs of getModulesPaginated: %v", normalized)
+	}
+}
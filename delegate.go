@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/signer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
@@ -49,16 +52,16 @@ func createAddDelegateCmd() *cobra.Command {
 				return fmt.Errorf("label is required")
 			}
 
-			if keyfile == "" {
-				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file)")
+			if keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
 			}
 
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			delegator, signerErr := signer.Load(keyfile, password)
+			if signerErr != nil {
+				return signerErr
 			}
 
 			client, err := ethclient.Dial(rpcURL)
@@ -72,19 +75,23 @@ func createAddDelegateCmd() *cobra.Command {
 			}
 
 			if safeAPIURL == "" {
-				safeAPIURL = fmt.Sprintf("https://safe-client.safe.global/v2/chains/%d/delegates/", chainID.Int64())
-				fmt.Println("safe-api is not set, using default: ", safeAPIURL)
+				safeAPIURL = chains.DelegatesURL(chainID)
+				output.Infoln("safe-api is not set, using default: ", safeAPIURL)
 			} else {
-				fmt.Println("Using custom safe-api URL: ", safeAPIURL)
+				output.Infoln("Using custom safe-api URL: ", safeAPIURL)
 			}
 
-			err = AddDelegate(safe, delegate, label, chainID, key, safeAPIURL)
+			err = AddDelegate(safe, delegate, label, chainID, delegator, safeAPIURL)
 			if err != nil {
-				cmd.Printf("Error adding delegate: %v\n", err)
 				return fmt.Errorf("error adding delegate: %v", err)
 			}
-			cmd.Printf("Successfully added delegate %s for Safe %s\n", delegate, safe)
-			return nil
+			return output.Print(cmd, DelegateResult{
+				Action:    "added",
+				Safe:      common.HexToAddress(safe).Hex(),
+				Delegate:  common.HexToAddress(delegate).Hex(),
+				Delegator: delegator.Address().Hex(),
+				Label:     label,
+			})
 		},
 	}
 
@@ -95,7 +102,6 @@ func createAddDelegateCmd() *cobra.Command {
 	addDelegateCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the keystore file")
 	addDelegateCmd.Flags().StringVar(&rpcURL, "rpc", "", "RPC URL to retrieve chain ID")
 	addDelegateCmd.Flags().StringVar(&safeAPIURL, "safe-api", "", "Override default Safe API URL")
-	addDelegateCmd.MarkFlagRequired("keyfile")
 	addDelegateCmd.MarkFlagRequired("safe")
 	addDelegateCmd.MarkFlagRequired("delegate")
 
@@ -135,22 +141,18 @@ func createListDelegatesCmd() *cobra.Command {
 			}
 
 			if safeAPIURL == "" {
-				safeAPIURL = fmt.Sprintf("https://safe-client.safe.global/v2/chains/%d/delegates/", chainID.Int64())
-				fmt.Println("safe-api is not set, using default: ", safeAPIURL)
+				safeAPIURL = chains.DelegatesURL(chainID)
+				output.Infoln("safe-api is not set, using default: ", safeAPIURL)
 			}
 
 			delegates, err := GetDelegates(safe, delegate, delegator, label, limit, offset, chainID, safeAPIURL)
 			if err != nil {
 				return fmt.Errorf("error retrieving delegates: %v", err)
 			}
-			if len(delegates) == 0 {
+			if len(delegates) == 0 && !output.IsJSON() {
 				return fmt.Errorf("no delegates found")
-			} else {
-				for _, d := range delegates {
-					cmd.Printf("Safe: %s, Delegate: %s, Delegator: %s, Label: %s\n", d.Safe, d.Delegate, d.Delegator, d.Label)
-				}
 			}
-			return nil
+			return output.Print(cmd, DelegatesResult(delegates))
 		},
 	}
 
@@ -187,8 +189,8 @@ func createRemoveDelegateCmd() *cobra.Command {
 				return fmt.Errorf("invalid delegate address: %s", delegate)
 			}
 
-			if keyfile == "" {
-				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file)")
+			if keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
 			}
 
 			return nil
@@ -197,9 +199,9 @@ func createRemoveDelegateCmd() *cobra.Command {
 			checksumSafe := common.HexToAddress(safe).Hex()
 			checksumDelegate := common.HexToAddress(delegate).Hex()
 
-			key, keyErr := KeyFromFile(keyfile, password)
-			if keyErr != nil {
-				return keyErr
+			delegator, signerErr := signer.Load(keyfile, password)
+			if signerErr != nil {
+				return signerErr
 			}
 
 			client, err := ethclient.Dial(rpcURL)
@@ -213,16 +215,20 @@ func createRemoveDelegateCmd() *cobra.Command {
 			}
 
 			if safeAPIURL == "" {
-				safeAPIURL = fmt.Sprintf("https://safe-client.safe.global/v2/chains/%d/delegates", chainID.Int64())
-				fmt.Println("safe-api is not set, using default: ", safeAPIURL)
+				safeAPIURL = chains.DelegateURL(chainID, common.HexToAddress(checksumDelegate))
+				output.Infoln("safe-api is not set, using default: ", safeAPIURL)
 			}
 
-			err = RemoveDelegate(checksumSafe, checksumDelegate, chainID, key, safeAPIURL)
+			err = RemoveDelegate(checksumSafe, checksumDelegate, chainID, delegator, safeAPIURL)
 			if err != nil {
 				return fmt.Errorf("error removing delegate: %v", err)
 			}
-			cmd.Printf("Successfully removed delegate %s from Safe %s\n", checksumDelegate, checksumSafe)
-			return nil
+			return output.Print(cmd, DelegateResult{
+				Action:    "removed",
+				Safe:      checksumSafe,
+				Delegate:  checksumDelegate,
+				Delegator: delegator.Address().Hex(),
+			})
 		},
 	}
 
@@ -233,7 +239,6 @@ func createRemoveDelegateCmd() *cobra.Command {
 	removeDelegateCmd.Flags().StringVar(&rpcURL, "rpc", "", "RPC URL to retrieve chain ID")
 	removeDelegateCmd.Flags().StringVar(&safeAPIURL, "safe-api", "", "Override default Safe API URL")
 	removeDelegateCmd.MarkFlagRequired("safe")
-	removeDelegateCmd.MarkFlagRequired("keyfile")
 	removeDelegateCmd.MarkFlagRequired("rpc")
 	removeDelegateCmd.MarkFlagRequired("delegate")
 
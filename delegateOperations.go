@@ -7,18 +7,15 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
-	"os"
-
 	"io"
 
-	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/G7DAO/safes/signer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
-	"golang.org/x/crypto/ssh/terminal"
 )
 
 type DelegateResponse struct {
@@ -28,14 +25,41 @@ type DelegateResponse struct {
 	Label     string `json:"label"`
 }
 
-func AddDelegate(safeAddress, delegateAddress, label string, chainID *big.Int, key *keystore.Key, apiURL string) error {
+// DelegatesResult is the result of listing the delegates of a Safe.
+type DelegatesResult []DelegateResponse
+
+func (r DelegatesResult) Text() string {
+	var builder strings.Builder
+	for _, d := range r {
+		fmt.Fprintf(&builder, "Safe: %s, Delegate: %s, Delegator: %s, Label: %s\n", d.Safe, d.Delegate, d.Delegator, d.Label)
+	}
+	return builder.String()
+}
+
+// DelegateResult is the result of adding or removing a delegate.
+type DelegateResult struct {
+	Action    string `json:"action"`
+	Safe      string `json:"safe"`
+	Delegate  string `json:"delegate"`
+	Delegator string `json:"delegator"`
+	Label     string `json:"label,omitempty"`
+}
+
+func (r DelegateResult) Text() string {
+	if r.Action == "removed" {
+		return fmt.Sprintf("Successfully removed delegate %s from Safe %s\n", r.Delegate, r.Safe)
+	}
+	return fmt.Sprintf("Successfully added delegate %s for Safe %s\n", r.Delegate, r.Safe)
+}
+
+func AddDelegate(safeAddress, delegateAddress, label string, chainID *big.Int, delegator signer.Signer, apiURL string) error {
 	// Generate TOTP (Time-based One-Time Password)
 	totp := big.NewInt(time.Now().Unix() / 3600)
 
 	// Convert addresses to checksum format
 	checksumSafe := common.HexToAddress(safeAddress).Hex()
 	checksumDelegate := common.HexToAddress(delegateAddress).Hex()
-	checksumSigner := key.Address.Hex()
+	checksumSigner := delegator.Address().Hex()
 
 	// Create EIP-712 message
 	typedData := apitypes.TypedData{
@@ -62,20 +86,15 @@ func AddDelegate(safeAddress, delegateAddress, label string, chainID *big.Int, k
 		},
 	}
 
-	typedDataHash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return fmt.Errorf("failed to hash typed data: %v", err)
+	// Show the typed data and have the delegator confirm it before signing it
+	if err := signer.ConfirmTypedData(typedData, fmt.Sprintf("Action: add %s as a delegate of %s for Safe %s", checksumDelegate, checksumSigner, checksumSafe)); err != nil {
+		return err
 	}
-
-	// Sign the typedDataHash
-	signature, err := crypto.Sign(common.BytesToHash(typedDataHash).Bytes(), key.PrivateKey)
+	signature, err := delegator.SignTypedData(typedData)
 	if err != nil {
-		return fmt.Errorf("failed to sign typed data hash: %v", err)
+		return fmt.Errorf("failed to sign typed data: %v", err)
 	}
 
-	// Adjust V value for Ethereum's replay protection
-	signature[64] += 27
-
 	// Convert signature to hex
 	senderSignature := "0x" + common.Bytes2Hex(signature)
 
@@ -112,8 +131,6 @@ func AddDelegate(safeAddress, delegateAddress, label string, chainID *big.Int, k
 		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	fmt.Println("Delegate added successfully.")
-
 	return nil
 }
 
@@ -172,14 +189,14 @@ func GetDelegates(safe, delegate, delegator, label string, limit, offset int, ch
 	return response.Results, nil
 }
 
-func RemoveDelegate(safeAddress, delegateAddress string, chainID *big.Int, key *keystore.Key, apiURL string) error {
+func RemoveDelegate(safeAddress, delegateAddress string, chainID *big.Int, delegator signer.Signer, apiURL string) error {
 	// Generate TOTP (Time-based One-Time Password)
 	totp := big.NewInt(time.Now().Unix() / 3600)
 
 	// Convert addresses to checksum format
 	checksumSafe := common.HexToAddress(safeAddress).Hex()
 	checksumDelegate := common.HexToAddress(delegateAddress).Hex()
-	checksumSigner := key.Address.Hex()
+	checksumSigner := delegator.Address().Hex()
 
 	// Create EIP-712 message
 	typedData := apitypes.TypedData{
@@ -206,20 +223,15 @@ func RemoveDelegate(safeAddress, delegateAddress string, chainID *big.Int, key *
 		},
 	}
 
-	typedDataHash, _, err := apitypes.TypedDataAndHash(typedData)
-	if err != nil {
-		return fmt.Errorf("failed to hash typed data: %v", err)
+	// Show the typed data and have the delegator confirm it before signing it
+	if err := signer.ConfirmTypedData(typedData, fmt.Sprintf("Action: remove %s as a delegate of %s for Safe %s", checksumDelegate, checksumSigner, checksumSafe)); err != nil {
+		return err
 	}
-
-	// Sign the SafeTxHash
-	signature, err := crypto.Sign(common.BytesToHash(typedDataHash).Bytes(), key.PrivateKey)
+	signature, err := delegator.SignTypedData(typedData)
 	if err != nil {
-		return fmt.Errorf("failed to sign SafeTxHash: %v", err)
+		return fmt.Errorf("failed to sign typed data: %v", err)
 	}
 
-	// Adjust V value for Ethereum's replay protection
-	signature[64] += 27
-
 	// Convert signature to hex
 	senderSignature := "0x" + common.Bytes2Hex(signature)
 
@@ -255,29 +267,5 @@ func RemoveDelegate(safeAddress, delegateAddress string, chainID *big.Int, key *
 		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	fmt.Println("Delegate removed successfully.")
-
 	return nil
 }
-
-func KeyFromFile(keystoreFile string, password string) (*keystore.Key, error) {
-	var emptyKey *keystore.Key
-	keystoreContent, readErr := os.ReadFile(keystoreFile)
-	if readErr != nil {
-		return emptyKey, readErr
-	}
-
-	// If password is "", prompt user for password.
-	if password == "" {
-		fmt.Printf("Please provide a password for keystore (%s): ", keystoreFile)
-		passwordRaw, inputErr := terminal.ReadPassword(int(os.Stdin.Fd()))
-		if inputErr != nil {
-			return emptyKey, fmt.Errorf("error reading password: %s", inputErr.Error())
-		}
-		fmt.Print("\n")
-		password = string(passwordRaw)
-	}
-
-	key, err := keystore.DecryptKey(keystoreContent, password)
-	return key, err
-}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+)
+
+func CreateDeployArtifactCmd() *cobra.Command {
+	var (
+		artifactPath      string
+		arguments         []string
+		librarySpecs      []string
+		rpc               string
+		keyfile           string
+		password          string
+		valueRaw          string
+		safeAddress       string
+		safeAPI           string
+		safeCreateCall    string
+		safeSaltRaw       string
+		safeOperationType uint8
+		safeGasPriceRaw   string
+		safeGasTokenRaw   string
+		safeRefundRaw     string
+		safeGasOptions    safetx.GasOptions
+		value             *big.Int
+		libraries         map[string]common.Address
+	)
+
+	deployArtifactCmd := &cobra.Command{
+		Use:   "deploy-artifact",
+		Short: "Deploy a contract from a Hardhat or Foundry artifact",
+		Long: `Deploy a contract from a Hardhat or Foundry artifact JSON file.
+
+Constructor arguments are given in order with --arg: addresses and bytes as hex, integers in decimal or as
+0x-prefixed hex, booleans as true or false, and arrays and tuples as JSON arrays. Libraries are linked
+with --library <name>=<address> (or <source file>:<name>=<address> if the name is ambiguous).
+
+Without --safe, the contract is deployed from the signer's account. With --safe, a Safe transaction which
+calls CreateCall is proposed instead: performCreate2 with --safe-salt, or performCreate without it. The
+address at which the contract will be deployed is reported. For performCreate, it is only accurate if the
+deployer creates no other contract before the proposal is executed.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if artifactPath == "" {
+				return fmt.Errorf("--artifact not specified")
+			}
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
+			}
+
+			value = big.NewInt(0)
+			if valueRaw != "" {
+				if _, ok := value.SetString(valueRaw, 0); !ok {
+					return fmt.Errorf("invalid value: %s", valueRaw)
+				}
+			}
+
+			var err error
+			libraries, err = ParseLibraries(librarySpecs)
+			if err != nil {
+				return err
+			}
+
+			if safeAddress != "" {
+				if !common.IsHexAddress(safeAddress) {
+					return fmt.Errorf("--safe is not a valid Ethereum address")
+				}
+				if safeCreateCall != "" && !common.IsHexAddress(safeCreateCall) {
+					return fmt.Errorf("--safe-create-call is not a valid Ethereum address")
+				}
+				if safetx.OperationType(safeOperationType).String() == "Unknown" {
+					return fmt.Errorf("--safe-operation must be 0 (Call) or 1 (DelegateCall)")
+				}
+				safeGasOptions, err = safetx.ParseGasOptions(safeGasPriceRaw, safeGasTokenRaw, safeRefundRaw)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			artifact, err := LoadArtifact(artifactPath)
+			if err != nil {
+				return err
+			}
+			deploymentData, err := ArtifactDeploymentData(artifact, libraries, arguments)
+			if err != nil {
+				return err
+			}
+
+			client, err := ethclient.Dial(rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			chainID, err := client.ChainID(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+
+			txSigner, err := signer.Load(keyfile, password)
+			if err != nil {
+				return err
+			}
+
+			if safeAddress == "" {
+				opts, err := txSigner.TransactOpts(chainID)
+				if err != nil {
+					return err
+				}
+				opts.Value = value
+				// The constructor arguments are already encoded, so bind gets an empty ABI.
+				address, tx, _, err := bind.DeployContract(opts, abi.ABI{}, deploymentData, client)
+				if err != nil {
+					return fmt.Errorf("failed to deploy %s: %v", artifactName(artifact, artifactPath), err)
+				}
+				return output.Print(cmd, output.TransactionResult{Hash: tx.Hash().Hex(), ContractAddress: address.Hex(), Submitted: true})
+			}
+
+			safe := common.HexToAddress(safeAddress)
+			if safeAPI == "" {
+				safeAPI = chains.ProposeURL(chainID, safe)
+				output.Infoln("--safe-api not specified, using default (", safeAPI, ")")
+			}
+			if safeCreateCall == "" {
+				createCallAddress, err := chains.DefaultCreateCall(chainID)
+				if err != nil {
+					return fmt.Errorf("--safe-create-call not specified and no default is available: %v", err)
+				}
+				safeCreateCall = createCallAddress.Hex()
+				output.Infoln("--safe-create-call not specified, using default (", safeCreateCall, ")")
+			}
+			createCall := common.HexToAddress(safeCreateCall)
+			operation := safetx.OperationType(safeOperationType)
+
+			var proposal *output.ProposalResult
+			if safeSaltRaw != "" {
+				salt, err := safetx.ParseSalt(safeSaltRaw)
+				if err != nil {
+					return fmt.Errorf("--safe-salt: %v", err)
+				}
+				contractAddress := safetx.Create2Address(safetx.CreateCallDeployer(safe, createCall, operation), salt, deploymentData)
+				output.Infoln("The contract will be deployed at", contractAddress.Hex(), "once the Safe transaction is executed")
+				proposal, err = safetx.DeployWithSafe(client, txSigner, safe, createCall, value, safeAPI, deploymentData, operation, salt, safeGasOptions)
+				if err != nil {
+					return fmt.Errorf("failed to create Safe proposal: %v", err)
+				}
+			} else {
+				proposal, err = safetx.DeployWithSafeCreate(client, txSigner, safe, createCall, value, safeAPI, deploymentData, operation, safeGasOptions)
+				if err != nil {
+					return fmt.Errorf("failed to create Safe proposal: %v", err)
+				}
+			}
+			return output.Print(cmd, proposal)
+		},
+	}
+
+	deployArtifactCmd.Flags().StringVar(&artifactPath, "artifact", "", "Path to the Hardhat or Foundry artifact JSON file")
+	deployArtifactCmd.Flags().StringArrayVar(&arguments, "arg", nil, "Constructor argument (repeat for each argument, in order)")
+	deployArtifactCmd.Flags().StringArrayVar(&librarySpecs, "library", nil, "Library address, as <name>=<address> (repeat for each library)")
+	deployArtifactCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	deployArtifactCmd.Flags().StringVarP(&keyfile, "keyfile", "k", "", "Path to the keystore file")
+	deployArtifactCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the keystore file")
+	deployArtifactCmd.Flags().StringVar(&valueRaw, "value", "", "Value (in wei) to send to the constructor")
+	deployArtifactCmd.Flags().StringVar(&safeAddress, "safe", "", "Address of the Safe to deploy the contract through (optional)")
+	deployArtifactCmd.Flags().StringVar(&safeAPI, "safe-api", "", "URL to which the Safe transaction is proposed (default: derived from --transaction-service or the chain registry)")
+	deployArtifactCmd.Flags().StringVar(&safeCreateCall, "safe-create-call", "", "Address of the CreateCall contract (default: from the chain registry)")
+	deployArtifactCmd.Flags().Uint8Var(&safeOperationType, "safe-operation", 1, "Safe operation type: 0 (Call) or 1 (DelegateCall)")
+	deployArtifactCmd.Flags().StringVar(&safeGasPriceRaw, "safe-gas-price", "", "Gas price at which the Safe refunds the executor of the Safe transaction, in units of --safe-gas-token (optional, no refund by default)")
+	deployArtifactCmd.Flags().StringVar(&safeGasTokenRaw, "safe-gas-token", "", "Token in which the Safe refunds the executor of the Safe transaction (optional, the native currency by default)")
+	deployArtifactCmd.Flags().StringVar(&safeRefundRaw, "safe-refund-receiver", "", "Account to which the Safe pays the refund (optional, the executor of the Safe transaction by default)")
+	deployArtifactCmd.Flags().StringVar(&safeSaltRaw, "safe-salt", "", "CREATE2 salt, as 0x-prefixed hex (at most 32 bytes) or a decimal number (if not specified, CreateCall's performCreate is used)")
+
+	return deployArtifactCmd
+}
+
+func artifactName(artifact *Artifact, path string) string {
+	if artifact.ContractName != "" {
+		return artifact.ContractName
+	}
+	return path
+}
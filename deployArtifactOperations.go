@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LinkReference is the position of a library address placeholder in a contract's bytecode, in bytes.
+type LinkReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// Artifact is a compiled contract, as written by Hardhat or Foundry.
+type Artifact struct {
+	ContractName string
+	ABI          abi.ABI
+	// Bytecode is the hex encoded creation code of the contract, which may contain placeholders for
+	// library addresses.
+	Bytecode string
+	// LinkReferences maps source files to library names to the positions of their placeholders.
+	LinkReferences map[string]map[string][]LinkReference
+}
+
+// The bytecode of a Foundry artifact.
+type foundryBytecode struct {
+	Object         string                                `json:"object"`
+	LinkReferences map[string]map[string][]LinkReference `json:"linkReferences"`
+}
+
+// Loads a Hardhat or Foundry artifact. Hardhat artifacts hold the bytecode as a string, with the link
+// references next to it. Foundry artifacts hold an object with both.
+func LoadArtifact(path string) (*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %v", err)
+	}
+
+	var raw struct {
+		ContractName   string                                `json:"contractName"`
+		ABI            json.RawMessage                       `json:"abi"`
+		Bytecode       json.RawMessage                       `json:"bytecode"`
+		LinkReferences map[string]map[string][]LinkReference `json:"linkReferences"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse artifact %s: %v", path, err)
+	}
+	if len(raw.ABI) == 0 || len(raw.Bytecode) == 0 {
+		return nil, fmt.Errorf("%s is not a Hardhat or Foundry artifact: it needs abi and bytecode", path)
+	}
+
+	artifact := &Artifact{ContractName: raw.ContractName, LinkReferences: raw.LinkReferences}
+	if artifact.ABI, err = abi.JSON(bytes.NewReader(raw.ABI)); err != nil {
+		return nil, fmt.Errorf("failed to parse ABI of artifact %s: %v", path, err)
+	}
+
+	if err := json.Unmarshal(raw.Bytecode, &artifact.Bytecode); err != nil {
+		var foundry foundryBytecode
+		if err := json.Unmarshal(raw.Bytecode, &foundry); err != nil {
+			return nil, fmt.Errorf("failed to parse bytecode of artifact %s: %v", path, err)
+		}
+		artifact.Bytecode = foundry.Object
+		artifact.LinkReferences = foundry.LinkReferences
+	}
+	artifact.Bytecode = strings.TrimPrefix(artifact.Bytecode, "0x")
+	if artifact.Bytecode == "" {
+		return nil, fmt.Errorf("artifact %s has no bytecode (is it an interface or an abstract contract?)", path)
+	}
+	return artifact, nil
+}
+
+// Parses library addresses given as <name>=<address> or <source file>:<name>=<address>.
+func ParseLibraries(specs []string) (map[string]common.Address, error) {
+	libraries := map[string]common.Address{}
+	for _, spec := range specs {
+		name, address, ok := strings.Cut(spec, "=")
+		if !ok || name == "" || !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid library: %s (expected <name>=<address>)", spec)
+		}
+		libraries[name] = common.HexToAddress(address)
+	}
+	return libraries, nil
+}
+
+// Returns the creation code of the artifact with the given libraries linked in. Libraries are looked up
+// by <source file>:<name> first, then by name.
+func (a *Artifact) Link(libraries map[string]common.Address) ([]byte, error) {
+	bytecode := []byte(a.Bytecode)
+
+	files := make([]string, 0, len(a.LinkReferences))
+	for file := range a.LinkReferences {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		for name, references := range a.LinkReferences[file] {
+			address, ok := libraries[file+":"+name]
+			if !ok {
+				address, ok = libraries[name]
+			}
+			if !ok {
+				return nil, fmt.Errorf("library %s:%s is not linked (pass --library %s=<address>)", file, name, name)
+			}
+			addressHex := []byte(hex.EncodeToString(address.Bytes()))
+			for _, reference := range references {
+				start, end := 2*reference.Start, 2*(reference.Start+reference.Length)
+				if reference.Length != common.AddressLength || end > len(bytecode) {
+					return nil, fmt.Errorf("invalid link reference for library %s:%s", file, name)
+				}
+				copy(bytecode[start:end], addressHex)
+			}
+		}
+	}
+
+	code, err := hex.DecodeString(string(bytecode))
+	if err != nil {
+		if strings.Contains(string(bytecode), "__") {
+			return nil, fmt.Errorf("the bytecode has unlinked libraries which are missing from its link references")
+		}
+		return nil, fmt.Errorf("invalid bytecode: %v", err)
+	}
+	return code, nil
+}
+
+// Parses constructor (or method) arguments from their command line representation. Simple values are
+// given as is: addresses and bytes as hex, integers in decimal or as 0x-prefixed hex, booleans as true or
+// false. Arrays and tuples are given as JSON arrays of such values.
+func ParseArguments(inputs abi.Arguments, raw []string) ([]interface{}, error) {
+	if len(raw) != len(inputs) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(inputs), len(raw))
+	}
+	values := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		value, err := parseArgument(input.Type, raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %s (%s): %v", input.Name, input.Type.String(), err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Parses a single argument of type typ from its command line representation (see ParseArguments).
+func parseArgument(typ abi.Type, raw string) (interface{}, error) {
+	var value interface{} = raw
+	if typ.T == abi.SliceTy || typ.T == abi.ArrayTy || typ.T == abi.TupleTy {
+		decoder := json.NewDecoder(strings.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("expected a JSON array: %v", err)
+		}
+	}
+	converted, err := convertArgument(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	return converted.Interface(), nil
+}
+
+// Converts a string, JSON number, boolean or array to the Go value which go-ethereum packs as typ.
+func convertArgument(typ abi.Type, value interface{}) (reflect.Value, error) {
+	text := fmt.Sprint(value)
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(text) {
+			return reflect.Value{}, fmt.Errorf("invalid address: %s", text)
+		}
+		return reflect.ValueOf(common.HexToAddress(text)), nil
+
+	case abi.BoolTy:
+		switch text {
+		case "true":
+			return reflect.ValueOf(true), nil
+		case "false":
+			return reflect.ValueOf(false), nil
+		}
+		return reflect.Value{}, fmt.Errorf("invalid boolean: %s", text)
+
+	case abi.StringTy:
+		return reflect.ValueOf(text), nil
+
+	case abi.IntTy, abi.UintTy:
+		number, ok := new(big.Int).SetString(text, 0)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("invalid integer: %s", text)
+		}
+		if typ.T == abi.UintTy && (number.Sign() < 0 || number.BitLen() > typ.Size) {
+			return reflect.Value{}, fmt.Errorf("%s does not fit in uint%d", text, typ.Size)
+		}
+		if typ.T == abi.IntTy {
+			limit := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+			if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return reflect.Value{}, fmt.Errorf("%s does not fit in int%d", text, typ.Size)
+			}
+		}
+		goType := typ.GetType()
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(number), nil
+		}
+		if typ.T == abi.UintTy {
+			return reflect.ValueOf(number.Uint64()).Convert(goType), nil
+		}
+		return reflect.ValueOf(number.Int64()).Convert(goType), nil
+
+	case abi.BytesTy:
+		data, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid hex: %s", text)
+		}
+		return reflect.ValueOf(data), nil
+
+	case abi.FixedBytesTy:
+		data, err := hex.DecodeString(strings.TrimPrefix(text, "0x"))
+		if err != nil || len(data) != typ.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes of hex: %s", typ.Size, text)
+		}
+		array := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(array, reflect.ValueOf(data))
+		return array, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		elements, ok := value.([]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected an array")
+		}
+		var collection reflect.Value
+		if typ.T == abi.SliceTy {
+			collection = reflect.MakeSlice(typ.GetType(), len(elements), len(elements))
+		} else {
+			if len(elements) != typ.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", typ.Size, len(elements))
+			}
+			collection = reflect.New(typ.GetType()).Elem()
+		}
+		for i, element := range elements {
+			converted, err := convertArgument(*typ.Elem, element)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %v", i, err)
+			}
+			collection.Index(i).Set(converted)
+		}
+		return collection, nil
+
+	case abi.TupleTy:
+		elements, ok := value.([]interface{})
+		if !ok || len(elements) != len(typ.TupleElems) {
+			return reflect.Value{}, fmt.Errorf("expected an array of %d tuple components", len(typ.TupleElems))
+		}
+		tuple := reflect.New(typ.GetType()).Elem()
+		for i, element := range elements {
+			converted, err := convertArgument(*typ.TupleElems[i], element)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("component %s: %v", typ.TupleRawNames[i], err)
+			}
+			tuple.Field(i).Set(converted)
+		}
+		return tuple, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("unsupported type")
+}
+
+// Returns the creation code of the artifact, linked with the given libraries, followed by its ABI
+// encoded constructor arguments.
+func ArtifactDeploymentData(artifact *Artifact, libraries map[string]common.Address, rawArguments []string) ([]byte, error) {
+	code, err := artifact.Link(libraries)
+	if err != nil {
+		return nil, err
+	}
+	arguments, err := ParseArguments(artifact.ABI.Constructor.Inputs, rawArguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constructor arguments: %v", err)
+	}
+	encoded, err := artifact.ABI.Pack("", arguments...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode constructor arguments: %v", err)
+	}
+	return append(code, encoded...), nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/moonstream-to/seer/bindings/CreateCall"
+
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+const artifactABI = `[{"type":"constructor","stateMutability":"payable","inputs":[
+	{"name":"owner","type":"address"},
+	{"name":"count","type":"uint8"},
+	{"name":"deltas","type":"int16[]"},
+	{"name":"config","type":"tuple","components":[{"name":"id","type":"bytes32"},{"name":"enabled","type":"bool"}]},
+	{"name":"label","type":"string"}
+]}]`
+
+const libraryPlaceholder = "__$aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa$__"
+
+// Returns the hex creation code of a contract whose runtime code returns the address of its linked
+// library, Lib in contracts/Lib.sol at byte 13. Constructor arguments are ignored.
+func libraryUserBytecode() string {
+	// PUSH20 library PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	runtime := "73" + libraryPlaceholder + "60005260206000f3"
+	// PUSH2 len DUP1 PUSH1 12 PUSH1 0 CODECOPY PUSH1 0 RETURN, as in simtest.DeployCode.
+	return "0x61" + hex.EncodeToString([]byte{0, byte(len(runtime) / 2)}) + "80600c6000396000f3" + runtime
+}
+
+// Writes a Hardhat or Foundry artifact of the library user contract and returns its path.
+func writeArtifact(t *testing.T, foundry bool) string {
+	t.Helper()
+	linkReferences := map[string]map[string][]LinkReference{"contracts/Lib.sol": {"Lib": {{Start: 13, Length: 20}}}}
+	artifact := map[string]interface{}{"contractName": "LibraryUser", "abi": json.RawMessage(artifactABI)}
+	if foundry {
+		artifact["bytecode"] = foundryBytecode{Object: libraryUserBytecode(), LinkReferences: linkReferences}
+	} else {
+		artifact["bytecode"] = libraryUserBytecode()
+		artifact["linkReferences"] = linkReferences
+	}
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		t.Fatalf("could not encode artifact: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "LibraryUser.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("could not write artifact: %v", err)
+	}
+	return path
+}
+
+var artifactArguments = []string{
+	"--arg", "0x00000000000000000000000000000000000000aa",
+	"--arg", "255",
+	"--arg", `[-1, 2, "0x7fff"]`,
+	"--arg", `["0x` + strings.Repeat("11", 32) + `", true]`,
+	"--arg", "hello",
+}
+
+// Returns the ABI encoding of artifactArguments.
+func encodedArtifactArguments(t *testing.T) []byte {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(artifactABI))
+	if err != nil {
+		t.Fatalf("could not parse ABI: %v", err)
+	}
+	var id [32]byte
+	copy(id[:], bytes.Repeat([]byte{0x11}, 32))
+	config := struct {
+		Id      [32]byte
+		Enabled bool
+	}{id, true}
+	encoded, err := parsed.Pack("", common.HexToAddress("0xaa"), uint8(255), []int16{-1, 2, 0x7fff}, config, "hello")
+	if err != nil {
+		t.Fatalf("could not encode arguments: %v", err)
+	}
+	return encoded
+}
+
+// Checks that the contract at address returns library, i.e. that it was deployed with the library linked.
+func checkLinkedLibrary(t *testing.T, chain *simtest.Chain, address, library common.Address) {
+	t.Helper()
+	code, err := chain.Client.CodeAt(context.Background(), address, nil)
+	if err != nil || len(code) == 0 {
+		t.Fatalf("no contract at %s: %v", address.Hex(), err)
+	}
+	if !bytes.Equal(code[1:21], library.Bytes()) {
+		t.Fatalf("the contract at %s was linked with %x, expected %s", address.Hex(), code[1:21], library.Hex())
+	}
+}
+
+func TestDeployArtifact(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	library := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	args := append([]string{"deploy-artifact",
+		"--rpc", chain.Endpoint,
+		"--keyfile", chain.Keystore(t, deployer),
+		"--password", simtest.KeystorePassword,
+		"--artifact", writeArtifact(t, false),
+		"--library", "Lib=" + library.Hex(),
+	}, artifactArguments...)
+	var result output.TransactionResult
+	mustRunCLI(t, &result, args...)
+	chain.Receipt(t, common.HexToHash(result.Hash))
+	checkLinkedLibrary(t, chain, common.HexToAddress(result.ContractAddress), library)
+
+	tx, _, err := chain.Client.TransactionByHash(context.Background(), common.HexToHash(result.Hash))
+	if err != nil {
+		t.Fatalf("could not get deployment transaction: %v", err)
+	}
+	if !bytes.HasSuffix(tx.Data(), encodedArtifactArguments(t)) {
+		t.Fatalf("the deployment does not end with the encoded constructor arguments")
+	}
+
+	// Libraries must be linked.
+	args = []string{"deploy-artifact",
+		"--rpc", chain.Endpoint,
+		"--keyfile", chain.Keystore(t, deployer),
+		"--password", simtest.KeystorePassword,
+		"--artifact", writeArtifact(t, false),
+	}
+	if err := runCLI(t, nil, append(args, artifactArguments...)...); err == nil || !strings.Contains(err.Error(), "contracts/Lib.sol:Lib is not linked") {
+		t.Fatalf("expected a missing library to be reported, got %v", err)
+	}
+}
+
+func TestDeployArtifactThroughSafe(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	createCall, tx, _, err := CreateCall.DeployCreateCall(chain.TransactOpts(t, deployer), chain.Client)
+	if err != nil {
+		t.Fatalf("could not deploy CreateCall: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+	library := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+
+	cases := []struct {
+		name      string
+		extra     []string
+		operation safetx.OperationType
+	}{
+		// The CreateCall of the test chain is not in the chain registry.
+		{"performCreate2", []string{"--safe-salt", "0x01", "--safe-operation", "1", "--i-know-what-im-doing"}, safetx.DelegateCall},
+		{"performCreate", []string{"--safe-operation", "0"}, safetx.Call},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var proposed map[string]interface{}
+			service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+					t.Errorf("could not decode proposal: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer service.Close()
+
+			args := append([]string{"deploy-artifact",
+				"--rpc", chain.Endpoint,
+				"--keyfile", chain.Keystore(t, owner1),
+				"--password", simtest.KeystorePassword,
+				"--artifact", writeArtifact(t, true),
+				"--library", "contracts/Lib.sol:Lib=" + library.Hex(),
+				"--safe", safeAddress.Hex(),
+				"--safe-api", service.URL,
+				"--safe-create-call", createCall.Hex(),
+			}, c.extra...)
+			var proposal output.ProposalResult
+			mustRunCLI(t, &proposal, append(args, artifactArguments...)...)
+			if proposal.ContractAddress == "" {
+				t.Fatalf("the proposal does not report the contract address")
+			}
+
+			receipt, err := h.execTransactionTo(createCall, common.FromHex(proposed["data"].(string)), c.operation, owner1)
+			if err != nil || len(parseEvents(h, receipt, h.filterer.ParseExecutionSuccess)) != 1 {
+				t.Fatalf("could not execute the deployment: %v", err)
+			}
+			checkLinkedLibrary(t, chain, common.HexToAddress(proposal.ContractAddress), library)
+		})
+	}
+}
+
+func TestParseArguments(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(artifactABI))
+	if err != nil {
+		t.Fatalf("could not parse ABI: %v", err)
+	}
+	inputs := parsed.Constructor.Inputs
+	valid := []string{"0x00000000000000000000000000000000000000aa", "0xff", "[]", `["0x` + strings.Repeat("00", 32) + `", false]`, ""}
+	if _, err := ParseArguments(inputs, valid); err != nil {
+		t.Fatalf("could not parse valid arguments: %v", err)
+	}
+
+	invalid := []struct {
+		index int
+		value string
+		error string
+	}{
+		{0, "0x1234", "invalid address"},
+		{1, "256", "does not fit in uint8"},
+		{1, "-1", "does not fit in uint8"},
+		{2, `[-32769]`, "does not fit in int16"},
+		{2, `1`, "expected an array"},
+		{3, `["0x00", true]`, "expected 32 bytes"},
+		{3, `["0x` + strings.Repeat("00", 32) + `"]`, "tuple components"},
+		{3, `["0x` + strings.Repeat("00", 32) + `", "yes"]`, "invalid boolean"},
+	}
+	for _, c := range invalid {
+		arguments := append([]string{}, valid...)
+		arguments[c.index] = c.value
+		if _, err := ParseArguments(inputs, arguments); err == nil || !strings.Contains(err.Error(), c.error) {
+			t.Errorf("argument %d = %s: expected an error containing %q, got %v", c.index, c.value, c.error, err)
+		}
+	}
+	if _, err := ParseArguments(inputs, valid[:4]); err == nil {
+		t.Errorf("expected a missing argument to be reported")
+	}
+
+	// Big integers are not truncated.
+	big256, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		t.Fatalf("could not create type: %v", err)
+	}
+	values, err := ParseArguments(abi.Arguments{{Name: "amount", Type: big256}}, []string{"115792089237316195423570985008687907853269984665640564039457584007913129639935"})
+	if err != nil || values[0].(*big.Int).BitLen() != 256 {
+		t.Fatalf("could not parse a uint256: %v", err)
+	}
+}
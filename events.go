@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/output"
+)
+
+func createEventsCmd() *cobra.Command {
+	var (
+		safe          string
+		rpc           string
+		fromBlock     uint64
+		toBlock       uint64
+		confirmations uint64
+		chunkSize     uint64
+		dbPath        string
+	)
+
+	eventsCmd := &cobra.Command{
+		Use:   "events",
+		Short: "Scan the events emitted by a Safe",
+		Long: `Scan the events emitted by a Safe, decode them and print them in timestamp order.
+
+Blocks are scanned --chunk-size blocks at a time. If the node refuses a query (because the range has
+too many blocks or logs), the chunk size is halved until the node accepts it.
+
+With --db, events are stored in a SQLite database along with the last block which was scanned, and later
+runs resume from the block after it: they print the stored events, followed by the events of the
+blocks which they scan. Without --from-block or a stored checkpoint, the scan starts at the
+block at which the Safe was deployed (if the node serves historical state) or at block 0. --to-block
+defaults to the latest block minus --confirmations.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if chunkSize == 0 {
+				return fmt.Errorf("--chunk-size must be at least 1")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := ethclient.Dial(rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+			safeAddress := common.HexToAddress(safe)
+
+			var store *EventStore
+			if dbPath != "" {
+				store, err = OpenEventStore(dbPath)
+				if err != nil {
+					return err
+				}
+				defer store.Close()
+			}
+
+			if !cmd.Flags().Changed("to-block") {
+				latest, err := client.HeaderByNumber(ctx, nil)
+				if err != nil {
+					return fmt.Errorf("failed to get latest block: %v", err)
+				}
+				toBlock = 0
+				if latest.Number.Uint64() > confirmations {
+					toBlock = latest.Number.Uint64() - confirmations
+				}
+			}
+
+			result := SafeEventsResult{Safe: safeAddress.Hex(), Events: []SafeEvent{}}
+			if !cmd.Flags().Changed("from-block") {
+				checkpoint, ok := uint64(0), false
+				if store != nil {
+					checkpoint, ok, err = store.Checkpoint(chainID, safeAddress)
+					if err != nil {
+						return err
+					}
+				}
+				if ok {
+					fromBlock = checkpoint + 1
+					output.Infoln("Resuming after block", checkpoint)
+					result.Events, err = store.Events(chainID, safeAddress)
+					if err != nil {
+						return err
+					}
+					result.Stored = len(result.Events)
+				} else {
+					fromBlock = safeDeploymentBlock(ctx, client, safeAddress, toBlock)
+				}
+			}
+			result.FromBlock, result.ToBlock = fromBlock, toBlock
+			if fromBlock > toBlock {
+				return output.Print(cmd, result)
+			}
+
+			err = ScanSafeEvents(ctx, client, safeAddress, fromBlock, toBlock, chunkSize, func(events []SafeEvent, lastBlock uint64) error {
+				result.Events = append(result.Events, events...)
+				if store != nil {
+					return store.Save(chainID, safeAddress, events, lastBlock)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	eventsCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe")
+	eventsCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	eventsCmd.Flags().Uint64Var(&fromBlock, "from-block", 0, "First block to scan (default: after the stored checkpoint, or the Safe's deployment)")
+	eventsCmd.Flags().Uint64Var(&toBlock, "to-block", 0, "Last block to scan (default: the latest block minus --confirmations)")
+	eventsCmd.Flags().Uint64Var(&confirmations, "confirmations", 0, "Number of blocks to leave unscanned at the head of the chain, in case of reorganizations")
+	eventsCmd.Flags().Uint64Var(&chunkSize, "chunk-size", 2000, "Number of blocks to query for logs at a time")
+	eventsCmd.Flags().StringVar(&dbPath, "db", "", "Path to a SQLite database in which to store the events and resume from")
+	eventsCmd.MarkFlagRequired("safe")
+
+	return eventsCmd
+}
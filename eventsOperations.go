@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	_ "modernc.org/sqlite"
+
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+)
+
+// EventsBackend is what scanning the events of a Safe needs from the chain.
+type EventsBackend interface {
+	ethereum.LogFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// SafeEvent is a decoded event emitted by a Safe.
+type SafeEvent struct {
+	BlockNumber     uint64                 `json:"blockNumber"`
+	Timestamp       uint64                 `json:"timestamp"`
+	TransactionHash string                 `json:"transactionHash"`
+	LogIndex        uint                   `json:"logIndex"`
+	Event           string                 `json:"event"`
+	Arguments       map[string]interface{} `json:"arguments"`
+	// Log is the log the event was decoded from. It is not stored.
+	Log types.Log `json:"-"`
+}
+
+func (e SafeEvent) Text() string {
+	names := make([]string, 0, len(e.Arguments))
+	for name := range e.Arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s %s", e.BlockNumber, time.Unix(int64(e.Timestamp), 0).UTC().Format(time.RFC3339), e.Event)
+	for _, name := range names {
+		fmt.Fprintf(&b, " %s=%v", name, e.Arguments[name])
+	}
+	return b.String()
+}
+
+// SafeEventsResult is the result of the safe events command.
+type SafeEventsResult struct {
+	Safe      string `json:"safe"`
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+	// Stored is the number of events, at the start of Events, which were read from the database rather
+	// than scanned in blocks FromBlock to ToBlock.
+	Stored int         `json:"stored"`
+	Events []SafeEvent `json:"events"`
+}
+
+func (r SafeEventsResult) Text() string {
+	var b strings.Builder
+	if r.Stored > 0 {
+		fmt.Fprintf(&b, "Stored events of %s: %d\n", r.Safe, r.Stored)
+		for _, event := range r.Events[:r.Stored] {
+			fmt.Fprintln(&b, event.Text())
+		}
+	}
+	fmt.Fprintf(&b, "Events of %s in blocks %d to %d: %d\n", r.Safe, r.FromBlock, r.ToBlock, len(r.Events)-r.Stored)
+	for _, event := range r.Events[r.Stored:] {
+		fmt.Fprintln(&b, event.Text())
+	}
+	return b.String()
+}
+
+// The events of every Safe version are those of SafeL2, which adds SafeMultiSigTransaction and
+// SafeModuleTransaction to the events of Safe.
+func safeEventsABI() (*abi.ABI, error) {
+	safeABI, err := SafeL2.SafeL2MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SafeL2 ABI: %v", err)
+	}
+	return safeABI, nil
+}
+
+// Decodes a log emitted by a Safe with safetx.DecodeEvent. Logs which are not Safe events (emitted by code
+// the Safe delegatecalls) are returned as Unknown, with their raw topics and data.
+func DecodeSafeEvent(safeABI *abi.ABI, log types.Log) (SafeEvent, error) {
+	name, arguments, err := safetx.DecodeEvent(safeABI, log)
+	if err != nil {
+		return SafeEvent{}, fmt.Errorf("%v in transaction %s", err, log.TxHash.Hex())
+	}
+	return SafeEvent{
+		BlockNumber:     log.BlockNumber,
+		TransactionHash: log.TxHash.Hex(),
+		LogIndex:        log.Index,
+		Event:           name,
+		Arguments:       arguments,
+		Log:             log,
+	}, nil
+}
+
+// Returns the first block at which there is code at the Safe, by binary search. This needs a node which
+// serves historical state, so on any error the scan starts at block 0.
+func safeDeploymentBlock(ctx context.Context, backend EventsBackend, safe common.Address, latest uint64) uint64 {
+	low, high := uint64(0), latest
+	for low < high {
+		middle := low + (high-low)/2
+		code, err := backend.CodeAt(ctx, safe, new(big.Int).SetUint64(middle))
+		if err != nil {
+			return 0
+		}
+		if len(code) > 0 {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+	return low
+}
+
+// Scans the events of the Safe from block fromBlock to toBlock (inclusive), chunkSize blocks at a time.
+// Nodes limit the number of blocks or logs which eth_getLogs may return, so the chunk size is halved
+// whenever a query fails, until it is a single block. The events of every chunk are decoded, given the
+// timestamps of their blocks and passed to handle in block and log order, which is timestamp order.
+// handle is called for every chunk, even without events, so that it may record progress.
+func ScanSafeEvents(ctx context.Context, backend EventsBackend, safe common.Address, fromBlock, toBlock, chunkSize uint64, handle func(events []SafeEvent, lastBlock uint64) error) error {
+	safeABI, err := safeEventsABI()
+	if err != nil {
+		return err
+	}
+	if chunkSize == 0 {
+		return fmt.Errorf("the chunk size must be at least 1")
+	}
+
+	timestamps := map[uint64]uint64{}
+	for start := fromBlock; start <= toBlock; {
+		end := toBlock
+		if end-start >= chunkSize {
+			end = start + chunkSize - 1
+		}
+
+		logs, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{safe},
+		})
+		if err != nil {
+			if ctx.Err() != nil || end == start {
+				return fmt.Errorf("failed to get logs of blocks %d to %d: %v", start, end, err)
+			}
+			chunkSize = (end - start + 1) / 2
+			output.Infof("Failed to get logs of blocks %d to %d, retrying %d blocks at a time: %v\n", start, end, chunkSize, err)
+			continue
+		}
+
+		sort.Slice(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+
+		events := make([]SafeEvent, 0, len(logs))
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+			event, err := DecodeSafeEvent(safeABI, log)
+			if err != nil {
+				return err
+			}
+			timestamp, ok := timestamps[log.BlockNumber]
+			if !ok {
+				header, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(log.BlockNumber))
+				if err != nil {
+					return fmt.Errorf("failed to get block %d: %v", log.BlockNumber, err)
+				}
+				timestamp = header.Time
+				timestamps[log.BlockNumber] = timestamp
+			}
+			event.Timestamp = timestamp
+			events = append(events, event)
+		}
+
+		if err := handle(events, end); err != nil {
+			return err
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// EventStore is a SQLite database of Safe events, which records up to which block the events of every
+// Safe have been scanned.
+type EventStore struct {
+	db *sql.DB
+}
+
+const eventStoreSchema = `
+CREATE TABLE IF NOT EXISTS safe_events (
+	chain_id TEXT NOT NULL,
+	safe TEXT NOT NULL,
+	block_number INTEGER NOT NULL,
+	log_index INTEGER NOT NULL,
+	block_timestamp INTEGER NOT NULL,
+	transaction_hash TEXT NOT NULL,
+	event TEXT NOT NULL,
+	arguments TEXT NOT NULL,
+	PRIMARY KEY (chain_id, safe, block_number, log_index)
+);
+CREATE TABLE IF NOT EXISTS safe_event_checkpoints (
+	chain_id TEXT NOT NULL,
+	safe TEXT NOT NULL,
+	last_block INTEGER NOT NULL,
+	PRIMARY KEY (chain_id, safe)
+);
+`
+
+// Opens the event store at path, creating it if it does not exist.
+func OpenEventStore(path string) (*EventStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event store %s: %v", path, err)
+	}
+	if _, err := db.Exec(eventStoreSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize event store %s: %v", path, err)
+	}
+	return &EventStore{db: db}, nil
+}
+
+func (s *EventStore) Close() error {
+	return s.db.Close()
+}
+
+// Returns the last block up to which the events of the Safe have been stored, and whether there is one.
+func (s *EventStore) Checkpoint(chainID *big.Int, safe common.Address) (uint64, bool, error) {
+	var lastBlock uint64
+	err := s.db.QueryRow("SELECT last_block FROM safe_event_checkpoints WHERE chain_id = ? AND safe = ?", chainID.String(), safe.Hex()).Scan(&lastBlock)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	return lastBlock, true, nil
+}
+
+// Stores events of the Safe and moves its checkpoint to lastBlock (unless it is already further), in a
+// single database transaction.
+func (s *EventStore) Save(chainID *big.Int, safe common.Address, events []SafeEvent, lastBlock uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin database transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		arguments, err := json.Marshal(event.Arguments)
+		if err != nil {
+			return fmt.Errorf("failed to encode arguments of %s: %v", event.Event, err)
+		}
+		_, err = tx.Exec(
+			"INSERT OR REPLACE INTO safe_events (chain_id, safe, block_number, log_index, block_timestamp, transaction_hash, event, arguments) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			chainID.String(), safe.Hex(), event.BlockNumber, event.LogIndex, event.Timestamp, event.TransactionHash, event.Event, string(arguments),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store event: %v", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO safe_event_checkpoints (chain_id, safe, last_block) VALUES (?, ?, ?)
+		ON CONFLICT (chain_id, safe) DO UPDATE SET last_block = MAX(last_block, excluded.last_block)`,
+		chainID.String(), safe.Hex(), lastBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store checkpoint: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit database transaction: %v", err)
+	}
+	return nil
+}
+
+// Returns the stored events of the Safe, in timestamp order.
+func (s *EventStore) Events(chainID *big.Int, safe common.Address) ([]SafeEvent, error) {
+	rows, err := s.db.Query(
+		"SELECT block_number, log_index, block_timestamp, transaction_hash, event, arguments FROM safe_events WHERE chain_id = ? AND safe = ? ORDER BY block_number, log_index",
+		chainID.String(), safe.Hex(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %v", err)
+	}
+	defer rows.Close()
+
+	events := []SafeEvent{}
+	for rows.Next() {
+		var event SafeEvent
+		var arguments string
+		if err := rows.Scan(&event.BlockNumber, &event.LogIndex, &event.Timestamp, &event.TransactionHash, &event.Event, &arguments); err != nil {
+			return nil, fmt.Errorf("failed to read event: %v", err)
+		}
+		if err := json.Unmarshal([]byte(arguments), &event.Arguments); err != nil {
+			return nil, fmt.Errorf("failed to decode arguments of %s: %v", event.Event, err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %v", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/G7DAO/safes/simtest"
+)
+
+// An events backend whose eth_getLogs refuses ranges of more than maxRange blocks, like many providers.
+type rangeLimitedBackend struct {
+	EventsBackend
+	maxRange uint64
+}
+
+func (b *rangeLimitedBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if query.ToBlock.Uint64()-query.FromBlock.Uint64()+1 > b.maxRange {
+		return nil, fmt.Errorf("block range is too large")
+	}
+	return b.EventsBackend.FilterLogs(ctx, query)
+}
+
+func eventNames(events []SafeEvent) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.Event
+	}
+	return names
+}
+
+func TestSafeEvents(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+	h.mustExecTransaction(h.calldata("addOwnerWithThreshold", chain.Address(owner2), big.NewInt(1)), owner1)
+	h.mustExecTransaction(h.calldata("changeThreshold", big.NewInt(2)), owner1)
+
+	dbPath := filepath.Join(t.TempDir(), "events.db")
+	args := []string{"safe", "events", "--safe", safeAddress.Hex(), "--rpc", chain.Endpoint, "--db", dbPath, "--chunk-size", "2"}
+	var first SafeEventsResult
+	mustRunCLI(t, &first, args...)
+	expected := []string{"SafeSetup", "AddedOwner", "ExecutionSuccess", "ChangedThreshold", "ExecutionSuccess"}
+	if names := eventNames(first.Events); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("scanned events %v, expected %v", names, expected)
+	}
+	if owner := first.Events[1].Arguments["owner"]; owner != chain.Address(owner2).Hex() {
+		t.Fatalf("AddedOwner has owner %v, expected %s", owner, chain.Address(owner2).Hex())
+	}
+	for i := 1; i < len(first.Events); i++ {
+		if first.Events[i].Timestamp < first.Events[i-1].Timestamp || first.Events[i].Timestamp == 0 {
+			t.Fatalf("events are not in timestamp order: %+v", first.Events)
+		}
+	}
+
+	// A later run resumes after the last scanned block.
+	h.mustExecTransaction(h.calldata("changeThreshold", big.NewInt(1)), owner1, owner2)
+	var second SafeEventsResult
+	mustRunCLI(t, &second, args...)
+	if second.FromBlock != first.ToBlock+1 {
+		t.Fatalf("the second scan started at block %d, expected %d", second.FromBlock, first.ToBlock+1)
+	}
+	// It prints the stored events before the new ones.
+	if second.Stored != len(first.Events) || !reflect.DeepEqual(second.Events[:second.Stored], first.Events) {
+		t.Fatalf("the second scan printed %d stored events %v, expected %v", second.Stored, eventNames(second.Events[:second.Stored]), eventNames(first.Events))
+	}
+	if names := eventNames(second.Events[second.Stored:]); !reflect.DeepEqual(names, []string{"ChangedThreshold", "ExecutionSuccess"}) {
+		t.Fatalf("the second scan found %v", names)
+	}
+
+	store, err := OpenEventStore(dbPath)
+	if err != nil {
+		t.Fatalf("could not open event store: %v", err)
+	}
+	defer store.Close()
+	stored, err := store.Events(simtest.ChainID, safeAddress)
+	if err != nil {
+		t.Fatalf("could not read stored events: %v", err)
+	}
+	if !reflect.DeepEqual(stored, second.Events) {
+		t.Fatalf("stored events %v do not match the scanned events", eventNames(stored))
+	}
+}
+
+func TestScanSafeEventsChunks(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+	for _, owner := range []int{owner2, owner3, owner4} {
+		h.mustExecTransaction(h.calldata("addOwnerWithThreshold", chain.Address(owner), big.NewInt(1)), owner1)
+	}
+	latest, err := chain.Client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatalf("could not get block number: %v", err)
+	}
+
+	scan := func(backend EventsBackend, chunkSize uint64) ([]SafeEvent, []uint64) {
+		var events []SafeEvent
+		var checkpoints []uint64
+		err := ScanSafeEvents(context.Background(), backend, safeAddress, 0, latest, chunkSize, func(chunk []SafeEvent, lastBlock uint64) error {
+			events = append(events, chunk...)
+			checkpoints = append(checkpoints, lastBlock)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not scan events: %v", err)
+		}
+		return events, checkpoints
+	}
+
+	all, _ := scan(chain.Client, 1000)
+	if len(all) != 7 {
+		t.Fatalf("expected SafeSetup and three AddedOwner and ExecutionSuccess events, got %v", eventNames(all))
+	}
+
+	// The chunk size is halved until the node accepts the range.
+	limited := &rangeLimitedBackend{EventsBackend: chain.Client, maxRange: 2}
+	events, checkpoints := scan(limited, 1000)
+	if !reflect.DeepEqual(events, all) {
+		t.Fatalf("the chunked scan found %v, expected %v", eventNames(events), eventNames(all))
+	}
+	if checkpoints[len(checkpoints)-1] != latest {
+		t.Fatalf("the scan ended at block %d, expected %d", checkpoints[len(checkpoints)-1], latest)
+	}
+	for i := 1; i < len(checkpoints); i++ {
+		if checkpoints[i]-checkpoints[i-1] > 2 {
+			t.Fatalf("chunks are larger than the node accepts: %v", checkpoints)
+		}
+	}
+
+	// A single block which the node refuses is an error.
+	limited = &rangeLimitedBackend{EventsBackend: chain.Client, maxRange: 0}
+	if err := ScanSafeEvents(context.Background(), limited, safeAddress, 0, latest, 4, func([]SafeEvent, uint64) error { return nil }); err == nil {
+		t.Fatalf("expected a scan the node refuses to fail")
+	}
+}
+
+func TestDecodeSafeEvent(t *testing.T) {
+	safeABI, err := safeEventsABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	// Safe 1.3.0 emits AddedOwner with the owner in the data rather than in a topic.
+	legacy := types.Log{Topics: []common.Hash{safeABI.Events["AddedOwner"].ID}, Data: common.LeftPadBytes(owner.Bytes(), 32)}
+	// Safe 1.4.1 indexes it.
+	current := types.Log{Topics: []common.Hash{safeABI.Events["AddedOwner"].ID, common.BytesToHash(owner.Bytes())}}
+	for _, log := range []types.Log{legacy, current} {
+		event, err := DecodeSafeEvent(safeABI, log)
+		if err != nil {
+			t.Fatalf("could not decode AddedOwner: %v", err)
+		}
+		if event.Event != "AddedOwner" || event.Arguments["owner"] != owner.Hex() {
+			t.Fatalf("decoded %+v", event)
+		}
+	}
+
+	unknown := types.Log{Topics: []common.Hash{common.HexToHash("0x01")}, Data: []byte{1}}
+	event, err := DecodeSafeEvent(safeABI, unknown)
+	if err != nil || event.Event != "Unknown" || event.Arguments["data"] != "0x01" {
+		t.Fatalf("decoded an unknown log as %+v: %v", event, err)
+	}
+}
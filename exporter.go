@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/output"
+)
+
+func CreateExporterCmd() *cobra.Command {
+	var (
+		safes         []string
+		tokens        []string
+		rpc           string
+		listen        string
+		interval      time.Duration
+		confirmations uint64
+		chunkSize     uint64
+	)
+
+	exporterCmd := &cobra.Command{
+		Use:   "exporter",
+		Short: "Serve Prometheus metrics about Safes",
+		Long: `Serve Prometheus metrics about Safes on /metrics, until interrupted.
+
+For every --safe, the exporter reports its nonce, threshold, number of owners and modules, native balance
+and balance of every --token, as read from the Safe and token contracts, along with the time of its last
+executed transaction and the number of ExecutionFailure and ExecutionFromModuleFailure events, as counted
+from its events since its deployment. All metrics are labelled with the chain ID and the Safe address, and
+are refreshed every --interval. safes_up is 0 for Safes whose last refresh failed.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(safes) == 0 {
+				return fmt.Errorf("no Safes to export metrics for (use --safe)")
+			}
+			for _, safe := range safes {
+				if !common.IsHexAddress(safe) {
+					return fmt.Errorf("invalid Safe address: %s", safe)
+				}
+			}
+			for _, token := range tokens {
+				if !common.IsHexAddress(token) {
+					return fmt.Errorf("invalid token address: %s", token)
+				}
+			}
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			if chunkSize == 0 {
+				return fmt.Errorf("--chunk-size must be at least 1")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			client, err := ethclient.DialContext(ctx, rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			defer client.Close()
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+
+			config := ExporterConfig{ChainID: chainID, Confirmations: confirmations, ChunkSize: chunkSize}
+			for _, safe := range safes {
+				config.Safes = append(config.Safes, common.HexToAddress(safe))
+			}
+			tokenAddresses := make([]common.Address, len(tokens))
+			for i, token := range tokens {
+				tokenAddresses[i] = common.HexToAddress(token)
+			}
+			config.Tokens, err = LoadExporterTokens(ctx, client, tokenAddresses)
+			if err != nil {
+				return err
+			}
+			exporter := NewSafeExporter(client, config)
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", exporter.Handler())
+			server := &http.Server{Addr: listen, Handler: mux}
+			serveErrors := make(chan error, 1)
+			go func() {
+				serveErrors <- server.ListenAndServe()
+			}()
+			output.Infoln("Serving metrics for", len(config.Safes), "Safes on", listen+"/metrics")
+
+			refresh := func() {
+				if err := exporter.Refresh(ctx); err != nil && ctx.Err() == nil {
+					output.Infoln("Refresh failed:", err)
+				}
+			}
+			refresh()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancel()
+					return server.Shutdown(shutdownCtx)
+				case err := <-serveErrors:
+					if errors.Is(err, http.ErrServerClosed) {
+						return nil
+					}
+					return fmt.Errorf("failed to serve metrics: %v", err)
+				case <-ticker.C:
+					refresh()
+				}
+			}
+		},
+	}
+
+	exporterCmd.Flags().StringArrayVar(&safes, "safe", nil, "Address of a Safe to export metrics for (repeat for several Safes)")
+	exporterCmd.Flags().StringArrayVar(&tokens, "token", nil, "Address of an ERC20 token whose balances to export (repeat for several tokens)")
+	exporterCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	exporterCmd.Flags().StringVar(&listen, "listen", ":9477", "Address to serve metrics on")
+	exporterCmd.Flags().DurationVar(&interval, "interval", time.Minute, "How often to refresh the metrics")
+	exporterCmd.Flags().Uint64Var(&confirmations, "confirmations", 0, "Number of blocks which must follow an event before it is counted")
+	exporterCmd.Flags().Uint64Var(&chunkSize, "chunk-size", 2000, "Number of blocks to query for logs at a time")
+
+	return exporterCmd
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+)
+
+// ExporterBackend is what the exporter needs from the chain: contract calls, balances and Safe events.
+type ExporterBackend interface {
+	EventsBackend
+	bind.ContractCaller
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+const exporterTokenABIJSON = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]}
+]`
+
+var exporterTokenABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(exporterTokenABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ExporterToken is an ERC20 token whose balances are exported.
+type ExporterToken struct {
+	Address  common.Address
+	Symbol   string
+	Decimals uint8
+}
+
+// Returns the tokens at the given addresses with their decimals and symbols. Tokens without a string
+// symbol are given an empty one.
+func LoadExporterTokens(ctx context.Context, backend bind.ContractCaller, addresses []common.Address) ([]ExporterToken, error) {
+	tokens := make([]ExporterToken, len(addresses))
+	for i, address := range addresses {
+		contract := bind.NewBoundContract(address, exporterTokenABI, backend, nil, nil)
+		var decimals []interface{}
+		if err := contract.Call(&bind.CallOpts{Context: ctx}, &decimals, "decimals"); err != nil {
+			return nil, fmt.Errorf("failed to get decimals of token %s: %v", address.Hex(), err)
+		}
+		tokens[i] = ExporterToken{Address: address, Decimals: decimals[0].(uint8)}
+
+		var symbol []interface{}
+		if err := contract.Call(&bind.CallOpts{Context: ctx}, &symbol, "symbol"); err == nil {
+			tokens[i].Symbol = symbol[0].(string)
+		}
+	}
+	return tokens, nil
+}
+
+// ExporterConfig describes the Safes a SafeExporter exports metrics for.
+type ExporterConfig struct {
+	ChainID *big.Int
+	Safes   []common.Address
+	Tokens  []ExporterToken
+	// Events are only counted once this many blocks follow them.
+	Confirmations uint64
+	ChunkSize     uint64
+}
+
+// SafeExporter exports the state of Safes as Prometheus metrics, which are updated by Refresh.
+type SafeExporter struct {
+	backend  ExporterBackend
+	config   ExporterConfig
+	registry *prometheus.Registry
+
+	up            *prometheus.GaugeVec
+	nonce         *prometheus.GaugeVec
+	threshold     *prometheus.GaugeVec
+	owners        *prometheus.GaugeVec
+	modules       *prometheus.GaugeVec
+	nativeBalance *prometheus.GaugeVec
+	tokenBalance  *prometheus.GaugeVec
+	lastExecution *prometheus.GaugeVec
+	failures      *prometheus.CounterVec
+
+	// The next block to scan for the events of each Safe.
+	next map[common.Address]uint64
+}
+
+// Creates a SafeExporter for the Safes in config. No metrics are exported until the first Refresh.
+func NewSafeExporter(backend ExporterBackend, config ExporterConfig) *SafeExporter {
+	constLabels := prometheus.Labels{"chain_id": config.ChainID.String()}
+	gauge := func(name, help string, labels ...string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "safes", Name: name, Help: help, ConstLabels: constLabels}, append([]string{"safe"}, labels...))
+	}
+
+	e := &SafeExporter{
+		backend:       backend,
+		config:        config,
+		registry:      prometheus.NewRegistry(),
+		up:            gauge("up", "Whether the last refresh of the Safe succeeded."),
+		nonce:         gauge("nonce", "Nonce of the Safe."),
+		threshold:     gauge("threshold", "Number of signatures required to execute a transaction."),
+		owners:        gauge("owners", "Number of owners of the Safe."),
+		modules:       gauge("modules", "Number of modules enabled on the Safe."),
+		nativeBalance: gauge("native_balance", "Balance of the Safe in the native currency, in ether."),
+		tokenBalance:  gauge("token_balance", "Balance of the Safe in an ERC20 token, in whole tokens.", "token", "symbol"),
+		lastExecution: gauge("last_execution_timestamp_seconds", "Time of the block of the last transaction the Safe executed."),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   "safes",
+			Name:        "execution_failures_total",
+			Help:        "Number of ExecutionFailure (kind multisig) and ExecutionFromModuleFailure (kind module) events.",
+			ConstLabels: constLabels,
+		}, []string{"safe", "kind"}),
+		next: map[common.Address]uint64{},
+	}
+	e.registry.MustRegister(e.up, e.nonce, e.threshold, e.owners, e.modules, e.nativeBalance, e.tokenBalance, e.lastExecution, e.failures)
+	return e
+}
+
+// Returns the handler which serves the metrics.
+func (e *SafeExporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Updates the metrics of every Safe. A Safe which cannot be refreshed keeps its previous values and has
+// safes_up set to 0; the errors of all such Safes are returned together.
+func (e *SafeExporter) Refresh(ctx context.Context) error {
+	head, err := e.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %v", err)
+	}
+	confirmed := uint64(0)
+	if head.Number.Uint64() > e.config.Confirmations {
+		confirmed = head.Number.Uint64() - e.config.Confirmations
+	}
+
+	var errs []error
+	for _, safe := range e.config.Safes {
+		if err := e.refreshSafe(ctx, safe, confirmed); err != nil {
+			e.up.WithLabelValues(safe.Hex()).Set(0)
+			errs = append(errs, fmt.Errorf("failed to refresh Safe %s: %v", safe.Hex(), err))
+			continue
+		}
+		e.up.WithLabelValues(safe.Hex()).Set(1)
+	}
+	return errors.Join(errs...)
+}
+
+func (e *SafeExporter) refreshSafe(ctx context.Context, safe common.Address, confirmed uint64) error {
+	label := safe.Hex()
+	caller, err := Safe.NewSafeCaller(safe, e.backend)
+	if err != nil {
+		return fmt.Errorf("failed to create Safe caller: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	nonce, err := caller.Nonce(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+	threshold, err := caller.GetThreshold(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get threshold: %v", err)
+	}
+	owners, err := caller.GetOwners(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get owners: %v", err)
+	}
+	modules, err := safeModules(ctx, caller)
+	if err != nil {
+		return err
+	}
+	balance, err := e.backend.BalanceAt(ctx, safe, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %v", err)
+	}
+	tokenBalances := make([]float64, len(e.config.Tokens))
+	for i, token := range e.config.Tokens {
+		var out []interface{}
+		contract := bind.NewBoundContract(token.Address, exporterTokenABI, e.backend, nil, nil)
+		if err := contract.Call(opts, &out, "balanceOf", safe); err != nil {
+			return fmt.Errorf("failed to get balance of token %s: %v", token.Address.Hex(), err)
+		}
+		tokenBalances[i] = tokenAmount(out[0].(*big.Int), token.Decimals)
+	}
+
+	e.nonce.WithLabelValues(label).Set(float64(nonce.Uint64()))
+	e.threshold.WithLabelValues(label).Set(float64(threshold.Uint64()))
+	e.owners.WithLabelValues(label).Set(float64(len(owners)))
+	e.modules.WithLabelValues(label).Set(float64(len(modules)))
+	e.nativeBalance.WithLabelValues(label).Set(tokenAmount(balance, 18))
+	for i, token := range e.config.Tokens {
+		e.tokenBalance.WithLabelValues(label, token.Address.Hex(), token.Symbol).Set(tokenBalances[i])
+	}
+
+	return e.scanEvents(ctx, safe, confirmed)
+}
+
+// Counts the failures and records the last execution of a Safe from its events since the last scan.
+func (e *SafeExporter) scanEvents(ctx context.Context, safe common.Address, confirmed uint64) error {
+	label := safe.Hex()
+	next, ok := e.next[safe]
+	if !ok {
+		next = safeDeploymentBlock(ctx, e.backend, safe, confirmed)
+		e.failures.WithLabelValues(label, "multisig").Add(0)
+		e.failures.WithLabelValues(label, "module").Add(0)
+	}
+	if next > confirmed {
+		e.next[safe] = next
+		return nil
+	}
+
+	return ScanSafeEvents(ctx, e.backend, safe, next, confirmed, e.config.ChunkSize, func(events []SafeEvent, lastBlock uint64) error {
+		for _, event := range events {
+			switch event.Event {
+			case "ExecutionFailure":
+				e.failures.WithLabelValues(label, "multisig").Inc()
+			case "ExecutionFromModuleFailure":
+				e.failures.WithLabelValues(label, "module").Inc()
+			}
+			switch event.Event {
+			case "ExecutionSuccess", "ExecutionFailure", "ExecutionFromModuleSuccess", "ExecutionFromModuleFailure":
+				e.lastExecution.WithLabelValues(label).Set(float64(event.Timestamp))
+			}
+		}
+		// Events are only counted once, even if a later chunk fails.
+		e.next[safe] = lastBlock + 1
+		return nil
+	})
+}
+
+// Returns amount in whole units of a token with the given decimals.
+func tokenAmount(amount *big.Int, decimals uint8) float64 {
+	value := new(big.Float).SetInt(amount)
+	value.Quo(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	result, _ := value.Float64()
+	return result
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// A token with 2 decimals which reports a balance of 123.45 for every account and has no symbol.
+var exporterTestToken = []byte{
+	0x60, 0x00, 0x35, 0x60, 0xe0, 0x1c, // selector := calldataload(0) >> 224
+	0x80, 0x63, 0x31, 0x3c, 0xe5, 0x67, 0x14, 0x60, 0x1d, 0x57, // decimals()
+	0x63, 0x70, 0xa0, 0x82, 0x31, 0x14, 0x60, 0x28, 0x57, // balanceOf(address)
+	0x60, 0x00, 0x80, 0xfd, // revert
+	0x5b, 0x60, 0x02, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3, // return 2
+	0x5b, 0x61, 0x30, 0x39, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3, // return 12345
+}
+
+// Returns the metrics served by the exporter.
+func scrapeMetrics(t *testing.T, exporter *SafeExporter) string {
+	recorder := httptest.NewRecorder()
+	exporter.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	if err != nil {
+		t.Fatalf("could not read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func expectMetrics(t *testing.T, metrics string, expected ...string) {
+	t.Helper()
+	for _, line := range expected {
+		if !strings.Contains(metrics, line+"\n") {
+			t.Fatalf("metrics do not contain %q:\n%s", line, metrics)
+		}
+	}
+}
+
+func TestSafeExporter(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+	token := chain.DeployCode(t, exporterTestToken)
+
+	opts := chain.TransactOpts(t, deployer)
+	opts.Value = new(big.Int).Mul(big.NewInt(3), big.NewInt(1e18))
+	tx, err := bind.NewBoundContract(safeAddress, abi.ABI{}, chain.Client, chain.Client, chain.Client).Transfer(opts)
+	if err != nil {
+		t.Fatalf("could not fund Safe: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+
+	// The account of owner4 becomes a module, whose calls to raise the threshold above the number of
+	// owners fail.
+	h.mustExecTransaction(h.calldata("addOwnerWithThreshold", chain.Address(owner2), big.NewInt(2)), owner1)
+	h.mustExecTransaction(h.calldata("enableModule", chain.Address(owner4)), owner1, owner2)
+	transactor, err := Safe.NewSafeTransactor(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not create transactor: %v", err)
+	}
+	failModuleTransaction := func() {
+		tx, err := transactor.ExecTransactionFromModule(chain.TransactOpts(t, owner4), safeAddress, big.NewInt(0), h.calldata("changeThreshold", big.NewInt(5)), uint8(safetx.Call))
+		if err != nil {
+			t.Fatalf("could not execute module transaction: %v", err)
+		}
+		chain.Receipt(t, tx.Hash())
+	}
+	failModuleTransaction()
+
+	ctx := context.Background()
+	tokens, err := LoadExporterTokens(ctx, chain.Client, []common.Address{token})
+	if err != nil {
+		t.Fatalf("could not load tokens: %v", err)
+	}
+	notSafe := common.HexToAddress("0x00000000000000000000000000000000000000dd")
+	exporter := NewSafeExporter(chain.Client, ExporterConfig{
+		ChainID:   simtest.ChainID,
+		Safes:     []common.Address{safeAddress, notSafe},
+		Tokens:    tokens,
+		ChunkSize: 3,
+	})
+	if err := exporter.Refresh(ctx); err == nil || !strings.Contains(err.Error(), notSafe.Hex()) {
+		t.Fatalf("expected the refresh of %s to fail, got %v", notSafe.Hex(), err)
+	}
+
+	labels := fmt.Sprintf(`chain_id="%d",safe="%s"`, simtest.ChainID, safeAddress.Hex())
+	metrics := scrapeMetrics(t, exporter)
+	expectMetrics(t, metrics,
+		fmt.Sprintf(`safes_up{%s} 1`, labels),
+		fmt.Sprintf(`safes_up{chain_id="%d",safe="%s"} 0`, simtest.ChainID, notSafe.Hex()),
+		fmt.Sprintf(`safes_nonce{%s} 2`, labels),
+		fmt.Sprintf(`safes_threshold{%s} 2`, labels),
+		fmt.Sprintf(`safes_owners{%s} 2`, labels),
+		fmt.Sprintf(`safes_modules{%s} 1`, labels),
+		fmt.Sprintf(`safes_native_balance{%s} 3`, labels),
+		fmt.Sprintf(`safes_token_balance{%s,symbol="",token="%s"} 123.45`, labels, token.Hex()),
+		fmt.Sprintf(`safes_execution_failures_total{chain_id="%d",kind="module",safe="%s"} 1`, simtest.ChainID, safeAddress.Hex()),
+		fmt.Sprintf(`safes_execution_failures_total{chain_id="%d",kind="multisig",safe="%s"} 0`, simtest.ChainID, safeAddress.Hex()),
+	)
+	if !strings.Contains(metrics, fmt.Sprintf("safes_last_execution_timestamp_seconds{%s}", labels)) {
+		t.Fatalf("no last execution time was exported:\n%s", metrics)
+	}
+
+	// Events are counted once.
+	failModuleTransaction()
+	exporter.Refresh(ctx) // The refresh of notSafe still fails.
+	expectMetrics(t, scrapeMetrics(t, exporter), fmt.Sprintf(`safes_execution_failures_total{chain_id="%d",kind="module",safe="%s"} 2`, simtest.ChainID, safeAddress.Hex()))
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/output"
+)
+
+func createHistoryCmd() *cobra.Command {
+	var (
+		safe          string
+		rpc           string
+		fromBlock     uint64
+		toBlock       uint64
+		confirmations uint64
+		chunkSize     uint64
+		csvPath       string
+	)
+
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Rebuild the transactions a SafeL2 executed from its events",
+		Long: `Rebuild the transactions a SafeL2 executed from its events, without the Safe Transaction Service.
+
+SafeL2 emits SafeMultiSigTransaction for every transaction executed with execTransaction and
+SafeModuleTransaction for every transaction executed by a module, with all their parameters. Each is paired
+with the execution event which follows it, which gives its status and SafeTxHash, and the signers are
+recovered from the signatures. Calls to the Safe itself, CreateCall, MultiSend, SafeMigration contracts
+and ERC20 tokens are decoded. Safes with the non-L2 singleton do not emit these events.
+
+The history is printed as text or JSON (see --output), or written as CSV with --csv (use - for stdout).
+Blocks are scanned as by safe events.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if chunkSize == 0 {
+				return fmt.Errorf("--chunk-size must be at least 1")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := ethclient.Dial(rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			safeAddress := common.HexToAddress(safe)
+
+			if !cmd.Flags().Changed("to-block") {
+				latest, err := client.HeaderByNumber(ctx, nil)
+				if err != nil {
+					return fmt.Errorf("failed to get latest block: %v", err)
+				}
+				toBlock = 0
+				if latest.Number.Uint64() > confirmations {
+					toBlock = latest.Number.Uint64() - confirmations
+				}
+			}
+			if !cmd.Flags().Changed("from-block") {
+				fromBlock = safeDeploymentBlock(ctx, client, safeAddress, toBlock)
+			}
+
+			result := HistoryResult{Safe: safeAddress.Hex(), FromBlock: fromBlock, ToBlock: toBlock, Transactions: []HistoryTransaction{}}
+			if fromBlock <= toBlock {
+				result.Transactions, err = SafeHistory(ctx, client, safeAddress, fromBlock, toBlock, chunkSize)
+				if err != nil {
+					return err
+				}
+			}
+
+			if csvPath == "" {
+				return output.Print(cmd, result)
+			}
+			var w io.Writer = cmd.OutOrStdout()
+			if csvPath != "-" {
+				file, err := os.Create(csvPath)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %v", csvPath, err)
+				}
+				defer file.Close()
+				w = file
+			}
+			if err := WriteHistoryCSV(w, result.Transactions); err != nil {
+				return err
+			}
+			if csvPath != "-" {
+				output.Infoln("Wrote", len(result.Transactions), "transactions to", csvPath)
+			}
+			return nil
+		},
+	}
+
+	historyCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe")
+	historyCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	historyCmd.Flags().Uint64Var(&fromBlock, "from-block", 0, "First block to scan (default: the Safe's deployment)")
+	historyCmd.Flags().Uint64Var(&toBlock, "to-block", 0, "Last block to scan (default: the latest block minus --confirmations)")
+	historyCmd.Flags().Uint64Var(&confirmations, "confirmations", 0, "Number of blocks to leave unscanned at the head of the chain")
+	historyCmd.Flags().Uint64Var(&chunkSize, "chunk-size", 2000, "Number of blocks to query for logs at a time")
+	historyCmd.Flags().StringVar(&csvPath, "csv", "", "Write the history as CSV to this file (- for stdout)")
+	historyCmd.MarkFlagRequired("safe")
+
+	return historyCmd
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/seer/bindings/CreateCall"
+
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+)
+
+// The interfaces of contracts which Safes commonly call, for decoding the calldata of their transactions.
+const historyCallsABI = `[
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"transactions","type":"bytes"}],"name":"multiSend","outputs":[],"stateMutability":"payable","type":"function"}
+]`
+
+// The encoding of additionalInfo in SafeMultiSigTransaction events: the nonce of the transaction, the
+// account which executed it and the threshold of the Safe.
+var safeMultiSigAdditionalInfo = abi.Arguments{
+	{Name: "nonce", Type: mustABIType("uint256")},
+	{Name: "sender", Type: mustABIType("address")},
+	{Name: "threshold", Type: mustABIType("uint256")},
+}
+
+func mustABIType(name string) abi.Type {
+	typ, err := abi.NewType(name, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// DecodedCall is calldata decoded with the ABI of a known contract.
+type DecodedCall struct {
+	Method    string                 `json:"method"`
+	Arguments map[string]interface{} `json:"arguments"`
+	// Transactions are the transactions batched by a multiSend call.
+	Transactions []MultiSendTransaction `json:"transactions,omitempty"`
+}
+
+// MultiSendTransaction is one of the transactions batched by a MultiSend multiSend call.
+type MultiSendTransaction struct {
+	Operation safetx.OperationType `json:"operation"`
+	To        string               `json:"to"`
+	Value     string               `json:"value"`
+	Data      string               `json:"data"`
+	Call      *DecodedCall         `json:"call,omitempty"`
+}
+
+// CallDecoder decodes calldata with the ABIs of the Safe and of contracts which Safes commonly call.
+type CallDecoder struct {
+	abis []*abi.ABI
+}
+
+func NewCallDecoder() (*CallDecoder, error) {
+	safeABI, err := SafeL2.SafeL2MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SafeL2 ABI: %v", err)
+	}
+	createCallABI, err := CreateCall.CreateCallMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CreateCall ABI: %v", err)
+	}
+	decoder := &CallDecoder{abis: []*abi.ABI{safeABI, createCallABI}}
+	for _, definition := range []string{historyCallsABI, safeMigrationABI} {
+		parsed, err := abi.JSON(strings.NewReader(definition))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI: %v", err)
+		}
+		decoder.abis = append(decoder.abis, &parsed)
+	}
+	return decoder, nil
+}
+
+// Decodes calldata, or returns nil if it does not call a known method. The transactions of multiSend
+// calls are unpacked and decoded as well.
+func (d *CallDecoder) Decode(data []byte) *DecodedCall {
+	if len(data) < 4 {
+		return nil
+	}
+	for _, contractABI := range d.abis {
+		method, err := contractABI.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		arguments := map[string]interface{}{}
+		if err := method.Inputs.UnpackIntoMap(arguments, data[4:]); err != nil {
+			continue
+		}
+		call := &DecodedCall{Method: method.Sig, Arguments: map[string]interface{}{}}
+		for name, value := range arguments {
+			call.Arguments[name] = output.Normalize(value)
+		}
+		if method.Name == "multiSend" {
+			call.Transactions = d.decodeMultiSend(arguments["transactions"].([]byte))
+		}
+		return call
+	}
+	return nil
+}
+
+// Unpacks the transactions of a multiSend call. Each is encoded as its operation (1 byte), to (20 bytes),
+// value (32 bytes), data length (32 bytes) and data. Malformed trailing bytes are ignored.
+func (d *CallDecoder) decodeMultiSend(packed []byte) []MultiSendTransaction {
+	var transactions []MultiSendTransaction
+	for offset := 0; offset+85 <= len(packed); {
+		length := new(big.Int).SetBytes(packed[offset+53 : offset+85])
+		if !length.IsUint64() || length.Uint64() > uint64(len(packed)-offset-85) {
+			break
+		}
+		data := packed[offset+85 : offset+85+int(length.Uint64())]
+		transactions = append(transactions, MultiSendTransaction{
+			Operation: safetx.OperationType(packed[offset]),
+			To:        common.BytesToAddress(packed[offset+1 : offset+21]).Hex(),
+			Value:     new(big.Int).SetBytes(packed[offset+21 : offset+53]).String(),
+			Data:      hexutil.Encode(data),
+			Call:      d.Decode(data),
+		})
+		offset += 85 + len(data)
+	}
+	return transactions
+}
+
+// Recovers the owners which signed a Safe transaction from the first count signatures, as the Safe checks
+// them: contract signatures (v = 0) and approved hashes (v = 1) carry the owner in r, eth_sign signatures
+// (v > 30) sign the prefixed hash with v + 4, and other signatures are ECDSA signatures of the hash.
+func RecoverSigners(safeTxHash common.Hash, signatures []byte, count int) ([]common.Address, error) {
+	signers := []common.Address{}
+	for i := 0; i < count; i++ {
+		if len(signatures) < 65*(i+1) {
+			return signers, fmt.Errorf("expected %d signatures, got %d bytes", count, len(signatures))
+		}
+		signature := signatures[65*i : 65*(i+1)]
+		v := signature[64]
+
+		switch {
+		case v == 0 || v == 1:
+			signers = append(signers, common.BytesToAddress(signature[:32]))
+		default:
+			hash := safeTxHash.Bytes()
+			if v > 30 {
+				hash = accounts.TextHash(hash)
+				v -= 4
+			}
+			ecdsaSignature := append(append([]byte{}, signature[:64]...), v-27)
+			publicKey, err := crypto.SigToPub(hash, ecdsaSignature)
+			if err != nil {
+				return signers, fmt.Errorf("failed to recover signer of signature %d: %v", i, err)
+			}
+			signers = append(signers, crypto.PubkeyToAddress(*publicKey))
+		}
+	}
+	return signers, nil
+}
+
+// HistoryTransaction is a transaction which a Safe executed, rebuilt from its events.
+type HistoryTransaction struct {
+	// Kind is "multisig" for transactions executed with execTransaction, or "module" for transactions
+	// executed by modules.
+	Kind            string `json:"kind"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	Timestamp       uint64 `json:"timestamp"`
+	TransactionHash string `json:"transactionHash"`
+	// Status is "success" or "failure", or "unknown" if no execution event follows the transaction.
+	Status string `json:"status"`
+
+	To        string               `json:"to"`
+	Value     string               `json:"value"`
+	Data      string               `json:"data"`
+	Operation safetx.OperationType `json:"operation"`
+	Call      *DecodedCall         `json:"call,omitempty"`
+
+	// Multisig transactions only.
+	Nonce          *uint64  `json:"nonce,omitempty"`
+	SafeTxHash     string   `json:"safeTxHash,omitempty"`
+	SafeTxGas      string   `json:"safeTxGas,omitempty"`
+	BaseGas        string   `json:"baseGas,omitempty"`
+	GasPrice       string   `json:"gasPrice,omitempty"`
+	GasToken       string   `json:"gasToken,omitempty"`
+	RefundReceiver string   `json:"refundReceiver,omitempty"`
+	Payment        string   `json:"payment,omitempty"`
+	Executor       string   `json:"executor,omitempty"`
+	Threshold      string   `json:"threshold,omitempty"`
+	Signers        []string `json:"signers,omitempty"`
+	// SignersError explains why the signers could not be recovered.
+	SignersError string `json:"signersError,omitempty"`
+
+	// Module transactions only.
+	Module string `json:"module,omitempty"`
+}
+
+// HistoryResult is the result of the safe history command.
+type HistoryResult struct {
+	Safe         string               `json:"safe"`
+	FromBlock    uint64               `json:"fromBlock"`
+	ToBlock      uint64               `json:"toBlock"`
+	Transactions []HistoryTransaction `json:"transactions"`
+}
+
+func (r HistoryResult) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Transactions of %s in blocks %d to %d: %d\n", r.Safe, r.FromBlock, r.ToBlock, len(r.Transactions))
+	for _, transaction := range r.Transactions {
+		timestamp := time.Unix(int64(transaction.Timestamp), 0).UTC().Format(time.RFC3339)
+		if transaction.Kind == "module" {
+			fmt.Fprintf(&b, "%s module %s: %s %s to %s (%s)\n", timestamp, transaction.Module, transaction.Operation, transaction.Value, transaction.To, transaction.Status)
+		} else {
+			fmt.Fprintf(&b, "%s nonce %d: %s %s to %s (%s)\n", timestamp, *transaction.Nonce, transaction.Operation, transaction.Value, transaction.To, transaction.Status)
+			fmt.Fprintf(&b, "\tSafeTxHash: %s\n", transaction.SafeTxHash)
+			fmt.Fprintf(&b, "\tSigners: %s\n", strings.Join(transaction.Signers, ", "))
+		}
+		if transaction.Call != nil {
+			fmt.Fprintf(&b, "\tCall: %s\n", transaction.Call.Method)
+		}
+		fmt.Fprintf(&b, "\tTransaction: %s\n", transaction.TransactionHash)
+	}
+	return b.String()
+}
+
+// Rebuilds the transactions which a SafeL2 executed from its events, in execution order. Every
+// SafeMultiSigTransaction or SafeModuleTransaction event is paired with the ExecutionSuccess or
+// ExecutionFailure (or ExecutionFromModuleSuccess or ExecutionFromModuleFailure) event which follows it in
+// the same transaction. Safe transactions can be nested (a Safe transaction may call execTransaction of
+// the same Safe), so the innermost open transaction is paired first. The SafeTxHash of the execution
+// event is the hash the owners signed, from which the signers are recovered.
+func RebuildHistory(events []SafeEvent, filterer *SafeL2.SafeL2Filterer, decoder *CallDecoder) ([]HistoryTransaction, error) {
+	transactions := []HistoryTransaction{}
+	signatures := map[int][]byte{}
+	var multisigOpen, moduleOpen []int
+	var ethereumTransaction string
+
+	for _, event := range events {
+		if event.TransactionHash != ethereumTransaction {
+			multisigOpen, moduleOpen = nil, nil
+			ethereumTransaction = event.TransactionHash
+		}
+
+		switch event.Event {
+		case "SafeMultiSigTransaction":
+			parsed, err := filterer.ParseSafeMultiSigTransaction(event.Log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse SafeMultiSigTransaction in transaction %s: %v", event.TransactionHash, err)
+			}
+			info, err := safeMultiSigAdditionalInfo.Unpack(parsed.AdditionalInfo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode additionalInfo of SafeMultiSigTransaction in transaction %s: %v", event.TransactionHash, err)
+			}
+			nonce := info[0].(*big.Int).Uint64()
+			transactions = append(transactions, HistoryTransaction{
+				Kind:            "multisig",
+				BlockNumber:     event.BlockNumber,
+				Timestamp:       event.Timestamp,
+				TransactionHash: event.TransactionHash,
+				Status:          "unknown",
+				To:              parsed.To.Hex(),
+				Value:           parsed.Value.String(),
+				Data:            hexutil.Encode(parsed.Data),
+				Operation:       safetx.OperationType(parsed.Operation),
+				Call:            decoder.Decode(parsed.Data),
+				Nonce:           &nonce,
+				SafeTxGas:       parsed.SafeTxGas.String(),
+				BaseGas:         parsed.BaseGas.String(),
+				GasPrice:        parsed.GasPrice.String(),
+				GasToken:        parsed.GasToken.Hex(),
+				RefundReceiver:  parsed.RefundReceiver.Hex(),
+				Executor:        info[1].(common.Address).Hex(),
+				Threshold:       info[2].(*big.Int).String(),
+				Signers:         []string{},
+				SignersError:    "no execution event follows the transaction",
+			})
+			multisigOpen = append(multisigOpen, len(transactions)-1)
+			signatures[len(transactions)-1] = parsed.Signatures
+
+		case "ExecutionSuccess", "ExecutionFailure":
+			if len(multisigOpen) == 0 {
+				continue
+			}
+			index := multisigOpen[len(multisigOpen)-1]
+			multisigOpen = multisigOpen[:len(multisigOpen)-1]
+			transaction := &transactions[index]
+			transaction.Status = executionStatus(event.Event)
+			transaction.SafeTxHash, _ = event.Arguments["txHash"].(string)
+			transaction.Payment, _ = event.Arguments["payment"].(string)
+			transaction.SignersError = ""
+
+			threshold, err := strconv.Atoi(transaction.Threshold)
+			if err != nil {
+				transaction.SignersError = fmt.Sprintf("invalid threshold: %s", transaction.Threshold)
+				continue
+			}
+			signers, err := RecoverSigners(common.HexToHash(transaction.SafeTxHash), signatures[index], threshold)
+			for _, signer := range signers {
+				transaction.Signers = append(transaction.Signers, signer.Hex())
+			}
+			if err != nil {
+				transaction.SignersError = err.Error()
+			}
+
+		case "SafeModuleTransaction":
+			parsed, err := filterer.ParseSafeModuleTransaction(event.Log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse SafeModuleTransaction in transaction %s: %v", event.TransactionHash, err)
+			}
+			transactions = append(transactions, HistoryTransaction{
+				Kind:            "module",
+				BlockNumber:     event.BlockNumber,
+				Timestamp:       event.Timestamp,
+				TransactionHash: event.TransactionHash,
+				Status:          "unknown",
+				To:              parsed.To.Hex(),
+				Value:           parsed.Value.String(),
+				Data:            hexutil.Encode(parsed.Data),
+				Operation:       safetx.OperationType(parsed.Operation),
+				Call:            decoder.Decode(parsed.Data),
+				Module:          parsed.Module.Hex(),
+			})
+			moduleOpen = append(moduleOpen, len(transactions)-1)
+
+		case "ExecutionFromModuleSuccess", "ExecutionFromModuleFailure":
+			if len(moduleOpen) == 0 {
+				continue
+			}
+			index := moduleOpen[len(moduleOpen)-1]
+			moduleOpen = moduleOpen[:len(moduleOpen)-1]
+			transactions[index].Status = executionStatus(event.Event)
+		}
+	}
+	return transactions, nil
+}
+
+func executionStatus(event string) string {
+	if strings.HasSuffix(event, "Success") {
+		return "success"
+	}
+	return "failure"
+}
+
+// The columns of the CSV export of a Safe's history.
+var historyCSVHeader = []string{
+	"kind", "blockNumber", "timestamp", "transactionHash", "status", "nonce", "safeTxHash", "to", "value",
+	"operation", "method", "call", "data", "safeTxGas", "baseGas", "gasPrice", "gasToken",
+	"refundReceiver", "payment", "executor", "threshold", "signers", "module",
+}
+
+// Writes transactions as CSV, one row per transaction. Signers are separated by semicolons, and the
+// decoded calls are written as JSON.
+func WriteHistoryCSV(w io.Writer, transactions []HistoryTransaction) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(historyCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	for _, transaction := range transactions {
+		var nonce, method string
+		if transaction.Nonce != nil {
+			nonce = strconv.FormatUint(*transaction.Nonce, 10)
+		}
+		call := []byte{}
+		if transaction.Call != nil {
+			method = transaction.Call.Method
+			var err error
+			if call, err = json.Marshal(transaction.Call); err != nil {
+				return fmt.Errorf("failed to encode call: %v", err)
+			}
+		}
+		row := []string{
+			transaction.Kind,
+			strconv.FormatUint(transaction.BlockNumber, 10),
+			time.Unix(int64(transaction.Timestamp), 0).UTC().Format(time.RFC3339),
+			transaction.TransactionHash,
+			transaction.Status,
+			nonce,
+			transaction.SafeTxHash,
+			transaction.To,
+			transaction.Value,
+			strconv.Itoa(int(transaction.Operation)),
+			method,
+			string(call),
+			transaction.Data,
+			transaction.SafeTxGas,
+			transaction.BaseGas,
+			transaction.GasPrice,
+			transaction.GasToken,
+			transaction.RefundReceiver,
+			transaction.Payment,
+			transaction.Executor,
+			transaction.Threshold,
+			strings.Join(transaction.Signers, ";"),
+			transaction.Module,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV: %v", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	return nil
+}
+
+// Scans the events of the Safe from fromBlock to toBlock and rebuilds the transactions it executed.
+func SafeHistory(ctx context.Context, backend EventsBackend, safe common.Address, fromBlock, toBlock, chunkSize uint64) ([]HistoryTransaction, error) {
+	filterer, err := SafeL2.NewSafeL2Filterer(safe, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SafeL2 filterer: %v", err)
+	}
+	decoder, err := NewCallDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []SafeEvent
+	err = ScanSafeEvents(ctx, backend, safe, fromBlock, toBlock, chunkSize, func(chunk []SafeEvent, lastBlock uint64) error {
+		events = append(events, chunk...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return RebuildHistory(events, filterer, decoder)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/csv"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+func TestSafeHistory(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.SafeL2, 2, owner1, owner2)
+	h := newSafeHarness(t, chain, "singleton-l2", safeAddress)
+
+	h.mustExecTransaction(h.calldata("addOwnerWithThreshold", chain.Address(owner3), big.NewInt(2)), owner1, owner2)
+	h.mustExecTransaction(h.calldata("enableModule", chain.Address(owner4)), owner2, owner3)
+
+	// The account of owner4 is now a module, which changes the threshold without signatures.
+	transactor, err := SafeL2.NewSafeL2Transactor(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not create transactor: %v", err)
+	}
+	tx, err := transactor.ExecTransactionFromModule(chain.TransactOpts(t, owner4), safeAddress, big.NewInt(0), h.calldata("changeThreshold", big.NewInt(1)), uint8(safetx.Call))
+	if err != nil {
+		t.Fatalf("could not execute module transaction: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+
+	var result HistoryResult
+	mustRunCLI(t, &result, "safe", "history", "--safe", safeAddress.Hex(), "--rpc", chain.Endpoint, "--chunk-size", "3")
+	if len(result.Transactions) != 3 {
+		t.Fatalf("expected 3 transactions, got %+v", result.Transactions)
+	}
+
+	expected := []struct {
+		kind    string
+		method  string
+		signers []common.Address
+	}{
+		{"multisig", "addOwnerWithThreshold(address,uint256)", []common.Address{chain.Address(owner1), chain.Address(owner2)}},
+		{"multisig", "enableModule(address)", []common.Address{chain.Address(owner2), chain.Address(owner3)}},
+		{"module", "changeThreshold(uint256)", nil},
+	}
+	for i, e := range expected {
+		transaction := result.Transactions[i]
+		if transaction.Kind != e.kind || transaction.Status != "success" || transaction.Call == nil || transaction.Call.Method != e.method {
+			t.Fatalf("transaction %d: %+v", i, transaction)
+		}
+		if common.HexToAddress(transaction.To) != safeAddress {
+			t.Fatalf("transaction %d is to %s", i, transaction.To)
+		}
+
+		if e.kind == "module" {
+			if common.HexToAddress(transaction.Module) != chain.Address(owner4) {
+				t.Fatalf("the module transaction was executed by %s", transaction.Module)
+			}
+			continue
+		}
+		if transaction.Nonce == nil || *transaction.Nonce != uint64(i) || transaction.SafeTxHash == "" {
+			t.Fatalf("transaction %d has nonce %v and SafeTxHash %q", i, transaction.Nonce, transaction.SafeTxHash)
+		}
+		signers := make([]common.Address, len(transaction.Signers))
+		for j, signer := range transaction.Signers {
+			signers[j] = common.HexToAddress(signer)
+		}
+		if !sameAddresses(signers, e.signers) {
+			t.Fatalf("transaction %d was signed by %v, expected %v", i, signers, e.signers)
+		}
+	}
+	if owner := result.Transactions[0].Call.Arguments["owner"]; owner != chain.Address(owner3).Hex() {
+		t.Fatalf("addOwnerWithThreshold was decoded with owner %v", owner)
+	}
+
+	csvPath := filepath.Join(t.TempDir(), "history.csv")
+	mustRunCLI(t, nil, "safe", "history", "--safe", safeAddress.Hex(), "--rpc", chain.Endpoint, "--csv", csvPath)
+	file, err := os.Open(csvPath)
+	if err != nil {
+		t.Fatalf("could not open CSV: %v", err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("could not read CSV: %v", err)
+	}
+	if len(rows) != 4 || !reflect.DeepEqual(rows[0], historyCSVHeader) {
+		t.Fatalf("unexpected CSV: %v", rows)
+	}
+	if signers := rows[1][21]; len(strings.Split(signers, ";")) != 2 {
+		t.Fatalf("the first transaction has signers %q in the CSV", signers)
+	}
+}
+
+func TestRecoverSigners(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	approver := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	safeTxHash := crypto.Keccak256Hash([]byte("SafeTx"))
+
+	ecdsaSignature, err := crypto.Sign(safeTxHash.Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSignature[64] += 27
+	ethSignSignature, err := crypto.Sign(accounts.TextHash(safeTxHash.Bytes()), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ethSignSignature[64] += 31
+	approvedHash := append(common.LeftPadBytes(approver.Bytes(), 32), append(make([]byte, 32), 1)...)
+
+	signatures := append(append(append([]byte{}, ecdsaSignature...), ethSignSignature...), approvedHash...)
+	signers, err := RecoverSigners(safeTxHash, signatures, 3)
+	if err != nil {
+		t.Fatalf("could not recover signers: %v", err)
+	}
+	if !reflect.DeepEqual(signers, []common.Address{owner, owner, approver}) {
+		t.Fatalf("recovered %v", signers)
+	}
+
+	if _, err := RecoverSigners(safeTxHash, signatures, 4); err == nil {
+		t.Fatalf("expected missing signatures to be reported")
+	}
+}
+
+func TestDecodeMultiSend(t *testing.T) {
+	decoder, err := NewCallDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	transfer, err := decoder.abis[2].Pack("transfer", recipient, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var packed []byte
+	packed = append(packed, byte(safetx.Call))
+	packed = append(packed, token.Bytes()...)
+	packed = append(packed, common.LeftPadBytes(nil, 32)...)
+	packed = append(packed, common.LeftPadBytes(big.NewInt(int64(len(transfer))).Bytes(), 32)...)
+	packed = append(packed, transfer...)
+	packed = append(packed, byte(safetx.Call))
+	packed = append(packed, recipient.Bytes()...)
+	packed = append(packed, common.LeftPadBytes(big.NewInt(7).Bytes(), 32)...)
+	packed = append(packed, common.LeftPadBytes(nil, 32)...)
+	multiSend, err := decoder.abis[2].Pack("multiSend", packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call := decoder.Decode(multiSend)
+	if call == nil || call.Method != "multiSend(bytes)" || len(call.Transactions) != 2 {
+		t.Fatalf("decoded %+v", call)
+	}
+	first, second := call.Transactions[0], call.Transactions[1]
+	if first.To != token.Hex() || first.Call == nil || first.Call.Method != "transfer(address,uint256)" || first.Call.Arguments["value"] != "5" {
+		t.Fatalf("decoded the first transaction as %+v", first)
+	}
+	if second.To != recipient.Hex() || second.Value != "7" || second.Call != nil {
+		t.Fatalf("decoded the second transaction as %+v", second)
+	}
+}
@@ -0,0 +1,672 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/seer/bindings/CreateCall"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/bindings/SafeProxyFactory"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// The zero address, which the Safe uses for "no fallback handler", "no payment token" and so on.
+var zeroAddress = common.Address{}.Hex()
+
+// Runs the CLI with the given arguments and JSON output, and decodes the result into result (unless it
+// is nil). Returns the error of the command, if any.
+func runCLI(t *testing.T, result interface{}, args ...string) error {
+	t.Helper()
+	// Keep the user's configuration file and SAFES_* variables out of the tests.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(ConfigFileEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+	for _, setting := range ProfileSettings {
+		t.Setenv(setting.EnvVar, "")
+	}
+
+	rootCmd := CreateRootCommand()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(io.Discard)
+	// Sign without confirmation, unless the test sets --yes itself.
+	flags := []string{"--output", "json"}
+	if !hasFlag(args, "--yes") {
+		flags = append(flags, "--yes")
+	}
+	rootCmd.SetArgs(append(args, flags...))
+	defer output.SetFormat("text")
+	defer signer.SetAssumeYes(false)
+
+	if err := rootCmd.Execute(); err != nil {
+		return err
+	}
+	if result != nil {
+		if err := json.Unmarshal(stdout.Bytes(), result); err != nil {
+			t.Fatalf("could not decode output of %q: %v\n%s", strings.Join(args, " "), err, stdout.String())
+		}
+	}
+	return nil
+}
+
+// Returns true if args include the given flag, with or without a value.
+func hasFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// Replaces standard input with the given input for the rest of the test.
+func setStdin(t *testing.T, input string) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("could not create pipe: %v", err)
+	}
+	if _, err := writer.WriteString(input); err != nil {
+		t.Fatalf("could not write to pipe: %v", err)
+	}
+	writer.Close()
+	stdin := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		reader.Close()
+	})
+}
+
+// Runs the CLI and fails the test if the command fails.
+func mustRunCLI(t *testing.T, result interface{}, args ...string) {
+	t.Helper()
+	if err := runCLI(t, result, args...); err != nil {
+		t.Fatalf("%q failed: %v", strings.Join(args, " "), err)
+	}
+}
+
+// safeHarness drives a Safe proxy on a simulated chain through the CLI commands of its singleton
+// (singleton or singleton-l2).
+type safeHarness struct {
+	t        *testing.T
+	chain    *simtest.Chain
+	command  string
+	safe     common.Address
+	filterer *Safe.SafeFilterer
+	safeABI  *abi.ABI
+}
+
+func newSafeHarness(t *testing.T, chain *simtest.Chain, command string, safe common.Address) *safeHarness {
+	filterer, err := Safe.NewSafeFilterer(safe, chain.Client)
+	if err != nil {
+		t.Fatalf("could not create filterer: %v", err)
+	}
+	safeABI, err := Safe.SafeMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("could not parse Safe ABI: %v", err)
+	}
+	return &safeHarness{t: t, chain: chain, command: command, safe: safe, filterer: filterer, safeABI: safeABI}
+}
+
+// Runs a view method of the Safe and returns its first output.
+func (h *safeHarness) view(method string, args ...string) interface{} {
+	h.t.Helper()
+	var result struct {
+		Outputs []interface{} `json:"outputs"`
+	}
+	mustRunCLI(h.t, &result, append([]string{h.command, method, "--rpc", h.chain.Endpoint, "--contract", h.safe.Hex()}, args...)...)
+	if len(result.Outputs) == 0 {
+		h.t.Fatalf("%s returned no outputs", method)
+	}
+	return result.Outputs[0]
+}
+
+// Sends a transaction to the Safe through the CLI, signed by the account with the given index, and
+// returns its receipt.
+func (h *safeHarness) transact(sender int, method string, args ...string) *types.Receipt {
+	h.t.Helper()
+	receipt, err := h.tryTransact(sender, method, args...)
+	if err != nil {
+		h.t.Fatalf("%s failed: %v", method, err)
+	}
+	return receipt
+}
+
+func (h *safeHarness) tryTransact(sender int, method string, args ...string) (*types.Receipt, error) {
+	h.t.Helper()
+	var result output.TransactionResult
+	cliArgs := append([]string{
+		h.command, method,
+		"--rpc", h.chain.Endpoint,
+		"--contract", h.safe.Hex(),
+		"--keyfile", h.chain.Keystore(h.t, sender),
+		"--password", simtest.KeystorePassword,
+	}, args...)
+	if err := runCLI(h.t, &result, cliArgs...); err != nil {
+		return nil, err
+	}
+	if !result.Submitted {
+		h.t.Fatalf("%s was not submitted", method)
+	}
+	return h.chain.Receipt(h.t, common.HexToHash(result.Hash)), nil
+}
+
+// Returns the calldata of a call to a method of the Safe itself.
+func (h *safeHarness) calldata(method string, args ...interface{}) []byte {
+	h.t.Helper()
+	data, err := h.safeABI.Pack(method, args...)
+	if err != nil {
+		h.t.Fatalf("could not encode %s: %v", method, err)
+	}
+	return data
+}
+
+// Has the owners with the given account indices sign a Safe transaction calling the Safe itself with
+// data, and executes it with exec-transaction, sent by the first of them.
+func (h *safeHarness) execTransaction(data []byte, owners ...int) (*types.Receipt, error) {
+	h.t.Helper()
+	return h.execTransactionTo(h.safe, data, safetx.Call, owners...)
+}
+
+// Like execTransaction, but the Safe transaction calls (or delegatecalls) the contract at to.
+func (h *safeHarness) execTransactionTo(to common.Address, data []byte, operation safetx.OperationType, owners ...int) (*types.Receipt, error) {
+	h.t.Helper()
+	nonce, ok := new(big.Int).SetString(h.view("nonce").(string), 10)
+	if !ok {
+		h.t.Fatalf("invalid nonce")
+	}
+
+	safeTxArgs := []string{
+		"--to-0", to.Hex(),
+		"--value-0", "0",
+		"--data", hex.EncodeToString(data),
+		"--operation", fmt.Sprintf("%d", operation),
+		"--safe-tx-gas", "0",
+		"--base-gas", "0",
+		"--gas-price-0", "0",
+		"--gas-token", zeroAddress,
+		"--refund-receiver", zeroAddress,
+	}
+	safeTxHash := common.HexToHash(h.view("get-transaction-hash", append(safeTxArgs, "--nonce-0", nonce.String())...).(string))
+
+	txData := safetx.TransactionData{
+		To:             to.Hex(),
+		Value:          "0",
+		Data:           hex.EncodeToString(data),
+		Operation:      operation,
+		SafeTxGas:      0,
+		BaseGas:        0,
+		GasPrice:       "0",
+		GasToken:       safetx.NativeTokenAddress,
+		RefundReceiver: safetx.NativeTokenAddress,
+		Nonce:          nonce.Uint64(),
+	}
+	expectedHash, err := safetx.CalculateSafeTxHash(h.safe, txData, simtest.ChainID)
+	if err != nil {
+		h.t.Fatalf("could not calculate SafeTxHash: %v", err)
+	}
+	if safeTxHash != expectedHash {
+		h.t.Fatalf("getTransactionHash returned %s, but safetx calculates %s", safeTxHash.Hex(), expectedHash.Hex())
+	}
+
+	// The Safe requires signatures ordered by owner address.
+	sorted := append([]int{}, owners...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(h.chain.Address(sorted[i]).Bytes(), h.chain.Address(sorted[j]).Bytes()) < 0
+	})
+	var signatures []byte
+	for _, owner := range sorted {
+		signature, err := signer.NewKeySigner(h.chain.Keys[owner]).SignDigest(safeTxHash)
+		if err != nil {
+			h.t.Fatalf("could not sign SafeTxHash: %v", err)
+		}
+		signatures = append(signatures, signature...)
+	}
+
+	return h.tryTransact(owners[0], "exec-transaction", append(safeTxArgs, "--signatures", hex.EncodeToString(signatures))...)
+}
+
+func (h *safeHarness) mustExecTransaction(data []byte, owners ...int) *types.Receipt {
+	h.t.Helper()
+	receipt, err := h.execTransaction(data, owners...)
+	if err != nil {
+		h.t.Fatalf("exec-transaction failed: %v", err)
+	}
+	if len(parseEvents(h, receipt, h.filterer.ParseExecutionSuccess)) != 1 {
+		h.t.Fatalf("exec-transaction did not emit ExecutionSuccess")
+	}
+	return receipt
+}
+
+// Returns the events of one type emitted by the Safe in a receipt.
+func parseEvents[E any](h *safeHarness, receipt *types.Receipt, parse func(types.Log) (*E, error)) []*E {
+	var events []*E
+	for _, log := range simtest.Logs(receipt, h.safe) {
+		if event, err := parse(log); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Returns the owners of the Safe, in the order of the Safe's owner list.
+func (h *safeHarness) owners() []common.Address {
+	h.t.Helper()
+	var owners []common.Address
+	for _, owner := range h.view("get-owners").([]interface{}) {
+		owners = append(owners, common.HexToAddress(owner.(string)))
+	}
+	return owners
+}
+
+// Returns the predecessor of an owner in the Safe's owner list, as removeOwner expects it.
+func (h *safeHarness) previousOwner(owner common.Address) common.Address {
+	h.t.Helper()
+	previous := common.HexToAddress("0x1") // SENTINEL_OWNERS
+	for _, current := range h.owners() {
+		if current == owner {
+			return previous
+		}
+		previous = current
+	}
+	h.t.Fatalf("%s is not an owner", owner.Hex())
+	return common.Address{}
+}
+
+func addressesJSON(addresses ...common.Address) string {
+	hexAddresses := make([]string, len(addresses))
+	for i, address := range addresses {
+		hexAddresses[i] = fmt.Sprintf("%q", address.Hex())
+	}
+	return "[" + strings.Join(hexAddresses, ",") + "]"
+}
+
+func sameAddresses(actual, expected []common.Address) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	remaining := map[common.Address]bool{}
+	for _, address := range expected {
+		remaining[address] = true
+	}
+	for _, address := range actual {
+		if !remaining[address] {
+			return false
+		}
+		delete(remaining, address)
+	}
+	return true
+}
+
+// Account indices on the simulated chain: 0 deploys the contracts, 1 to 4 are (or become) owners.
+const (
+	deployer = iota
+	owner1
+	owner2
+	owner3
+	owner4
+	accountCount
+)
+
+func TestSafeLifecycle(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+
+	for _, singleton := range []struct {
+		command string
+		address common.Address
+	}{
+		{"singleton", deployment.Safe},
+		{"singleton-l2", deployment.SafeL2},
+	} {
+		t.Run(singleton.command, func(t *testing.T) {
+			h := newSafeHarness(t, chain, singleton.command, chain.DeployProxy(t, singleton.address))
+			testSafeLifecycle(t, h)
+		})
+	}
+}
+
+func testSafeLifecycle(t *testing.T, h *safeHarness) {
+	chain := h.chain
+	initialOwners := []common.Address{chain.Address(owner1), chain.Address(owner2), chain.Address(owner3)}
+
+	// Setup: three owners, two of which must sign.
+	receipt := h.transact(deployer, "setup",
+		"--owners", addressesJSON(initialOwners...),
+		"--threshold", "2",
+		"--to-0", zeroAddress,
+		"--data", "",
+		"--fallback-handler", zeroAddress,
+		"--payment-token", zeroAddress,
+		"--payment", "0",
+		"--payment-receiver", zeroAddress,
+	)
+	setups := parseEvents(h, receipt, h.filterer.ParseSafeSetup)
+	if len(setups) != 1 || setups[0].Threshold.Cmp(big.NewInt(2)) != 0 || !sameAddresses(setups[0].Owners, initialOwners) {
+		t.Fatalf("setup did not emit the expected SafeSetup event: %+v", setups)
+	}
+	if threshold := h.view("get-threshold"); threshold != "2" {
+		t.Fatalf("threshold after setup is %v", threshold)
+	}
+
+	// A single signature does not meet the threshold (GS020: signatures data too short).
+	if _, err := h.execTransaction(h.calldata("changeThreshold", big.NewInt(1)), owner1); err == nil || !strings.Contains(err.Error(), "GS020") {
+		t.Fatalf("expected exec-transaction with fewer signatures than the threshold to revert with GS020, got %v", err)
+	}
+
+	// Add an owner.
+	newOwner := chain.Address(owner4)
+	receipt = h.mustExecTransaction(h.calldata("addOwnerWithThreshold", newOwner, big.NewInt(2)), owner1, owner2)
+	added := parseEvents(h, receipt, h.filterer.ParseAddedOwner)
+	if len(added) != 1 || added[0].Owner != newOwner {
+		t.Fatalf("addOwnerWithThreshold did not emit the expected AddedOwner event: %+v", added)
+	}
+	if isOwner := h.view("is-owner", "--owner", newOwner.Hex()); isOwner != true {
+		t.Fatalf("%s is not an owner after addOwnerWithThreshold", newOwner.Hex())
+	}
+
+	// Raise the threshold.
+	receipt = h.mustExecTransaction(h.calldata("changeThreshold", big.NewInt(3)), owner3, owner4)
+	thresholds := parseEvents(h, receipt, h.filterer.ParseChangedThreshold)
+	if len(thresholds) != 1 || thresholds[0].Threshold.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("changeThreshold did not emit the expected ChangedThreshold event: %+v", thresholds)
+	}
+
+	// Remove an owner, lowering the threshold again. This now takes three signatures.
+	removedOwner := chain.Address(owner2)
+	removeData := h.calldata("removeOwner", h.previousOwner(removedOwner), removedOwner, big.NewInt(2))
+	if _, err := h.execTransaction(removeData, owner1, owner3); err == nil || !strings.Contains(err.Error(), "GS020") {
+		t.Fatalf("expected exec-transaction with fewer signatures than the raised threshold to revert with GS020, got %v", err)
+	}
+	receipt = h.mustExecTransaction(removeData, owner1, owner3, owner4)
+	removed := parseEvents(h, receipt, h.filterer.ParseRemovedOwner)
+	if len(removed) != 1 || removed[0].Owner != removedOwner {
+		t.Fatalf("removeOwner did not emit the expected RemovedOwner event: %+v", removed)
+	}
+	expectedOwners := []common.Address{chain.Address(owner1), chain.Address(owner3), newOwner}
+	if owners := h.owners(); !sameAddresses(owners, expectedOwners) {
+		t.Fatalf("unexpected owners after removeOwner: %v", owners)
+	}
+
+	// Enable a module.
+	module := chain.Address(deployer)
+	receipt = h.mustExecTransaction(h.calldata("enableModule", module), owner4, owner1)
+	enabled := parseEvents(h, receipt, h.filterer.ParseEnabledModule)
+	if len(enabled) != 1 || enabled[0].Module != module {
+		t.Fatalf("enableModule did not emit the expected EnabledModule event: %+v", enabled)
+	}
+	if isEnabled := h.view("is-module-enabled", "--module", module.Hex()); isEnabled != true {
+		t.Fatalf("module is not enabled after enableModule")
+	}
+
+	// The enabled module can execute transactions without signatures.
+	receipt = h.transact(deployer, "exec-transaction-from-module",
+		"--to-0", h.safe.Hex(),
+		"--value-0", "0",
+		"--data", hex.EncodeToString(h.calldata("changeThreshold", big.NewInt(1))),
+		"--operation", "0",
+	)
+	if len(parseEvents(h, receipt, h.filterer.ParseExecutionFromModuleSuccess)) != 1 {
+		t.Fatalf("exec-transaction-from-module did not emit ExecutionFromModuleSuccess")
+	}
+	if threshold := h.view("get-threshold"); threshold != "1" {
+		t.Fatalf("threshold after module transaction is %v", threshold)
+	}
+
+	if h.command == "singleton-l2" {
+		// SafeL2 additionally logs every multisig transaction.
+		l2Filterer, err := SafeL2.NewSafeL2Filterer(h.safe, chain.Client)
+		if err != nil {
+			t.Fatalf("could not create SafeL2 filterer: %v", err)
+		}
+		iterator, err := l2Filterer.FilterSafeMultiSigTransaction(nil)
+		if err != nil {
+			t.Fatalf("could not filter SafeMultiSigTransaction events: %v", err)
+		}
+		count := 0
+		for iterator.Next() {
+			count++
+		}
+		if count != 4 {
+			t.Fatalf("expected 4 SafeMultiSigTransaction events, found %d", count)
+		}
+	}
+}
+
+func TestCreateProxyWithNonce(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+
+	safeABI, err := Safe.SafeMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("could not parse Safe ABI: %v", err)
+	}
+	owners := []common.Address{chain.Address(owner1), chain.Address(owner2)}
+	initializer, err := safeABI.Pack("setup", owners, big.NewInt(2), common.Address{}, []byte{}, common.Address{}, common.Address{}, big.NewInt(0), common.Address{})
+	if err != nil {
+		t.Fatalf("could not encode setup: %v", err)
+	}
+
+	var result output.TransactionResult
+	mustRunCLI(t, &result, "factory", "create-proxy-with-nonce",
+		"--rpc", chain.Endpoint,
+		"--contract", deployment.Factory.Hex(),
+		"--keyfile", chain.Keystore(t, deployer),
+		"--password", simtest.KeystorePassword,
+		"--singleton", deployment.SafeL2.Hex(),
+		"--initializer", hex.EncodeToString(initializer),
+		"--salt-nonce", "7",
+	)
+	receipt := chain.Receipt(t, common.HexToHash(result.Hash))
+
+	var proxy common.Address
+	for _, log := range receipt.Logs {
+		if log.Address == deployment.Factory && len(log.Topics) > 1 {
+			proxy = common.BytesToAddress(log.Topics[1].Bytes())
+		}
+	}
+	if proxy == (common.Address{}) {
+		t.Fatalf("create-proxy-with-nonce did not emit ProxyCreation")
+	}
+
+	h := newSafeHarness(t, chain, "singleton-l2", proxy)
+	if setups := parseEvents(h, receipt, h.filterer.ParseSafeSetup); len(setups) != 1 || !sameAddresses(setups[0].Owners, owners) {
+		t.Fatalf("the proxy was not set up by its initializer: %+v", setups)
+	}
+	if actual := h.owners(); !sameAddresses(actual, owners) {
+		t.Fatalf("unexpected owners of the new proxy: %v", actual)
+	}
+}
+
+func TestDeployThroughSafe(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	createCall, tx, _, err := CreateCall.DeployCreateCall(chain.TransactOpts(t, deployer), chain.Client)
+	if err != nil {
+		t.Fatalf("could not deploy CreateCall: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+
+	var proposed map[string]interface{}
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+			t.Errorf("could not decode proposal: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer service.Close()
+
+	args := []string{"factory", "deploy",
+		"--rpc", chain.Endpoint,
+		"--keyfile", chain.Keystore(t, owner1),
+		"--password", simtest.KeystorePassword,
+		"--safe", safeAddress.Hex(),
+		"--safe-api", service.URL,
+		"--safe-create-call", createCall.Hex(),
+		"--safe-salt", "7",
+	}
+	// This CreateCall is not in the chain registry, so its delegatecall must be forced.
+	if err := runCLI(t, nil, args...); err == nil || !strings.Contains(err.Error(), "dangerous") || proposed != nil {
+		t.Fatalf("expected the delegatecall of an unknown CreateCall to be refused, got %v", err)
+	}
+
+	// The typed data is only signed once the owner has confirmed it.
+	setStdin(t, "n\n")
+	if err := runCLI(t, nil, append(args, "--i-know-what-im-doing", "--yes=false")...); err == nil || !strings.Contains(err.Error(), "not confirmed") || proposed != nil {
+		t.Fatalf("expected the unconfirmed proposal to be refused, got %v", err)
+	}
+
+	// The salt is decoded as a number, not as the bytes of the string "7".
+	var proposal output.ProposalResult
+	mustRunCLI(t, &proposal, append(args, "--i-know-what-im-doing")...)
+	// Waiting for the execution of the proposal gives up after --safe-wait-timeout.
+	if err := runCLI(t, nil, append(args, "--i-know-what-im-doing", "--safe-wait", "--safe-wait-timeout", "1")...); err == nil || !strings.Contains(err.Error(), "was not executed") {
+		t.Fatalf("expected the wait for the unexecuted proposal to time out, got %v", err)
+	}
+	initCode := common.FromHex(SafeProxyFactory.SafeProxyFactoryBin)
+	expected := crypto.CreateAddress2(safeAddress, common.BigToHash(big.NewInt(7)), crypto.Keccak256(initCode))
+	if common.HexToAddress(proposal.ContractAddress) != expected {
+		t.Fatalf("the proposal deploys to %s, expected %s", proposal.ContractAddress, expected.Hex())
+	}
+
+	// Execute the proposed Safe transaction, which delegatecalls CreateCall.
+	if proposed["operation"] != float64(safetx.DelegateCall) || common.HexToAddress(proposed["to"].(string)) != createCall {
+		t.Fatalf("unexpected proposal: %v", proposed)
+	}
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+	receipt, err := h.execTransactionTo(createCall, common.FromHex(proposed["data"].(string)), safetx.DelegateCall, owner1)
+	if err != nil || len(parseEvents(h, receipt, h.filterer.ParseExecutionSuccess)) != 1 {
+		t.Fatalf("could not execute the deployment: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := safetx.WaitForDeployment(ctx, chain.Client, safeAddress, proposal.Nonce, expected, 10*time.Millisecond); err != nil {
+		t.Fatalf("the contract was not deployed at the predicted address: %v", err)
+	}
+	// An executed transaction which did not deploy to the address is reported.
+	if err := safetx.WaitForDeployment(ctx, chain.Client, safeAddress, proposal.Nonce, common.HexToAddress("0xdead"), 10*time.Millisecond); err == nil || !strings.Contains(err.Error(), "no code") {
+		t.Fatalf("expected a missing deployment to be reported, got %v", err)
+	}
+}
+
+func TestSimulateThroughSafe(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	createCall, tx, _, err := CreateCall.DeployCreateCall(chain.TransactOpts(t, deployer), chain.Client)
+	if err != nil {
+		t.Fatalf("could not deploy CreateCall: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+
+	// Simulations are never proposed.
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("a simulated transaction was proposed")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer service.Close()
+	safeArgs := []string{
+		"--rpc", chain.Endpoint,
+		"--keyfile", chain.Keystore(t, owner1),
+		"--password", simtest.KeystorePassword,
+		"--safe", safeAddress.Hex(),
+		"--safe-api", service.URL,
+		"--simulate",
+	}
+
+	var simulation output.SimulationResult
+	mustRunCLI(t, &simulation, append([]string{"singleton", "add-owner-with-threshold",
+		"--contract", safeAddress.Hex(),
+		"--owner", chain.Address(owner2).Hex(),
+		"--threshold", "2",
+	}, safeArgs...)...)
+	if !simulation.Success || !simulation.Effects || len(simulation.SafeChanges) != 2 {
+		t.Fatalf("unexpected simulation: %+v", simulation)
+	}
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+	if owners := h.owners(); len(owners) != 1 {
+		t.Fatalf("the simulation changed the owners: %v", owners)
+	}
+
+	// Deployments are simulated through CreateCall.
+	simulation = output.SimulationResult{}
+	mustRunCLI(t, &simulation, append([]string{"factory", "deploy", "--safe-create-call", createCall.Hex(), "--safe-salt", "7"}, safeArgs...)...)
+	expected := crypto.CreateAddress2(safeAddress, common.BigToHash(big.NewInt(7)), crypto.Keccak256(common.FromHex(SafeProxyFactory.SafeProxyFactoryBin)))
+	created := false
+	for _, log := range simulation.Logs {
+		created = created || (log.Event == "ContractCreation" && log.Arguments["newContract"] == expected.Hex())
+	}
+	if !simulation.Success || !created {
+		t.Fatalf("the simulation does not deploy to %s: %+v", expected.Hex(), simulation)
+	}
+}
+
+func TestProposeWithRefund(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+
+	var proposed map[string]interface{}
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+			t.Errorf("could not decode proposal: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer service.Close()
+	args := []string{"singleton", "change-threshold",
+		"--rpc", chain.Endpoint,
+		"--keyfile", chain.Keystore(t, owner1),
+		"--password", simtest.KeystorePassword,
+		"--contract", safeAddress.Hex(),
+		"--safe", safeAddress.Hex(),
+		"--safe-api", service.URL,
+		"--threshold", "1",
+	}
+
+	receiver := chain.Address(owner3)
+	var proposal output.ProposalResult
+	mustRunCLI(t, &proposal, append(args, "--safe-gas-price", "1000000000", "--safe-refund-receiver", receiver.Hex())...)
+	if proposal.SafeTxGas == 0 || proposal.BaseGas == 0 || proposal.GasPrice != "1000000000" || common.HexToAddress(proposal.RefundReceiver) != receiver {
+		t.Fatalf("unexpected refund parameters: %+v", proposal)
+	}
+	if proposed["safeTxGas"] != fmt.Sprint(proposal.SafeTxGas) || proposed["baseGas"] != fmt.Sprint(proposal.BaseGas) || proposed["gasPrice"] != "1000000000" {
+		t.Fatalf("the refund parameters were not proposed: %v", proposed)
+	}
+
+	// The proposal is signed with the refund parameters.
+	txData := safetx.NewTransactionData(safeAddress, common.FromHex(proposal.Data), big.NewInt(0), safetx.Call)
+	txData.SafeTxGas, txData.BaseGas, txData.GasPrice, txData.RefundReceiver = proposal.SafeTxGas, proposal.BaseGas, proposal.GasPrice, proposal.RefundReceiver
+	safeTxHash, err := safetx.ContractTransactionHash(context.Background(), chain.Client, safeAddress, txData)
+	if err != nil || safeTxHash.Hex() != proposal.SafeTxHash {
+		t.Fatalf("the SafeTxHash %s does not include the refund parameters (expected %s, %v)", proposal.SafeTxHash, safeTxHash.Hex(), err)
+	}
+
+	if err := runCLI(t, nil, append(args, "--safe-gas-token", receiver.Hex())...); err == nil {
+		t.Fatalf("a gas token without a gas price was accepted")
+	}
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+func CreateKeysCmd() *cobra.Command {
+	keysCmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Create, import and manage keystore files",
+		Long: `Create, import and manage Ethereum keystore files.
+
+Keys are stored in the keystore directory (--keystore-dir, SAFES_KEYSTORE_DIR, or by default
+$XDG_CONFIG_HOME/safes/keystore). Keys in the keystore directory can be given to --keyfile by their address
+or by an alias instead of by the path of their keystore file:
+
+	game7 keys new --alias treasury-owner
+	game7 singleton get-owners --contract 0x... --keyfile treasury-owner
+
+The passwords of new and imported keys are only taken from --password, --password-file, --password-env or
+--password-command given on the command line, and otherwise prompted for: the passwords which profiles and
+the environment provide unlock existing keys. change-password reads the new password from
+--new-password-file or prompts for it.`,
+	}
+
+	keysCmd.AddCommand(createNewKeyCmd())
+	keysCmd.AddCommand(createImportKeyCmd())
+	keysCmd.AddCommand(createListKeysCmd())
+	keysCmd.AddCommand(createInspectKeyCmd())
+	keysCmd.AddCommand(createChangePasswordCmd())
+	keysCmd.AddCommand(createAliasKeyCmd())
+
+	return keysCmd
+}
+
+// Returns the keystore directory selected by the --keystore-dir flag.
+func keystoreDir(cmd *cobra.Command) string {
+	dir, _ := cmd.Flags().GetString("keystore-dir")
+	if dir == "" {
+		dir = signer.DefaultKeystoreDir()
+	}
+	return dir
+}
+
+// Returns the password for a new keystore file. Only the --password, --password-file, --password-env and
+// --password-command flags given on the command line are used: the values which a profile or the
+// environment provide for them unlock existing keys, and are not reused for new ones. Without any of these
+// flags, the user is prompted for the new password.
+func newKeyPassword(cmd *cobra.Command) (string, error) {
+	if SetOnCommandLine(cmd, "password") {
+		password, _ := cmd.Flags().GetString("password")
+		return password, nil
+	}
+
+	var sources signer.PasswordSources
+	if SetOnCommandLine(cmd, "password-file") {
+		sources.File, _ = cmd.Flags().GetString("password-file")
+	}
+	if SetOnCommandLine(cmd, "password-env") {
+		sources.Env, _ = cmd.Flags().GetString("password-env")
+	}
+	if SetOnCommandLine(cmd, "password-command") {
+		sources.Command, _ = cmd.Flags().GetString("password-command")
+	}
+	if sources.Configured() {
+		return sources.Read()
+	}
+	return signer.PromptNewPassword("Please provide a password for the new keystore file")
+}
+
+func createNewKeyCmd() *cobra.Command {
+	var (
+		alias    string
+		lightKDF bool
+	)
+
+	newKeyCmd := &cobra.Command{
+		Use:   "new",
+		Short: "Generate a new key and store it in the keystore directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := newKeyPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			privateKey, err := crypto.GenerateKey()
+			if err != nil {
+				return fmt.Errorf("could not generate key: %v", err)
+			}
+
+			key, err := StoreKey(keystoreDir(cmd), privateKey, password, alias, lightKDF)
+			if err != nil {
+				return err
+			}
+			return output.Print(cmd, NewKeyResult(*key))
+		},
+	}
+
+	newKeyCmd.Flags().StringVar(&alias, "alias", "", "Alias to refer to the key by")
+	newKeyCmd.Flags().BoolVar(&lightKDF, "light-kdf", false, "Use less secure scrypt parameters, which make the key faster to unlock")
+
+	return newKeyCmd
+}
+
+func createImportKeyCmd() *cobra.Command {
+	var (
+		alias    string
+		fromFile string
+		mnemonic bool
+		lightKDF bool
+	)
+
+	importKeyCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a raw hex private key or a mnemonic into the keystore directory",
+		Long: `Import a raw hex private key, or a key derived from a BIP-39 mnemonic (with --mnemonic, at the path
+given by --hd-path), into the keystore directory.
+
+The private key or mnemonic is read from the file given by --from-file, or typed in at a prompt. It is
+never accepted as a command line argument.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var privateKey *ecdsa.PrivateKey
+			if mnemonic {
+				phrase, err := ReadImportSecret(fromFile, "Please provide the mnemonic to import")
+				if err != nil {
+					return err
+				}
+				hdPath, _ := cmd.Flags().GetString("hd-path")
+				privateKey, err = PrivateKeyFromMnemonic(phrase, hdPath)
+				if err != nil {
+					return err
+				}
+			} else {
+				privateKeyHex, err := ReadImportSecret(fromFile, "Please provide the hex-encoded private key to import")
+				if err != nil {
+					return err
+				}
+				privateKey, err = PrivateKeyFromHex(privateKeyHex)
+				if err != nil {
+					return err
+				}
+			}
+
+			password, err := newKeyPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			key, err := StoreKey(keystoreDir(cmd), privateKey, password, alias, lightKDF)
+			if err != nil {
+				return err
+			}
+			return output.Print(cmd, NewKeyResult(*key))
+		},
+	}
+
+	importKeyCmd.Flags().StringVar(&alias, "alias", "", "Alias to refer to the key by")
+	importKeyCmd.Flags().StringVar(&fromFile, "from-file", "", "File to read the private key or mnemonic from (default: prompt)")
+	importKeyCmd.Flags().BoolVar(&mnemonic, "mnemonic", false, "Import a key derived from a BIP-39 mnemonic instead of a raw private key")
+	importKeyCmd.Flags().BoolVar(&lightKDF, "light-kdf", false, "Use less secure scrypt parameters, which make the key faster to unlock")
+
+	return importKeyCmd
+}
+
+func createListKeysCmd() *cobra.Command {
+	listKeysCmd := &cobra.Command{
+		Use:   "list [directory]",
+		Short: "List the keys in the keystore directory (or in the given directory)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := keystoreDir(cmd)
+			if len(args) > 0 {
+				dir = expandHome(args[0])
+			}
+
+			keys, err := signer.ListKeys(dir)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 && !output.IsJSON() {
+				return fmt.Errorf("no keys found in %s", dir)
+			}
+
+			result := KeysResult{}
+			for _, key := range keys {
+				result = append(result, NewKeyResult(key))
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	return listKeysCmd
+}
+
+func createInspectKeyCmd() *cobra.Command {
+	var (
+		unlock      bool
+		showPrivate bool
+	)
+
+	inspectKeyCmd := &cobra.Command{
+		Use:   "inspect <keyfile, address or alias>",
+		Short: "Show the contents of a keystore file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := keystoreDir(cmd)
+			path, err := signer.ResolveKeyfile(dir, args[0])
+			if err != nil {
+				return err
+			}
+
+			var password *string
+			if unlock || showPrivate {
+				keyPassword, _ := cmd.Flags().GetString("password")
+				if keyPassword == "" {
+					keyPassword, err = signer.ReadPassword(fmt.Sprintf("Please provide a password for keystore (%s)", path))
+					if err != nil {
+						return err
+					}
+				}
+				password = &keyPassword
+			}
+
+			result, err := InspectKey(path, password, showPrivate)
+			if err != nil {
+				return err
+			}
+			result.Alias = aliasOf(dir, common.HexToAddress(result.Address))
+			return output.Print(cmd, result)
+		},
+	}
+
+	inspectKeyCmd.Flags().BoolVar(&unlock, "unlock", false, "Decrypt the key to check the password and show the public key")
+	inspectKeyCmd.Flags().BoolVar(&showPrivate, "private", false, "Decrypt the key and show the private key")
+
+	return inspectKeyCmd
+}
+
+func createChangePasswordCmd() *cobra.Command {
+	var (
+		newPasswordFile string
+		lightKDF        bool
+	)
+
+	changePasswordCmd := &cobra.Command{
+		Use:   "change-password <keyfile, address or alias>",
+		Short: "Re-encrypt a keystore file with a new password",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := signer.ResolveKeyfile(keystoreDir(cmd), args[0])
+			if err != nil {
+				return err
+			}
+
+			oldPassword, _ := cmd.Flags().GetString("password")
+			if oldPassword == "" {
+				oldPassword, err = signer.ReadPassword(fmt.Sprintf("Please provide the current password for keystore (%s)", path))
+				if err != nil {
+					return err
+				}
+			}
+			var newPassword string
+			if newPasswordFile != "" {
+				newPassword, err = signer.PasswordSources{File: newPasswordFile}.Read()
+			} else {
+				newPassword, err = signer.PromptNewPassword("Please provide the new password")
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := ChangeKeyPassword(path, oldPassword, newPassword, lightKDF); err != nil {
+				return err
+			}
+
+			address, err := signer.KeystoreAddress(path)
+			if err != nil {
+				return err
+			}
+			return output.Print(cmd, NewKeyResult(signer.KeyInfo{Address: address, Path: path, Alias: aliasOf(keystoreDir(cmd), address)}))
+		},
+	}
+
+	changePasswordCmd.Flags().StringVar(&newPasswordFile, "new-password-file", "", "File to read the new password for the keystore file from (default: prompt)")
+	changePasswordCmd.Flags().BoolVar(&lightKDF, "light-kdf", false, "Use less secure scrypt parameters, which make the key faster to unlock")
+
+	return changePasswordCmd
+}
+
+func createAliasKeyCmd() *cobra.Command {
+	var remove bool
+
+	aliasKeyCmd := &cobra.Command{
+		Use:   "alias <alias> [keyfile or address]",
+		Short: "Set (or with --remove, remove) the alias of a key in the keystore directory",
+		Args:  cobra.RangeArgs(1, 2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if remove && len(args) != 1 {
+				return fmt.Errorf("--remove takes only the alias to remove")
+			}
+			if !remove && len(args) != 2 {
+				return fmt.Errorf("specify the alias and the keyfile or address of the key")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := keystoreDir(cmd)
+			if remove {
+				if err := signer.SetAlias(dir, args[0], nil); err != nil {
+					return err
+				}
+				return output.Print(cmd, AliasResult{Alias: args[0], Removed: true})
+			}
+
+			path, err := signer.ResolveKeyfile(dir, args[1])
+			if err != nil {
+				return err
+			}
+			address, err := signer.KeystoreAddress(path)
+			if err != nil {
+				return err
+			}
+			if _, err := signer.ResolveKeyfile(dir, address.Hex()); err != nil {
+				return fmt.Errorf("only keys in the keystore directory can have aliases -- import the key first: %v", err)
+			}
+			if err := signer.SetAlias(dir, args[0], &address); err != nil {
+				return err
+			}
+			return output.Print(cmd, AliasResult{Alias: args[0], Address: address.Hex()})
+		},
+	}
+
+	aliasKeyCmd.Flags().BoolVar(&remove, "remove", false, "Remove the alias instead of setting it")
+
+	return aliasKeyCmd
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/G7DAO/safes/signer"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeyResult describes a key in the keystore directory.
+type KeyResult struct {
+	Address string `json:"address"`
+	Path    string `json:"path"`
+	Alias   string `json:"alias,omitempty"`
+}
+
+func NewKeyResult(info signer.KeyInfo) KeyResult {
+	return KeyResult{Address: info.Address.Hex(), Path: info.Path, Alias: info.Alias}
+}
+
+func (r KeyResult) Text() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Address: %s\n", r.Address)
+	if r.Alias != "" {
+		fmt.Fprintf(&builder, "Alias: %s\n", r.Alias)
+	}
+	fmt.Fprintf(&builder, "Path: %s\n", r.Path)
+	return builder.String()
+}
+
+// KeysResult lists the keys in a keystore directory.
+type KeysResult []KeyResult
+
+func (r KeysResult) Text() string {
+	var builder strings.Builder
+	for _, key := range r {
+		alias := key.Alias
+		if alias == "" {
+			alias = "-"
+		}
+		fmt.Fprintf(&builder, "%s  %-16s  %s\n", key.Address, alias, key.Path)
+	}
+	return builder.String()
+}
+
+// AliasResult is the result of setting or removing an alias.
+type AliasResult struct {
+	Alias   string `json:"alias"`
+	Address string `json:"address,omitempty"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+func (r AliasResult) Text() string {
+	if r.Removed {
+		return fmt.Sprintf("Removed alias %s\n", r.Alias)
+	}
+	return fmt.Sprintf("%s is now an alias of %s\n", r.Alias, r.Address)
+}
+
+// KeyInspectResult describes the contents of a keystore file.
+type KeyInspectResult struct {
+	Address    string `json:"address"`
+	Path       string `json:"path"`
+	Alias      string `json:"alias,omitempty"`
+	ID         string `json:"id"`
+	Version    int    `json:"version"`
+	Cipher     string `json:"cipher"`
+	KDF        string `json:"kdf"`
+	PublicKey  string `json:"publicKey,omitempty"`
+	PrivateKey string `json:"privateKey,omitempty"`
+}
+
+func (r KeyInspectResult) Text() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Address: %s\n", r.Address)
+	if r.Alias != "" {
+		fmt.Fprintf(&builder, "Alias: %s\n", r.Alias)
+	}
+	fmt.Fprintf(&builder, "Path: %s\n", r.Path)
+	fmt.Fprintf(&builder, "ID: %s\n", r.ID)
+	fmt.Fprintf(&builder, "Version: %d\n", r.Version)
+	fmt.Fprintf(&builder, "Cipher: %s\n", r.Cipher)
+	fmt.Fprintf(&builder, "KDF: %s\n", r.KDF)
+	if r.PublicKey != "" {
+		fmt.Fprintf(&builder, "Public key: %s\n", r.PublicKey)
+	}
+	if r.PrivateKey != "" {
+		fmt.Fprintf(&builder, "Private key: %s\n", r.PrivateKey)
+	}
+	return builder.String()
+}
+
+// Returns the scrypt parameters to encrypt keys with. The light parameters are much faster, but also
+// much cheaper to brute force.
+func scryptParameters(lightKDF bool) (int, int) {
+	if lightKDF {
+		return keystore.LightScryptN, keystore.LightScryptP
+	}
+	return keystore.StandardScryptN, keystore.StandardScryptP
+}
+
+// Encrypts a private key with password and stores it in a new keystore file in dir. If alias is not
+// empty, it is assigned to the key.
+func StoreKey(dir string, privateKey *ecdsa.PrivateKey, password, alias string, lightKDF bool) (*signer.KeyInfo, error) {
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	existing, err := signer.ListKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range existing {
+		if key.Address == address {
+			return nil, fmt.Errorf("the keystore directory already contains a key for %s (%s)", address.Hex(), key.Path)
+		}
+	}
+
+	scryptN, scryptP := scryptParameters(lightKDF)
+	ks := keystore.NewKeyStore(dir, scryptN, scryptP)
+	account, err := ks.ImportECDSA(privateKey, password)
+	if err != nil {
+		return nil, fmt.Errorf("could not store key: %v", err)
+	}
+
+	if alias != "" {
+		if err := signer.SetAlias(dir, alias, &address); err != nil {
+			return nil, err
+		}
+	}
+
+	return &signer.KeyInfo{Address: account.Address, Path: account.URL.Path, Alias: alias}, nil
+}
+
+// Reads the secret to import a key from: the contents of a file, or (if path is empty) input typed on
+// the terminal.
+func ReadImportSecret(path, prompt string) (string, error) {
+	if path == "" {
+		return signer.PromptPassword(prompt)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
+
+// Returns the private key encoded by a (possibly 0x-prefixed) hex string.
+func PrivateKeyFromHex(privateKeyHex string) (*ecdsa.PrivateKey, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	return privateKey, nil
+}
+
+// Returns the private key derived from a BIP-39 mnemonic at the given derivation path.
+func PrivateKeyFromMnemonic(mnemonic, hdPath string) (*ecdsa.PrivateKey, error) {
+	privateKeyBytes, err := signer.DeriveKeyFromMnemonic(mnemonic, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(privateKeyBytes)
+}
+
+// Describes a keystore file. If password is not nil, the key is decrypted with it, which checks the
+// password and reveals the public key and, if showPrivate is set, the private key.
+func InspectKey(path string, password *string, showPrivate bool) (*KeyInspectResult, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keystoreJSON struct {
+		Address string `json:"address"`
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+		Crypto  struct {
+			Cipher string `json:"cipher"`
+			KDF    string `json:"kdf"`
+		} `json:"crypto"`
+	}
+	if err := json.Unmarshal(contents, &keystoreJSON); err != nil {
+		return nil, fmt.Errorf("%s is not a keystore file: %v", path, err)
+	}
+
+	result := &KeyInspectResult{
+		Address: common.HexToAddress(keystoreJSON.Address).Hex(),
+		Path:    path,
+		ID:      keystoreJSON.ID,
+		Version: keystoreJSON.Version,
+		Cipher:  keystoreJSON.Crypto.Cipher,
+		KDF:     keystoreJSON.Crypto.KDF,
+	}
+
+	if password != nil {
+		key, err := signer.UnlockKeystore(contents, *password)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt %s: %v", path, err)
+		}
+		result.PublicKey = "0x" + common.Bytes2Hex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
+		if showPrivate {
+			result.PrivateKey = "0x" + common.Bytes2Hex(crypto.FromECDSA(key.PrivateKey))
+		}
+	}
+
+	return result, nil
+}
+
+// Re-encrypts a keystore file with a new password, replacing the file in place.
+func ChangeKeyPassword(path, oldPassword, newPassword string, lightKDF bool) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	key, err := signer.UnlockKeystore(contents, oldPassword)
+	if err != nil {
+		return fmt.Errorf("could not decrypt %s: %v", path, err)
+	}
+
+	scryptN, scryptP := scryptParameters(lightKDF)
+	encrypted, err := keystore.EncryptKey(key, newPassword, scryptN, scryptP)
+	if err != nil {
+		return fmt.Errorf("could not encrypt key: %v", err)
+	}
+
+	// Write to a temporary file first, so that the key is never lost if writing fails halfway.
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	temporary, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporary.Name())
+
+	if _, err := temporary.Write(encrypted); err != nil {
+		temporary.Close()
+		return err
+	}
+	if err := temporary.Chmod(info.Mode().Perm()); err != nil {
+		temporary.Close()
+		return err
+	}
+	if err := temporary.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temporary.Name(), path); err != nil {
+		return fmt.Errorf("could not replace keystore file: %v", err)
+	}
+	return nil
+}
+
+// Returns the alias of the given address in the keystore directory, if it has one.
+func aliasOf(dir string, address common.Address) string {
+	aliases, err := signer.LoadAliases(dir)
+	if err != nil {
+		return ""
+	}
+	for alias, aliasAddress := range aliases {
+		if aliasAddress == address {
+			return alias
+		}
+	}
+	return ""
+}
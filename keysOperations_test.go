@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+func TestKeyRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	key, err := StoreKey(dir, privateKey, "first", "owner", true)
+	if err != nil {
+		t.Fatalf("could not store key: %v", err)
+	}
+	if key.Address != address || key.Alias != "owner" || filepath.Dir(key.Path) != dir {
+		t.Fatalf("unexpected stored key: %+v", key)
+	}
+	if path, err := signer.ResolveKeyfile(dir, "owner"); err != nil || path != key.Path {
+		t.Fatalf("alias resolves to %s (%v), expected %s", path, err, key.Path)
+	}
+	if _, err := StoreKey(dir, privateKey, "other", "", true); err == nil || !strings.Contains(err.Error(), "already contains a key") {
+		t.Fatalf("expected the key to be refused a second time, got %v", err)
+	}
+
+	// Without a password, only the metadata of the key is shown.
+	inspected, err := InspectKey(key.Path, nil, false)
+	if err != nil {
+		t.Fatalf("could not inspect key: %v", err)
+	}
+	if inspected.Address != address.Hex() || inspected.KDF != "scrypt" || inspected.PublicKey != "" || inspected.PrivateKey != "" {
+		t.Fatalf("unexpected inspection without password: %+v", inspected)
+	}
+	wrong := "wrong"
+	if _, err := InspectKey(key.Path, &wrong, false); err == nil {
+		t.Fatalf("expected the wrong password to be refused")
+	}
+
+	// The key is re-encrypted with the new password only if the old one is right.
+	if err := ChangeKeyPassword(key.Path, "wrong", "second", true); err == nil {
+		t.Fatalf("expected the wrong old password to be refused")
+	}
+	if err := ChangeKeyPassword(key.Path, "first", "second", true); err != nil {
+		t.Fatalf("could not change password: %v", err)
+	}
+	first := "first"
+	if _, err := InspectKey(key.Path, &first, false); err == nil {
+		t.Fatalf("expected the old password to be refused after the change")
+	}
+	second := "second"
+	inspected, err = InspectKey(key.Path, &second, true)
+	if err != nil {
+		t.Fatalf("could not unlock key with the new password: %v", err)
+	}
+	if inspected.Address != address.Hex() || inspected.PrivateKey != "0x"+common.Bytes2Hex(crypto.FromECDSA(privateKey)) || inspected.PublicKey != "0x"+common.Bytes2Hex(crypto.FromECDSAPub(&privateKey.PublicKey)) {
+		t.Fatalf("unexpected inspection with the new password: %+v", inspected)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, ".*.tmp*")); len(files) != 0 {
+		t.Fatalf("temporary files left in the keystore directory: %v", files)
+	}
+}
+
+func TestNewKeyPasswordIgnoresProfile(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("default_profile: test\nprofiles:\n  test:\n    password: profile\n"), 0600); err != nil {
+		t.Fatalf("could not write config file: %v", err)
+	}
+	keyArgs := []string{"--config", configFile, "--keystore-dir", dir, "--light-kdf"}
+
+	// The password of the profile unlocks existing keys and is not used for new ones.
+	if err := runCLI(t, nil, append([]string{"keys", "new"}, keyArgs...)...); err == nil || !strings.Contains(err.Error(), "not a terminal") {
+		t.Fatalf("expected a prompt for the new password, got %v", err)
+	}
+
+	var key KeyResult
+	mustRunCLI(t, &key, append([]string{"keys", "new", "--password", "explicit"}, keyArgs...)...)
+	var inspected KeyInspectResult
+	mustRunCLI(t, &inspected, "--config", configFile, "--keystore-dir", dir, "keys", "inspect", key.Address, "--unlock", "--password", "explicit")
+	if inspected.Address != key.Address || inspected.PublicKey == "" {
+		t.Fatalf("unexpected inspection: %+v", inspected)
+	}
+
+	// The new password is read from a file.
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatalf("could not write password file: %v", err)
+	}
+	mustRunCLI(t, nil, "--config", configFile, "--keystore-dir", dir, "keys", "change-password", key.Address, "--password", "explicit", "--new-password-file", passwordFile, "--light-kdf")
+	mustRunCLI(t, &inspected, "--config", configFile, "--keystore-dir", dir, "keys", "inspect", key.Address, "--unlock", "--password", "from-file")
+}
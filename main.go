@@ -1,15 +1,16 @@
 package main
 
 import (
-	"fmt"
 	"os"
+
+	"github.com/G7DAO/safes/output"
 )
 
 func main() {
 	command := CreateRootCommand()
 	err := command.Execute()
 	if err != nil {
-		fmt.Println(err.Error())
+		output.PrintError(err)
 		os.Exit(1)
 	}
 }
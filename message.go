@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/signer"
+)
+
+func createMessageCmd() *cobra.Command {
+	messageCmd := &cobra.Command{
+		Use:   "message",
+		Short: "Sign and verify messages of a Safe (EIP-1271)",
+		Long:  `Sign messages with a Safe, so that contracts accept them through the Safe's isValidSignature (EIP-1271), and verify signatures which claim to come from a Safe.`,
+	}
+
+	messageCmd.AddCommand(createMessageSignCmd())
+	messageCmd.AddCommand(createMessageVerifyCmd())
+
+	return messageCmd
+}
+
+func createMessageSignCmd() *cobra.Command {
+	var (
+		safe              string
+		rpcURL            string
+		text              string
+		typedDataFile     string
+		onchain           bool
+		signaturesRaw     []string
+		signMessageLibRaw string
+		safeAPI           string
+		keyfile           string
+		password          string
+		message           *SafeMessage
+		collected         []byte
+	)
+
+	signCmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign an EIP-191 message or EIP-712 typed data with a Safe",
+		Long: `Sign an EIP-191 message (--message) or EIP-712 typed data (--typed-data) with a Safe.
+
+The Safe signs the hash of the message. Contracts check the signature by calling isValidSignature on
+the Safe with that hash, which its fallback handler checks against the SafeMessage hash: the EIP-712
+hash of the message hash in the Safe's domain.
+
+Off chain (the default), owners sign the SafeMessage hash. Each owner runs this command with the
+signatures collected so far (--signatures), and adds their own. Once the threshold of the Safe is met,
+the collected signatures, ordered by owner, are the signature which isValidSignature accepts.
+
+On chain (--onchain), a Safe transaction which delegatecalls signMessage on SignMessageLib is proposed.
+Once it has been executed, the Safe's signedMessages marks the SafeMessage hash as signed, and
+isValidSignature accepts an empty signature. Without --keyfile or --signer, the command only checks
+signedMessages.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if rpcURL == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if signMessageLibRaw != "" && !common.IsHexAddress(signMessageLibRaw) {
+				return fmt.Errorf("invalid SignMessageLib address: %s", signMessageLibRaw)
+			}
+			if onchain && len(signaturesRaw) > 0 {
+				return fmt.Errorf("--signatures are only collected off chain")
+			}
+			if !onchain && len(signaturesRaw) == 0 && keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
+			}
+			for _, signature := range signaturesRaw {
+				collected = append(collected, common.FromHex(signature)...)
+			}
+
+			var err error
+			message, err = LoadSafeMessage(text, typedDataFile)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			client, err := ethclient.Dial(rpcURL)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+			safeAddress := common.HexToAddress(safe)
+
+			var owner signer.Signer
+			if keyfile != "" || signer.Configured() {
+				owner, err = signer.Load(keyfile, password)
+				if err != nil {
+					return err
+				}
+			}
+
+			var result *MessageSignatureResult
+			if onchain {
+				if owner != nil && safeAPI == "" {
+					safeAPI = chains.ProposeURL(chainID, safeAddress)
+					output.Infoln("--safe-api not specified, using default (", safeAPI, ")")
+				}
+				result, err = SignSafeMessageOnchain(ctx, client, safeAddress, chainID, message, common.HexToAddress(signMessageLibRaw), owner, safeAPI)
+			} else {
+				result, err = SignSafeMessageOffchain(ctx, client, safeAddress, chainID, message, collected, owner)
+			}
+			if err != nil {
+				return err
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	signCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe which signs the message")
+	signCmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	signCmd.Flags().StringVar(&text, "message", "", "Text of an EIP-191 message to sign")
+	signCmd.Flags().StringVar(&typedDataFile, "typed-data", "", "Path to a JSON file with EIP-712 typed data to sign")
+	signCmd.Flags().BoolVar(&onchain, "onchain", false, "Propose a Safe transaction which signs the message on chain with SignMessageLib instead of collecting owner signatures")
+	signCmd.Flags().StringSliceVar(&signaturesRaw, "signatures", nil, "Owner signatures of the message collected so far (comma-separated or repeated)")
+	signCmd.Flags().StringVar(&signMessageLibRaw, "sign-message-lib", "", "Address of SignMessageLib (default: from the chain registry, for the Safe's version)")
+	signCmd.Flags().StringVar(&safeAPI, "safe-api", "", "URL to which the Safe transaction is proposed (default: derived from --transaction-service or the chain registry)")
+	signCmd.Flags().StringVarP(&keyfile, "keyfile", "k", "", "Path to the keystore file")
+	signCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the keystore file")
+	signCmd.MarkFlagRequired("safe")
+
+	return signCmd
+}
+
+func createMessageVerifyCmd() *cobra.Command {
+	var (
+		safe          string
+		rpcURL        string
+		text          string
+		typedDataFile string
+		hashRaw       string
+		signatureRaw  string
+		message       *SafeMessage
+	)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify a signature of a message by a Safe (EIP-1271)",
+		Long: `Verify a signature of a message by a Safe (EIP-1271).
+
+The message is given as an EIP-191 message (--message), as EIP-712 typed data (--typed-data), or by its
+hash (--hash). The signature is checked by calling both variants of isValidSignature on the Safe, which
+forwards them to its fallback handler: isValidSignature(bytes32,bytes) with the hash of the message, and
+isValidSignature(bytes,bytes) with the hash as data. The signature is valid if the bytes32 variant
+accepts it, and the command fails otherwise.
+
+The owner signatures packed into the signature are decoded and checked as the Safe checks them, to
+explain why it is rejected: signers which are not owners, owners out of order or signing twice, and too
+few signatures for the threshold. An empty signature (0x) is valid if the Safe has signed the message on
+chain with SignMessageLib.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if rpcURL == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if _, err := hexutil.Decode(signatureRaw); err != nil {
+				return fmt.Errorf("invalid signature: %s (expected 0x-prefixed hex, or 0x for a message signed on chain)", signatureRaw)
+			}
+
+			if hashRaw == "" {
+				var err error
+				message, err = LoadSafeMessage(text, typedDataFile)
+				return err
+			}
+			if text != "" || typedDataFile != "" {
+				return fmt.Errorf("only one of --message, --typed-data and --hash may be specified")
+			}
+			hash, err := hexutil.Decode(hashRaw)
+			if err != nil || len(hash) != 32 {
+				return fmt.Errorf("invalid hash: %s (expected 32 bytes of 0x-prefixed hex)", hashRaw)
+			}
+			message = &SafeMessage{Kind: HashMessage, Hash: common.BytesToHash(hash)}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := ethclient.Dial(rpcURL)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+
+			result, err := VerifySafeMessage(context.Background(), client, common.HexToAddress(safe), message, common.FromHex(signatureRaw))
+			if err != nil {
+				return err
+			}
+			if err := output.Print(cmd, result); err != nil {
+				return err
+			}
+			if !result.Valid {
+				cmd.SilenceUsage = true
+				return output.ReportedError{Err: fmt.Errorf("the signature is not a valid signature of Safe %s", result.Safe)}
+			}
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe which claims to have signed the message")
+	verifyCmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	verifyCmd.Flags().StringVar(&text, "message", "", "Text of the EIP-191 message")
+	verifyCmd.Flags().StringVar(&typedDataFile, "typed-data", "", "Path to a JSON file with the EIP-712 typed data")
+	verifyCmd.Flags().StringVar(&hashRaw, "hash", "", "Hash of the message, as passed to isValidSignature(bytes32,bytes)")
+	verifyCmd.Flags().StringVar(&signatureRaw, "signature", "", "Signature to verify (0x for a message signed on chain)")
+	verifyCmd.MarkFlagRequired("safe")
+	verifyCmd.MarkFlagRequired("signature")
+
+	return verifyCmd
+}
@@ -0,0 +1,538 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+)
+
+// The method of SignMessageLib which a Safe delegatecalls to mark a message as signed in its
+// signedMessages.
+const signMessageLibABI = `[
+	{"inputs":[{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"signMessage","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// Kinds of messages which Safes sign.
+const (
+	EIP191Message = "eip191"
+	EIP712Message = "eip712"
+)
+
+// Routes by which Safes sign messages.
+const (
+	OffchainRoute = "offchain"
+	OnchainRoute  = "onchain"
+)
+
+// SafeMessage is a message which a Safe signs through EIP-1271: an EIP-191 text message or EIP-712
+// typed data. Contracts check the signature of the Safe by passing the hash of the message to the
+// bytes32 variant of isValidSignature.
+type SafeMessage struct {
+	Kind      string
+	Hash      common.Hash
+	Text      string
+	TypedData *apitypes.TypedData
+}
+
+// Loads a message to sign: either text, which is hashed as an EIP-191 message, or the EIP-712 typed
+// data in the JSON file at typedDataFile.
+func LoadSafeMessage(text, typedDataFile string) (*SafeMessage, error) {
+	if (text == "") == (typedDataFile == "") {
+		return nil, errors.New("exactly one of --message and --typed-data must be specified")
+	}
+	if text != "" {
+		return &SafeMessage{Kind: EIP191Message, Hash: common.BytesToHash(accounts.TextHash([]byte(text))), Text: text}, nil
+	}
+
+	contents, err := os.ReadFile(typedDataFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read typed data file: %v", err)
+	}
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(contents, &typedData); err != nil {
+		return nil, fmt.Errorf("could not parse typed data file %s: %v", typedDataFile, err)
+	}
+	hash, err := signer.TypedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return &SafeMessage{Kind: EIP712Message, Hash: hash, TypedData: &typedData}, nil
+}
+
+// Returns the details shown with the SafeMessage typed data when it is reviewed before signing: the
+// message which the Safe signs and its hash.
+func (m *SafeMessage) ReviewDetails() ([]string, error) {
+	details := []string{}
+	switch m.Kind {
+	case EIP191Message:
+		details = append(details, fmt.Sprintf("Signed message (EIP-191): %q", m.Text))
+	case EIP712Message:
+		review, err := signer.ReviewTypedData(*m.TypedData)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, "Signed typed data (EIP-712):\n  "+strings.ReplaceAll(strings.TrimSuffix(review, "\n"), "\n", "\n  "))
+	}
+	return append(details, fmt.Sprintf("Signed message hash: %s", m.Hash.Hex())), nil
+}
+
+// MessageSignatureResult is the result of the safe message sign command.
+type MessageSignatureResult struct {
+	Safe            string `json:"safe"`
+	Route           string `json:"route"`
+	MessageType     string `json:"messageType"`
+	MessageHash     string `json:"messageHash"`
+	SafeMessageHash string `json:"safeMessageHash"`
+	Threshold       uint64 `json:"threshold"`
+	// Signers are the owners whose signatures have been collected (off-chain route only).
+	Signers []string `json:"signers,omitempty"`
+	// Complete is true if isValidSignature accepts Signature for MessageHash.
+	Complete bool `json:"complete"`
+	// Signature is the signature which isValidSignature accepts once Complete. On the off-chain route,
+	// it holds the collected owner signatures until then. On the on-chain route, it is empty.
+	Signature string                 `json:"signature"`
+	Proposal  *output.ProposalResult `json:"proposal,omitempty"`
+}
+
+func (r MessageSignatureResult) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Safe: %s\n", r.Safe)
+	fmt.Fprintf(&b, "Message hash (%s): %s\n", r.MessageType, r.MessageHash)
+	fmt.Fprintf(&b, "SafeMessage hash: %s\n", r.SafeMessageHash)
+	switch r.Route {
+	case OffchainRoute:
+		fmt.Fprintf(&b, "Signers: %d of %d required\n", len(r.Signers), r.Threshold)
+		for _, owner := range r.Signers {
+			fmt.Fprintf(&b, "  %s\n", owner)
+		}
+		if r.Complete {
+			fmt.Fprintf(&b, "Signature: %s\n", r.Signature)
+		} else {
+			fmt.Fprintf(&b, "Collected signatures: %s\n", r.Signature)
+			b.WriteString("Pass the collected signatures to the next owner with --signatures.\n")
+		}
+	case OnchainRoute:
+		if r.Complete {
+			b.WriteString("Signed on chain: yes (the signature is empty: 0x)\n")
+		} else {
+			b.WriteString("Signed on chain: no\n")
+		}
+		if r.Proposal != nil {
+			b.WriteString(r.Proposal.Text())
+			b.WriteString("Once the Safe transaction has been executed, run this command again without --keyfile to check the signature.\n")
+		}
+	}
+	return b.String()
+}
+
+// Signs a message of a Safe off chain: the SafeMessage hash is signed by owner (unless owner is nil or
+// has already signed), and the signature is added to the collected signatures of other owners. Once
+// the threshold of the Safe is met, the packed signatures are the signature which isValidSignature
+// accepts.
+func SignSafeMessageOffchain(ctx context.Context, client bind.ContractCaller, safeAddress common.Address, chainID *big.Int, message *SafeMessage, collected []byte, owner signer.Signer) (*MessageSignatureResult, error) {
+	prepared, err := safetx.PrepareMessage(ctx, client, safeAddress, message.Hash.Bytes(), chainID)
+	if err != nil {
+		return nil, err
+	}
+	owners, threshold, err := safeOwnersAndThreshold(ctx, client, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := func(address common.Address) bool {
+		for _, candidate := range owners {
+			if candidate == address {
+				return true
+			}
+		}
+		return false
+	}
+
+	split, err := safetx.SplitSignatures(collected)
+	if err != nil {
+		return nil, err
+	}
+	var signatures []safetx.OwnerSignature
+	signed := map[common.Address]bool{}
+	for _, signature := range split {
+		signerAddress, err := safetx.RecoverSigner(prepared.SafeMessageHash, signature)
+		if err != nil {
+			return nil, err
+		}
+		if !isOwner(signerAddress) {
+			return nil, fmt.Errorf("signature %s was made by %s, which is not an owner of Safe %s", hexutil.Encode(signature), signerAddress.Hex(), safeAddress.Hex())
+		}
+		if signed[signerAddress] {
+			continue
+		}
+		signed[signerAddress] = true
+		signatures = append(signatures, safetx.OwnerSignature{Owner: signerAddress, Signature: signature})
+	}
+
+	if owner != nil && !signed[owner.Address()] {
+		if !isOwner(owner.Address()) {
+			return nil, fmt.Errorf("%s is not an owner of Safe %s", owner.Address().Hex(), safeAddress.Hex())
+		}
+		details, err := message.ReviewDetails()
+		if err != nil {
+			return nil, err
+		}
+		if err := signer.ConfirmTypedData(prepared.TypedData, details...); err != nil {
+			return nil, err
+		}
+		signature, err := owner.SignTypedData(prepared.TypedData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign SafeMessage hash: %v", err)
+		}
+		signatures = append(signatures, safetx.OwnerSignature{Owner: owner.Address(), Signature: signature})
+	}
+
+	safetx.SortSignatures(signatures)
+	result := &MessageSignatureResult{
+		Safe:            safeAddress.Hex(),
+		Route:           OffchainRoute,
+		MessageType:     message.Kind,
+		MessageHash:     message.Hash.Hex(),
+		SafeMessageHash: prepared.SafeMessageHash.Hex(),
+		Threshold:       threshold,
+		Complete:        uint64(len(signatures)) >= threshold,
+		Signature:       hexutil.Encode(safetx.PackSignatures(signatures)),
+	}
+	for _, signature := range signatures {
+		result.Signers = append(result.Signers, signature.Owner.Hex())
+	}
+	return result, nil
+}
+
+// Signs a message of a Safe on chain. If the Safe's signedMessages already marks the SafeMessage hash as
+// signed, the signature is complete (and empty). Otherwise, if txSigner is not nil, a Safe transaction
+// which delegatecalls signMessage on the SignMessageLib at signMessageLib (or, if it is the zero
+// address, the one of the Safe's version in the chain registry) is proposed to safeAPI.
+func SignSafeMessageOnchain(ctx context.Context, client *ethclient.Client, safeAddress common.Address, chainID *big.Int, message *SafeMessage, signMessageLib common.Address, txSigner signer.Signer, safeAPI string) (*MessageSignatureResult, error) {
+	prepared, err := safetx.PrepareMessage(ctx, client, safeAddress, message.Hash.Bytes(), chainID)
+	if err != nil {
+		return nil, err
+	}
+	_, threshold, err := safeOwnersAndThreshold(ctx, client, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	safe, err := Safe.NewSafeCaller(safeAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Safe instance: %v", err)
+	}
+	signedMessage, err := safe.SignedMessages(&bind.CallOpts{Context: ctx}, prepared.SafeMessageHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signedMessages: %v", err)
+	}
+
+	result := &MessageSignatureResult{
+		Safe:            safeAddress.Hex(),
+		Route:           OnchainRoute,
+		MessageType:     message.Kind,
+		MessageHash:     message.Hash.Hex(),
+		SafeMessageHash: prepared.SafeMessageHash.Hex(),
+		Threshold:       threshold,
+		Complete:        signedMessage.Sign() != 0,
+		Signature:       "0x",
+	}
+	if result.Complete || txSigner == nil {
+		return result, nil
+	}
+
+	if signMessageLib == (common.Address{}) {
+		contracts, err := chains.Default().Contracts(chainID, prepared.Version.String())
+		if err != nil {
+			return nil, fmt.Errorf("%v (pass --sign-message-lib)", err)
+		}
+		if !common.IsHexAddress(contracts.SignMessageLib) {
+			return nil, fmt.Errorf("no SignMessageLib address for Safe version %s on chain %s in the chain registry (pass --sign-message-lib)", prepared.Version, chainID.String())
+		}
+		signMessageLib = common.HexToAddress(contracts.SignMessageLib)
+	}
+	parsed, err := abi.JSON(strings.NewReader(signMessageLibABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SignMessageLib ABI: %v", err)
+	}
+	data, err := parsed.Pack("signMessage", message.Hash.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack signMessage transaction: %v", err)
+	}
+
+	details, err := message.ReviewDetails()
+	if err != nil {
+		return nil, err
+	}
+	output.Infoln("The Safe transaction signs this message on chain:")
+	output.Infof("%s\n", strings.Join(details, "\n"))
+	result.Proposal, err = safetx.Propose(client, txSigner, safeAddress, signMessageLib, data, big.NewInt(0), safeAPI, safetx.DelegateCall, safetx.GasOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error proposing signMessage transaction: %v", err)
+	}
+	return result, nil
+}
+
+func safeOwnersAndThreshold(ctx context.Context, client bind.ContractCaller, safeAddress common.Address) ([]common.Address, uint64, error) {
+	safe, err := Safe.NewSafeCaller(safeAddress, client)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create Safe instance: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	owners, err := safe.GetOwners(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get owners: %v", err)
+	}
+	threshold, err := safe.GetThreshold(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get threshold: %v", err)
+	}
+	return owners, threshold.Uint64(), nil
+}
+
+// The isValidSignature methods of EIP-1271 (and of its draft), which the fallback handlers of Safes
+// implement, and the magic values which they return for valid signatures.
+const eip1271ABI = `[
+	{"inputs":[{"internalType":"bytes32","name":"_dataHash","type":"bytes32"},{"internalType":"bytes","name":"_signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"internalType":"bytes4","name":"","type":"bytes4"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"bytes","name":"_data","type":"bytes"},{"internalType":"bytes","name":"_signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"internalType":"bytes4","name":"","type":"bytes4"}],"stateMutability":"view","type":"function"}
+]`
+
+var (
+	eip1271MagicValue       = [4]byte{0x16, 0x26, 0xba, 0x7e}
+	eip1271LegacyMagicValue = [4]byte{0x20, 0xc1, 0x3b, 0x0b}
+)
+
+// HashMessage is the kind of messages which are given by their hash.
+const HashMessage = "hash"
+
+// SignatureCheck reports a single owner signature decoded from a Safe signature.
+type SignatureCheck struct {
+	Index   int    `json:"index"`
+	Type    string `json:"type"`
+	Signer  string `json:"signer"`
+	Valid   bool   `json:"valid"`
+	Problem string `json:"problem,omitempty"`
+}
+
+// InterfaceCheck reports the result of a call of isValidSignature on a Safe.
+type InterfaceCheck struct {
+	Method string `json:"method"`
+	Valid  bool   `json:"valid"`
+	// Result is the value which the call returned, or why it failed.
+	Result string `json:"result"`
+}
+
+// MessageVerificationResult is the result of the safe message verify command. A signature is valid if
+// the Safe accepts it through the bytes32 variant of isValidSignature.
+type MessageVerificationResult struct {
+	Safe            string           `json:"safe"`
+	MessageType     string           `json:"messageType"`
+	MessageHash     string           `json:"messageHash"`
+	SafeMessageHash string           `json:"safeMessageHash"`
+	Threshold       uint64           `json:"threshold"`
+	Signatures      []SignatureCheck `json:"signatures"`
+	// SignedOnchain reports the Safe's signedMessages, which is checked for empty signatures.
+	SignedOnchain    *bool            `json:"signedOnchain,omitempty"`
+	FallbackHandler  string           `json:"fallbackHandler"`
+	IsValidSignature []InterfaceCheck `json:"isValidSignature"`
+	Valid            bool             `json:"valid"`
+	Problems         []string         `json:"problems,omitempty"`
+}
+
+func (r MessageVerificationResult) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Safe: %s\n", r.Safe)
+	fmt.Fprintf(&b, "Message hash (%s): %s\n", r.MessageType, r.MessageHash)
+	fmt.Fprintf(&b, "SafeMessage hash: %s\n", r.SafeMessageHash)
+	fmt.Fprintf(&b, "Threshold: %d\n", r.Threshold)
+	if r.SignedOnchain != nil {
+		fmt.Fprintf(&b, "Signed on chain: %t\n", *r.SignedOnchain)
+	}
+	if len(r.Signatures) > 0 {
+		b.WriteString("Signatures:\n")
+	}
+	for _, signature := range r.Signatures {
+		if signature.Valid {
+			fmt.Fprintf(&b, "  %d. %s signature by owner %s\n", signature.Index, signature.Type, signature.Signer)
+		} else {
+			fmt.Fprintf(&b, "  %d. %s signature by %s (INVALID: %s)\n", signature.Index, signature.Type, signature.Signer, signature.Problem)
+		}
+	}
+	fmt.Fprintf(&b, "Fallback handler: %s\n", r.FallbackHandler)
+	for _, check := range r.IsValidSignature {
+		verdict := "rejected"
+		if check.Valid {
+			verdict = "accepted"
+		}
+		fmt.Fprintf(&b, "%s: %s (%s)\n", check.Method, verdict, check.Result)
+	}
+	if r.Valid {
+		b.WriteString("Valid: yes\n")
+	} else {
+		b.WriteString("Valid: no\n")
+	}
+	for _, problem := range r.Problems {
+		fmt.Fprintf(&b, "  - %s\n", problem)
+	}
+	return b.String()
+}
+
+// Verifies a signature of a message by a Safe. The signature is checked with both variants of
+// isValidSignature, which the Safe forwards to its fallback handler. Its owner signatures are decoded
+// and checked as the Safe checks them, to explain why the Safe rejects it.
+func VerifySafeMessage(ctx context.Context, backend VerificationBackend, safeAddress common.Address, message *SafeMessage, signature []byte) (*MessageVerificationResult, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	prepared, err := safetx.PrepareMessage(ctx, backend, safeAddress, message.Hash.Bytes(), chainID)
+	if err != nil {
+		return nil, err
+	}
+	owners, threshold, err := safeOwnersAndThreshold(ctx, backend, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	safe, err := Safe.NewSafeCaller(safeAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Safe instance: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	result := &MessageVerificationResult{
+		Safe:            safeAddress.Hex(),
+		MessageType:     message.Kind,
+		MessageHash:     message.Hash.Hex(),
+		SafeMessageHash: prepared.SafeMessageHash.Hex(),
+		Threshold:       threshold,
+		Signatures:      []SignatureCheck{},
+	}
+
+	if len(signature) == 0 {
+		signedMessage, err := safe.SignedMessages(opts, prepared.SafeMessageHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get signedMessages: %v", err)
+		}
+		signed := signedMessage.Sign() != 0
+		result.SignedOnchain = &signed
+		if !signed {
+			result.Problems = append(result.Problems, "the signature is empty, but the Safe has not signed the message on chain (signedMessages is 0)")
+		}
+	} else if problems, err := checkOwnerSignatures(ctx, safe, prepared.SafeMessageHash, signature, owners, threshold, result); err != nil {
+		return nil, err
+	} else {
+		result.Problems = append(result.Problems, problems...)
+	}
+
+	handler, err := storageAddress(ctx, backend, safeAddress, safetx.FallbackHandlerStorageSlot)
+	if err != nil {
+		return nil, err
+	}
+	result.FallbackHandler = handler.Hex()
+	if handler == (common.Address{}) {
+		result.Problems = append(result.Problems, "the Safe has no fallback handler, so it does not implement isValidSignature")
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(eip1271ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse EIP-1271 ABI: %v", err)
+	}
+	contract := bind.NewBoundContract(safeAddress, parsed, backend, nil, nil)
+	for _, variant := range []struct {
+		method string
+		name   string
+		data   interface{}
+		magic  [4]byte
+	}{
+		{"isValidSignature", "isValidSignature(bytes32,bytes)", [32]byte(message.Hash), eip1271MagicValue},
+		{"isValidSignature0", "isValidSignature(bytes,bytes)", message.Hash.Bytes(), eip1271LegacyMagicValue},
+	} {
+		check := InterfaceCheck{Method: variant.name}
+		var results []interface{}
+		if err := contract.Call(opts, &results, variant.method, variant.data, signature); err != nil {
+			check.Result = fmt.Sprintf("call failed: %v", err)
+		} else if value, ok := results[0].([4]byte); !ok {
+			check.Result = "unexpected result"
+		} else {
+			check.Valid = value == variant.magic
+			check.Result = hexutil.Encode(value[:])
+			if !check.Valid {
+				check.Result += fmt.Sprintf(", expected %s", hexutil.Encode(variant.magic[:]))
+			}
+		}
+		if handler != (common.Address{}) && !check.Valid {
+			result.Problems = append(result.Problems, fmt.Sprintf("%s rejects the signature: %s", check.Method, check.Result))
+		}
+		result.IsValidSignature = append(result.IsValidSignature, check)
+	}
+	result.Valid = result.IsValidSignature[0].Valid
+	return result, nil
+}
+
+// Decodes the owner signatures of the SafeMessage hash and checks them as the Safe's checkNSignatures
+// does: the first threshold signatures must be valid signatures of distinct owners, ordered by owner
+// address. Adds the decoded signatures to result and returns the problems which make the Safe reject
+// them.
+func checkOwnerSignatures(ctx context.Context, safe *Safe.SafeCaller, safeMessageHash common.Hash, signature []byte, owners []common.Address, threshold uint64, result *MessageVerificationResult) ([]string, error) {
+	decoded, err := safetx.DecodeSignatures(safeMessageHash, signature)
+	if err != nil {
+		return []string{fmt.Sprintf("the signature cannot be decoded: %v", err)}, nil
+	}
+	isOwner := map[common.Address]bool{}
+	for _, owner := range owners {
+		isOwner[owner] = true
+	}
+
+	var problems []string
+	seen := map[common.Address]bool{}
+	lastOwner := common.Address{}
+	for i, signature := range decoded {
+		check := SignatureCheck{Index: i + 1, Type: signature.Type, Signer: signature.Owner.Hex()}
+		switch {
+		case !isOwner[signature.Owner]:
+			check.Problem = fmt.Sprintf("%s is not an owner of the Safe", signature.Owner.Hex())
+		case seen[signature.Owner]:
+			check.Problem = fmt.Sprintf("%s signed more than once", signature.Owner.Hex())
+		case bytes.Compare(signature.Owner.Bytes(), lastOwner.Bytes()) <= 0:
+			check.Problem = fmt.Sprintf("signatures must be ordered by owner address, but %s comes after %s", signature.Owner.Hex(), lastOwner.Hex())
+		case signature.Type == safetx.ApprovedHashSignature:
+			approved, err := safe.ApprovedHashes(&bind.CallOpts{Context: ctx}, signature.Owner, safeMessageHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get approvedHashes: %v", err)
+			}
+			if approved.Sign() == 0 {
+				check.Problem = fmt.Sprintf("%s has not approved the SafeMessage hash with approveHash", signature.Owner.Hex())
+			}
+		}
+		// Contract signatures are checked by the owner itself, when the Safe calls isValidSignature.
+		check.Valid = check.Problem == ""
+		// The Safe only checks the first threshold signatures.
+		if !check.Valid && uint64(i) < threshold {
+			problems = append(problems, fmt.Sprintf("signature %d: %s", check.Index, check.Problem))
+		}
+		seen[signature.Owner] = true
+		lastOwner = signature.Owner
+		result.Signatures = append(result.Signatures, check)
+	}
+	if uint64(len(decoded)) < threshold {
+		problems = append(problems, fmt.Sprintf("the threshold of the Safe is not met: %d signatures, %d required", len(decoded), threshold))
+	}
+	return problems, nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// An EIP-712 order, as a marketplace would ask the Safe to sign it.
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [{"name": "name", "type": "string"}, {"name": "chainId", "type": "uint256"}],
+		"Order": [{"name": "token", "type": "address"}, {"name": "price", "type": "uint256"}]
+	},
+	"primaryType": "Order",
+	"domain": {"name": "Marketplace", "chainId": "1337"},
+	"message": {"token": "0x00000000000000000000000000000000000000aa", "price": "1000"}
+}`
+
+// Returns the SafeMessage hash of a message hash as the Safe's fallback handler calculates it.
+func expectedSafeMessageHash(t *testing.T, chain *simtest.Chain, safeAddress common.Address, messageHash common.Hash) common.Hash {
+	t.Helper()
+	safe, err := Safe.NewSafeCaller(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not bind Safe: %v", err)
+	}
+	domainSeparator, err := safe.DomainSeparator(&bind.CallOpts{})
+	if err != nil {
+		t.Fatalf("could not get domain separator: %v", err)
+	}
+	typeHash := crypto.Keccak256([]byte("SafeMessage(bytes message)"))
+	structHash := crypto.Keccak256(typeHash, crypto.Keccak256(messageHash.Bytes()))
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], structHash)
+}
+
+func TestSignSafeMessageOffchain(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 2, owner1, owner2, owner3)
+	safe, err := Safe.NewSafeCaller(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not bind Safe: %v", err)
+	}
+
+	signArgs := func(owner int, args ...string) []string {
+		return append([]string{"safe", "message", "sign",
+			"--rpc", chain.Endpoint,
+			"--safe", safeAddress.Hex(),
+			"--keyfile", chain.Keystore(t, owner),
+			"--password", simtest.KeystorePassword,
+		}, args...)
+	}
+
+	text := "Sign in to the marketplace"
+	var first MessageSignatureResult
+	mustRunCLI(t, &first, signArgs(owner1, "--message", text)...)
+	messageHash := common.BytesToHash(accounts.TextHash([]byte(text)))
+	safeMessageHash := expectedSafeMessageHash(t, chain, safeAddress, messageHash)
+	if first.MessageHash != messageHash.Hex() || first.SafeMessageHash != safeMessageHash.Hex() {
+		t.Fatalf("unexpected hashes: %+v", first)
+	}
+	if first.Complete || len(first.Signers) != 1 || first.Signers[0] != chain.Address(owner1).Hex() || len(common.FromHex(first.Signature)) != 65 {
+		t.Fatalf("unexpected first signature: %+v", first)
+	}
+
+	var second MessageSignatureResult
+	mustRunCLI(t, &second, signArgs(owner2, "--message", text, "--signatures", first.Signature)...)
+	if !second.Complete || len(second.Signers) != 2 || bytes.Compare(common.HexToAddress(second.Signers[0]).Bytes(), common.HexToAddress(second.Signers[1]).Bytes()) > 0 {
+		t.Fatalf("unexpected second signature: %+v", second)
+	}
+	if err := safe.CheckSignatures(&bind.CallOpts{}, safeMessageHash, messageHash.Bytes(), common.FromHex(second.Signature)); err != nil {
+		t.Fatalf("the Safe does not accept the collected signatures: %v", err)
+	}
+
+	// Owners who have signed are not asked to sign again.
+	var again MessageSignatureResult
+	mustRunCLI(t, &again, signArgs(owner1, "--message", text, "--signatures", second.Signature)...)
+	if again.Signature != second.Signature {
+		t.Fatalf("signing again changed the signature from %s to %s", second.Signature, again.Signature)
+	}
+
+	// Signatures of accounts which are not owners are refused.
+	if err := runCLI(t, nil, signArgs(owner4, "--message", text)...); err == nil || !strings.Contains(err.Error(), "not an owner") {
+		t.Fatalf("expected the signature of a non-owner to be refused, got %v", err)
+	}
+	stranger, err := signer.NewKeySigner(chain.Keys[owner4]).SignDigest(safeMessageHash)
+	if err != nil {
+		t.Fatalf("could not sign: %v", err)
+	}
+	if err := runCLI(t, nil, signArgs(owner1, "--message", text, "--signatures", hexutil.Encode(stranger))...); err == nil || !strings.Contains(err.Error(), "not an owner") {
+		t.Fatalf("expected the collected signature of a non-owner to be refused, got %v", err)
+	}
+
+	typedDataFile := filepath.Join(t.TempDir(), "order.json")
+	if err := os.WriteFile(typedDataFile, []byte(testTypedData), 0600); err != nil {
+		t.Fatalf("could not write typed data: %v", err)
+	}
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal([]byte(testTypedData), &typedData); err != nil {
+		t.Fatalf("could not parse typed data: %v", err)
+	}
+	typedDataHash, err := signer.TypedDataHash(typedData)
+	if err != nil {
+		t.Fatalf("could not hash typed data: %v", err)
+	}
+	var order MessageSignatureResult
+	mustRunCLI(t, &order, signArgs(owner3, "--typed-data", typedDataFile)...)
+	if order.MessageType != EIP712Message || order.MessageHash != typedDataHash.Hex() || order.SafeMessageHash != expectedSafeMessageHash(t, chain, safeAddress, typedDataHash).Hex() {
+		t.Fatalf("unexpected typed data signature: %+v", order)
+	}
+}
+
+func TestSignSafeMessageOnchain(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+
+	var proposed map[string]interface{}
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+			t.Errorf("could not decode proposal: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer service.Close()
+
+	text := "Sign in to the marketplace"
+	checkArgs := []string{"safe", "message", "sign", "--onchain", "--rpc", chain.Endpoint, "--safe", safeAddress.Hex(), "--message", text}
+	var proposal MessageSignatureResult
+	mustRunCLI(t, &proposal, append(checkArgs, "--keyfile", chain.Keystore(t, owner1), "--password", simtest.KeystorePassword, "--safe-api", service.URL)...)
+	if proposal.Complete || proposal.Proposal == nil {
+		t.Fatalf("unexpected result before the message is signed: %+v", proposal)
+	}
+
+	// The proposal delegatecalls signMessage on the SignMessageLib of Safe 1.4.1 in the chain registry.
+	messageHash := common.BytesToHash(accounts.TextHash([]byte(text)))
+	expectedData := append(crypto.Keccak256([]byte("signMessage(bytes)"))[:4], common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	expectedData = append(expectedData, common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	expectedData = append(expectedData, messageHash.Bytes()...)
+	if common.HexToAddress(proposed["to"].(string)) != common.HexToAddress("0xd53cd0aB83D845Ac265BE939c57F53AD838012c9") || proposed["operation"] != float64(safetx.DelegateCall) || proposed["data"] != hexutil.Encode(expectedData) {
+		t.Fatalf("unexpected proposal: %v", proposed)
+	}
+
+	// Mark the SafeMessage hash as signed in signedMessages (slot 7), as SignMessageLib does, with a
+	// contract which stores 1 at the slot in its calldata: PUSH1 1 PUSH1 4 CALLDATALOAD SSTORE STOP.
+	store := chain.DeployCode(t, []byte{0x60, 0x01, 0x60, 0x04, 0x35, 0x55, 0x00})
+	slot := crypto.Keccak256(common.HexToHash(proposal.SafeMessageHash).Bytes(), common.BigToHash(big.NewInt(7)).Bytes())
+	if _, err := h.execTransactionTo(store, append(make([]byte, 4), slot...), safetx.DelegateCall, owner1); err != nil {
+		t.Fatalf("could not mark the message as signed: %v", err)
+	}
+
+	var signed MessageSignatureResult
+	mustRunCLI(t, &signed, checkArgs...)
+	if !signed.Complete || signed.Signature != "0x" || signed.Proposal != nil {
+		t.Fatalf("unexpected result after the message was signed: %+v", signed)
+	}
+}
+
+func TestVerifySafeMessage(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 2, owner1, owner2, owner3)
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+
+	message, err := LoadSafeMessage("Sign in to the marketplace", "")
+	if err != nil {
+		t.Fatalf("could not load message: %v", err)
+	}
+	safeMessageHash := expectedSafeMessageHash(t, chain, safeAddress, message.Hash)
+	sign := func(account int) safetx.OwnerSignature {
+		t.Helper()
+		signature, err := signer.NewKeySigner(chain.Keys[account]).SignDigest(safeMessageHash)
+		if err != nil {
+			t.Fatalf("could not sign: %v", err)
+		}
+		return safetx.OwnerSignature{Owner: chain.Address(account), Signature: signature}
+	}
+	valid := safetx.PackSignatures([]safetx.OwnerSignature{sign(owner1), sign(owner2)})
+	reversed := append(common.CopyBytes(valid[65:]), valid[:65]...)
+	// An eth_sign signature signs the SafeMessage hash as an EIP-191 message, and adds 4 to v.
+	ethSign, err := signer.NewKeySigner(chain.Keys[owner3]).SignDigest(common.BytesToHash(accounts.TextHash(safeMessageHash.Bytes())))
+	if err != nil {
+		t.Fatalf("could not sign: %v", err)
+	}
+	ethSign[64] += 4
+	// An approved hash signature holds the owner in r and has v = 1.
+	approved := append(common.LeftPadBytes(chain.Address(owner3).Bytes(), 32), make([]byte, 32)...)
+	approved = append(approved, 1)
+
+	verify := func(signature []byte) *MessageVerificationResult {
+		t.Helper()
+		result, err := VerifySafeMessage(context.Background(), chain.Client, safeAddress, message, signature)
+		if err != nil {
+			t.Fatalf("could not verify signature: %v", err)
+		}
+		return result
+	}
+
+	cases := []struct {
+		name      string
+		signature []byte
+		problem   string
+	}{
+		{"valid", valid, ""},
+		{"eth_sign", safetx.PackSignatures([]safetx.OwnerSignature{sign(owner1), {Owner: chain.Address(owner3), Signature: ethSign}}), ""},
+		{"threshold not met", sign(owner1).Signature, "threshold of the Safe is not met"},
+		{"non-owner", safetx.PackSignatures([]safetx.OwnerSignature{sign(owner1), sign(owner4)}), "is not an owner"},
+		{"bad ordering", reversed, "ordered by owner address"},
+		{"signed twice", append(sign(owner1).Signature, sign(owner1).Signature...), "signed more than once"},
+		{"hash not approved", safetx.PackSignatures([]safetx.OwnerSignature{sign(owner1), {Owner: chain.Address(owner3), Signature: approved}}), "has not approved"},
+		{"not signed on chain", []byte{}, "has not signed the message on chain"},
+		{"truncated", valid[:64], "cannot be decoded"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := verify(c.signature)
+			if result.Valid || result.SafeMessageHash != safeMessageHash.Hex() {
+				t.Fatalf("unexpected verification without a fallback handler: %+v", result)
+			}
+			// Without a fallback handler, the Safe does not implement isValidSignature.
+			problems := strings.Join(result.Problems, "\n")
+			if !strings.Contains(problems, "no fallback handler") {
+				t.Fatalf("the missing fallback handler was not reported: %v", result.Problems)
+			}
+			if c.problem == "" && len(result.Problems) != 1 || c.problem != "" && !strings.Contains(problems, c.problem) {
+				t.Fatalf("expected the problem %q, got %v", c.problem, result.Problems)
+			}
+		})
+	}
+	if result := verify(valid); len(result.Signatures) != 2 || !result.Signatures[0].Valid || result.Signatures[0].Type != safetx.ECDSASignature {
+		t.Fatalf("unexpected decoded signatures: %+v", result.Signatures)
+	}
+
+	// A fallback handler which returns the selector of every call, which is the magic value of both
+	// variants of isValidSignature: PUSH1 0 CALLDATALOAD PUSH1 224 SHR PUSH1 224 SHL PUSH1 0 MSTORE
+	// PUSH1 32 PUSH1 0 RETURN.
+	handler := chain.DeployCode(t, []byte{0x60, 0x00, 0x35, 0x60, 0xe0, 0x1c, 0x60, 0xe0, 0x1b, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3})
+	if _, err := h.execTransaction(h.calldata("setFallbackHandler", handler), owner1, owner2); err != nil {
+		t.Fatalf("could not set fallback handler: %v", err)
+	}
+
+	var result MessageVerificationResult
+	mustRunCLI(t, &result, "safe", "message", "verify", "--rpc", chain.Endpoint, "--safe", safeAddress.Hex(), "--hash", message.Hash.Hex(), "--signature", hexutil.Encode(valid))
+	if !result.Valid || result.FallbackHandler != handler.Hex() || len(result.IsValidSignature) != 2 || !result.IsValidSignature[1].Valid || len(result.Problems) != 0 {
+		t.Fatalf("unexpected verification with a fallback handler: %+v", result)
+	}
+}
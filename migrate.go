@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+)
+
+func createMigrateCmd() *cobra.Command {
+	var (
+		safe                string
+		migration           string
+		rpcURL              string
+		safeAPI             string
+		keyfile             string
+		password            string
+		l2                  bool
+		keepFallbackHandler bool
+		dryRun              bool
+	)
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Propose moving a Safe to a new singleton",
+		Long: `Propose moving a Safe to a new singleton (and fallback handler) with a SafeMigration contract.
+
+The Safe's current singleton and VERSION are read from the chain, and the target singleton and fallback
+handler are read from the migration contract. The migration is refused if either version predates the
+storage layout of Safe 1.0.0, if it would downgrade the Safe, or if the Safe's storage does not hold its
+owner count, threshold and nonce where the target singleton expects them.
+
+The Safe transaction delegatecalls the migration contract. Before it is proposed, its execution is
+simulated with eth_call, as if the Safe had a threshold of one. With --dry-run, the migration is planned
+and simulated but not proposed.
+
+A migration contract can claim any singleton, so only the SafeMigration contracts of the chain registry
+are trusted. The delegatecall of any other migration contract is refused unless --i-know-what-im-doing
+is passed.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if !common.IsHexAddress(migration) {
+				return fmt.Errorf("invalid migration contract address: %s", migration)
+			}
+			if rpcURL == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if !dryRun && keyfile == "" && !signer.Configured() {
+				return fmt.Errorf("--keyfile not specified (this should be a path to an Ethereum account keystore file, or use --signer)")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			rpcClient, err := rpc.DialContext(ctx, rpcURL)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			client := ethclient.NewClient(rpcClient)
+
+			safeAddress := common.HexToAddress(safe)
+			plan, err := PlanMigration(ctx, client, safeAddress, common.HexToAddress(migration), l2, !keepFallbackHandler)
+			if err != nil {
+				return err
+			}
+
+			safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
+			if err != nil {
+				return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+			}
+			owners, err := safeInstance.GetOwners(nil)
+			if err != nil {
+				return fmt.Errorf("failed to get owners: %v", err)
+			}
+			if len(owners) == 0 {
+				return fmt.Errorf("Safe %s has no owners", safeAddress.Hex())
+			}
+			if err := SimulateMigration(ctx, gethclient.New(rpcClient), plan, owners[0]); err != nil {
+				return err
+			}
+
+			result := MigrationResult{MigrationPlan: *plan, Simulated: true}
+			if dryRun {
+				return output.Print(cmd, result)
+			}
+
+			txSigner, err := signer.Load(keyfile, password)
+			if err != nil {
+				return err
+			}
+
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+			if safeAPI == "" {
+				safeAPI = chains.ProposeURL(chainID, safeAddress)
+				output.Infoln("--safe-api not specified, using default (", safeAPI, ")")
+			}
+
+			// The SafeMigration contracts of the chain registry are known Safe libraries. The delegatecall
+			// of any other contract is refused by the policy like that of any unknown contract.
+			for _, known := range chains.MigrationContracts(chainID) {
+				if known == common.HexToAddress(plan.Migration) {
+					safetx.AllowDelegateCall(known)
+				}
+			}
+			result.Proposal, err = safetx.Propose(client, txSigner, safeAddress, common.HexToAddress(plan.Migration), common.FromHex(plan.Data), big.NewInt(0), safeAPI, safetx.DelegateCall, safetx.GasOptions{})
+			if err != nil {
+				return fmt.Errorf("error proposing migration: %v", err)
+			}
+			return output.Print(cmd, result)
+		},
+	}
+
+	migrateCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe to migrate")
+	migrateCmd.Flags().StringVar(&migration, "migration", "", "Address of the SafeMigration contract to delegatecall")
+	migrateCmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use")
+	migrateCmd.Flags().StringVar(&safeAPI, "safe-api", "", "URL to which the Safe transaction is proposed (default: derived from --transaction-service or the chain registry)")
+	migrateCmd.Flags().StringVarP(&keyfile, "keyfile", "k", "", "Path to the keystore file")
+	migrateCmd.Flags().StringVarP(&password, "password", "p", "", "Password for the keystore file")
+	migrateCmd.Flags().BoolVar(&l2, "l2", false, "Migrate to the L2 singleton of the migration contract, which emits events for every Safe transaction")
+	migrateCmd.Flags().BoolVar(&keepFallbackHandler, "keep-fallback-handler", false, "Keep the Safe's fallback handler instead of setting the migration contract's")
+	migrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Plan and simulate the migration without proposing it")
+	migrateCmd.MarkFlagRequired("safe")
+	migrateCmd.MarkFlagRequired("migration")
+
+	return migrateCmd
+}
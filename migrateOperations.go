@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+)
+
+// The interface of SafeMigration-style contracts (see SafeMigration.sol in the Safe contracts), which a
+// Safe delegatecalls to replace its singleton and, optionally, its fallback handler. The targets are
+// immutables of the migration contract.
+const safeMigrationABI = `[
+	{"inputs":[],"name":"SAFE_SINGLETON","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"SAFE_L2_SINGLETON","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"SAFE_FALLBACK_HANDLER","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"migrateSingleton","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[],"name":"migrateWithFallbackHandler","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[],"name":"migrateL2Singleton","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[],"name":"migrateL2WithFallbackHandler","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// MigrationBackend is what planning a migration needs from the chain: contract calls and storage reads.
+type MigrationBackend interface {
+	bind.ContractCaller
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// MigrationPlan describes the Safe transaction which moves a Safe to a new singleton.
+type MigrationPlan struct {
+	Safe               string `json:"safe"`
+	Migration          string `json:"migration"`
+	Method             string `json:"method"`
+	Data               string `json:"data"`
+	CurrentSingleton   string `json:"currentSingleton"`
+	CurrentVersion     string `json:"currentVersion"`
+	TargetSingleton    string `json:"targetSingleton"`
+	TargetVersion      string `json:"targetVersion"`
+	L2                 bool   `json:"l2"`
+	FallbackHandler    string `json:"fallbackHandler"`
+	NewFallbackHandler string `json:"newFallbackHandler"`
+}
+
+// MigrationResult is the result of the safe migrate command.
+type MigrationResult struct {
+	MigrationPlan
+	Simulated bool                   `json:"simulated"`
+	Proposal  *output.ProposalResult `json:"proposal,omitempty"`
+}
+
+func (r MigrationResult) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Safe: %s\n", r.Safe)
+	fmt.Fprintf(&b, "Singleton: %s (%s) -> %s (%s)\n", r.CurrentSingleton, r.CurrentVersion, r.TargetSingleton, r.TargetVersion)
+	if r.NewFallbackHandler != r.FallbackHandler {
+		fmt.Fprintf(&b, "Fallback handler: %s -> %s\n", r.FallbackHandler, r.NewFallbackHandler)
+	} else {
+		fmt.Fprintf(&b, "Fallback handler: %s (unchanged)\n", r.FallbackHandler)
+	}
+	fmt.Fprintf(&b, "Migration: DelegateCall %s.%s() (data: %s)\n", r.Migration, r.Method, r.Data)
+	if r.Simulated {
+		b.WriteString("Simulation: succeeded\n")
+	}
+	if r.Proposal != nil {
+		b.WriteString(r.Proposal.Text())
+	}
+	return b.String()
+}
+
+func storageAddress(ctx context.Context, backend MigrationBackend, account common.Address, slot common.Hash) (common.Address, error) {
+	value, err := backend.StorageAt(ctx, account, slot, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read storage of %s: %v", account.Hex(), err)
+	}
+	return common.BytesToAddress(value), nil
+}
+
+func callAddress(ctx context.Context, contract *bind.BoundContract, method string) (common.Address, error) {
+	var results []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &results, method); err != nil {
+		return common.Address{}, fmt.Errorf("failed to call %s: %v", method, err)
+	}
+	if len(results) != 1 {
+		return common.Address{}, fmt.Errorf("unexpected %s result", method)
+	}
+	address, ok := results[0].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("unexpected %s result", method)
+	}
+	return address, nil
+}
+
+// Checks that the Safe's storage is laid out as the migration expects, by comparing the raw owner count,
+// threshold and nonce slots with what the Safe reports through its getters.
+func CheckStorageLayout(ctx context.Context, backend MigrationBackend, safeAddress common.Address) error {
+	safe, err := Safe.NewSafeCaller(safeAddress, backend)
+	if err != nil {
+		return fmt.Errorf("failed to create Safe instance: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	owners, err := safe.GetOwners(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get owners: %v", err)
+	}
+	threshold, err := safe.GetThreshold(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get threshold: %v", err)
+	}
+	nonce, err := safe.Nonce(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	for _, slot := range []struct {
+		name     string
+		slot     common.Hash
+		expected *big.Int
+	}{
+		{"owner count", safetx.OwnerCountStorageSlot, big.NewInt(int64(len(owners)))},
+		{"threshold", safetx.ThresholdStorageSlot, threshold},
+		{"nonce", safetx.NonceStorageSlot, nonce},
+	} {
+		value, err := backend.StorageAt(ctx, safeAddress, slot.slot, nil)
+		if err != nil {
+			return fmt.Errorf("failed to read storage of %s: %v", safeAddress.Hex(), err)
+		}
+		if new(big.Int).SetBytes(value).Cmp(slot.expected) != 0 {
+			return fmt.Errorf("unexpected storage layout: slot %s does not hold the %s of the Safe", slot.slot.Big().String(), slot.name)
+		}
+	}
+	return nil
+}
+
+// Checks that a Safe can be migrated from a singleton of version current to one of version target
+// without reinterpreting its storage. Every Safe from 1.0.0 on shares the storage layout, and migrations
+// to older versions are refused.
+func CheckMigrationCompatibility(current, target safetx.Version) error {
+	if !current.AtLeast(1, 0, 0) {
+		return fmt.Errorf("migrating Safes of version %s is not supported: their storage layout predates 1.0.0", current)
+	}
+	if !target.AtLeast(1, 0, 0) {
+		return fmt.Errorf("migrating to a singleton of version %s is not supported: its storage layout predates 1.0.0", target)
+	}
+	if !target.AtLeast(current.Major, current.Minor, current.Patch) {
+		return fmt.Errorf("refusing to downgrade the Safe from version %s to %s", current, target)
+	}
+	return nil
+}
+
+// Plans the migration of the Safe at safeAddress with the SafeMigration-style contract at migration: reads
+// the Safe's current singleton, fallback handler and version, the targets of the migration contract, and
+// checks that the Safe's storage is compatible with the target singleton. If updateFallbackHandler is
+// true, the migration also sets the fallback handler of the migration contract.
+func PlanMigration(ctx context.Context, backend MigrationBackend, safeAddress, migration common.Address, l2, updateFallbackHandler bool) (*MigrationPlan, error) {
+	parsed, err := abi.JSON(strings.NewReader(safeMigrationABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SafeMigration ABI: %v", err)
+	}
+	migrationContract := bind.NewBoundContract(migration, parsed, backend, nil, nil)
+
+	currentSingleton, err := storageAddress(ctx, backend, safeAddress, safetx.SingletonStorageSlot)
+	if err != nil {
+		return nil, err
+	}
+	currentFallbackHandler, err := storageAddress(ctx, backend, safeAddress, safetx.FallbackHandlerStorageSlot)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion, err := safetx.DetectVersion(ctx, backend, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	singletonMethod, method := "SAFE_SINGLETON", "migrateSingleton"
+	if l2 {
+		singletonMethod, method = "SAFE_L2_SINGLETON", "migrateL2Singleton"
+	}
+	if updateFallbackHandler {
+		method = strings.TrimSuffix(method, "Singleton") + "WithFallbackHandler"
+	}
+
+	targetSingleton, err := callAddress(ctx, migrationContract, singletonMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the target singleton of migration contract %s: %v", migration.Hex(), err)
+	}
+	code, err := backend.CodeAt(ctx, targetSingleton, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code of singleton %s: %v", targetSingleton.Hex(), err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("target singleton %s has no code", targetSingleton.Hex())
+	}
+	targetVersion, err := safetx.DetectVersion(ctx, backend, targetSingleton)
+	if err != nil {
+		return nil, err
+	}
+
+	newFallbackHandler := currentFallbackHandler
+	if updateFallbackHandler {
+		newFallbackHandler, err = callAddress(ctx, migrationContract, "SAFE_FALLBACK_HANDLER")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the fallback handler of migration contract %s: %v", migration.Hex(), err)
+		}
+	}
+
+	if targetSingleton == currentSingleton && newFallbackHandler == currentFallbackHandler {
+		return nil, fmt.Errorf("Safe %s already uses singleton %s and fallback handler %s", safeAddress.Hex(), targetSingleton.Hex(), newFallbackHandler.Hex())
+	}
+	if err := CheckMigrationCompatibility(currentVersion, targetVersion); err != nil {
+		return nil, err
+	}
+	if err := CheckStorageLayout(ctx, backend, safeAddress); err != nil {
+		return nil, err
+	}
+
+	data, err := parsed.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %v", method, err)
+	}
+
+	return &MigrationPlan{
+		Safe:               safeAddress.Hex(),
+		Migration:          migration.Hex(),
+		Method:             method,
+		Data:               hexutil.Encode(data),
+		CurrentSingleton:   currentSingleton.Hex(),
+		CurrentVersion:     currentVersion.String(),
+		TargetSingleton:    targetSingleton.Hex(),
+		TargetVersion:      targetVersion.String(),
+		L2:                 l2,
+		FallbackHandler:    currentFallbackHandler.Hex(),
+		NewFallbackHandler: newFallbackHandler.Hex(),
+	}, nil
+}
+
+// Simulates the execution of the migration by the Safe with eth_call. The call is made as if owner
+// executed the SafeTx with a threshold of one and its own pre-validated signature, so that the
+// simulation exercises the Safe's guard and the migration contract without needing any signatures.
+func SimulateMigration(ctx context.Context, client *gethclient.Client, plan *MigrationPlan, owner common.Address) error {
+	safeABI, err := Safe.SafeMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("failed to get Safe ABI: %v", err)
+	}
+
+	// A pre-validated signature: r is the owner, s is zero and v is one.
+	signature := append(common.LeftPadBytes(owner.Bytes(), 32), append(make([]byte, 32), 1)...)
+	zero := big.NewInt(0)
+	execData, err := safeABI.Pack("execTransaction", common.HexToAddress(plan.Migration), zero, common.FromHex(plan.Data), uint8(safetx.DelegateCall), zero, zero, zero, common.Address{}, common.Address{}, signature)
+	if err != nil {
+		return fmt.Errorf("failed to pack execTransaction call: %v", err)
+	}
+
+	safeAddress := common.HexToAddress(plan.Safe)
+	overrides := map[common.Address]gethclient.OverrideAccount{
+		safeAddress: {StateDiff: map[common.Hash]common.Hash{safetx.ThresholdStorageSlot: common.BigToHash(big.NewInt(1))}},
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{From: owner, To: &safeAddress, Data: execData}, nil, &overrides)
+	if err != nil {
+		return fmt.Errorf("simulation of the migration failed: %v", err)
+	}
+	if len(result) != 32 || new(big.Int).SetBytes(result).Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("simulation of the migration failed: execTransaction did not succeed")
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// A tiny assembler for the hand-written contracts of the tests.
+type assembler struct {
+	code   []byte
+	labels map[string]int
+	jumps  map[int]string
+}
+
+func newAssembler() *assembler {
+	return &assembler{labels: map[string]int{}, jumps: map[int]string{}}
+}
+
+func (a *assembler) op(code ...byte) {
+	a.code = append(a.code, code...)
+}
+
+func (a *assembler) push(value []byte) {
+	a.op(byte(0x5f + len(value)))
+	a.op(value...)
+}
+
+func (a *assembler) label(name string) {
+	a.labels[name] = len(a.code)
+	a.op(0x5b) // JUMPDEST
+}
+
+func (a *assembler) jumpi(name string) {
+	a.jumps[len(a.code)+1] = name
+	a.op(0x61, 0, 0, 0x57) // PUSH2 label JUMPI
+}
+
+func (a *assembler) assemble() []byte {
+	for position, name := range a.jumps {
+		destination := a.labels[name]
+		a.code[position], a.code[position+1] = byte(destination>>8), byte(destination)
+	}
+	return a.code
+}
+
+// Returns the runtime code of a SafeMigration-style contract which migrates Safes to singleton (or
+// singletonL2) and fallbackHandler. If broken is true, its migration methods revert.
+func migrationContract(t *testing.T, singleton, singletonL2, fallbackHandler common.Address, broken bool) []byte {
+	parsed, err := abi.JSON(strings.NewReader(safeMigrationABI))
+	if err != nil {
+		t.Fatalf("could not parse SafeMigration ABI: %v", err)
+	}
+
+	a := newAssembler()
+	a.op(0x60, 0x00, 0x35, 0x60, 0xe0, 0x1c) // PUSH1 0 CALLDATALOAD PUSH1 224 SHR
+	for name, method := range parsed.Methods {
+		a.op(0x80) // DUP1
+		a.push(method.ID)
+		a.op(0x14) // EQ
+		a.jumpi(name)
+	}
+	a.op(0x60, 0x00, 0x80, 0xfd) // PUSH1 0 DUP1 REVERT
+
+	for name, value := range map[string]common.Address{
+		"SAFE_SINGLETON":        singleton,
+		"SAFE_L2_SINGLETON":     singletonL2,
+		"SAFE_FALLBACK_HANDLER": fallbackHandler,
+	} {
+		a.label(name)
+		a.push(value.Bytes())
+		a.op(0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3) // PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	}
+
+	for name, target := range map[string]common.Address{
+		"migrateSingleton":             singleton,
+		"migrateWithFallbackHandler":   singleton,
+		"migrateL2Singleton":           singletonL2,
+		"migrateL2WithFallbackHandler": singletonL2,
+	} {
+		a.label(name)
+		if broken {
+			a.op(0x60, 0x00, 0x80, 0xfd) // PUSH1 0 DUP1 REVERT
+			continue
+		}
+		a.push(target.Bytes())
+		a.op(0x60, 0x00, 0x55) // PUSH1 0 SSTORE
+		if strings.HasSuffix(name, "WithFallbackHandler") {
+			a.push(fallbackHandler.Bytes())
+			a.push(safetx.FallbackHandlerStorageSlot.Bytes())
+			a.op(0x55) // SSTORE
+		}
+		a.op(0x00) // STOP
+	}
+
+	return a.assemble()
+}
+
+func storedAddress(t *testing.T, chain *simtest.Chain, account common.Address, slot common.Hash) common.Address {
+	value, err := chain.Client.StorageAt(context.Background(), account, slot, nil)
+	if err != nil {
+		t.Fatalf("could not read storage: %v", err)
+	}
+	return common.BytesToAddress(value)
+}
+
+func TestMigrateSafe(t *testing.T) {
+	chain := simtest.New(t, 3)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 2, 1, 2)
+	fallbackHandler := chain.DeployCode(t, []byte{0x00})
+	migration := chain.DeployCode(t, migrationContract(t, deployment.Safe, deployment.SafeL2, fallbackHandler, false))
+
+	migrateArgs := []string{"safe", "migrate", "--rpc", chain.Endpoint, "--safe", safeAddress.Hex(), "--migration", migration.Hex()}
+
+	// Plan and simulate the migration to the L2 singleton and the new fallback handler.
+	var plan MigrationResult
+	mustRunCLI(t, &plan, append(migrateArgs, "--l2", "--dry-run")...)
+	if plan.Method != "migrateL2WithFallbackHandler" || !plan.Simulated || plan.Proposal != nil {
+		t.Fatalf("unexpected migration plan: %+v", plan)
+	}
+	if common.HexToAddress(plan.CurrentSingleton) != deployment.Safe || common.HexToAddress(plan.TargetSingleton) != deployment.SafeL2 {
+		t.Fatalf("unexpected singletons: %s -> %s", plan.CurrentSingleton, plan.TargetSingleton)
+	}
+	if common.HexToAddress(plan.FallbackHandler) != (common.Address{}) || common.HexToAddress(plan.NewFallbackHandler) != fallbackHandler {
+		t.Fatalf("unexpected fallback handlers: %s -> %s", plan.FallbackHandler, plan.NewFallbackHandler)
+	}
+
+	// Execute the planned Safe transaction.
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+	receipt, err := h.execTransactionTo(migration, common.FromHex(plan.Data), safetx.DelegateCall, 1, 2)
+	if err != nil {
+		t.Fatalf("could not execute migration: %v", err)
+	}
+	if len(parseEvents(h, receipt, h.filterer.ParseExecutionSuccess)) != 1 {
+		t.Fatalf("migration did not emit ExecutionSuccess")
+	}
+	if singleton := storedAddress(t, chain, safeAddress, safetx.SingletonStorageSlot); singleton != deployment.SafeL2 {
+		t.Fatalf("Safe uses singleton %s after the migration", singleton.Hex())
+	}
+	if handler := storedAddress(t, chain, safeAddress, safetx.FallbackHandlerStorageSlot); handler != fallbackHandler {
+		t.Fatalf("Safe uses fallback handler %s after the migration", handler.Hex())
+	}
+
+	// The migrated Safe is refused.
+	if err := runCLI(t, nil, append(migrateArgs, "--l2", "--dry-run")...); err == nil || !strings.Contains(err.Error(), "already uses") {
+		t.Fatalf("expected the migration of a migrated Safe to be refused, got %v", err)
+	}
+
+	// Propose the migration back to the L1 singleton, keeping the fallback handler.
+	var proposed map[string]interface{}
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+			t.Errorf("could not decode proposal: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer service.Close()
+
+	proposeArgs := append(migrateArgs,
+		"--keep-fallback-handler",
+		"--safe-api", service.URL,
+		"--keyfile", chain.Keystore(t, 1),
+		"--password", simtest.KeystorePassword,
+	)
+
+	// The migration contract is not in the chain registry, so its delegatecall is refused by default.
+	if err := runCLI(t, nil, proposeArgs...); err == nil || !strings.Contains(err.Error(), "not a known Safe library") || proposed != nil {
+		t.Fatalf("expected the delegatecall of an unknown migration contract to be refused, got %v", err)
+	}
+
+	var result MigrationResult
+	mustRunCLI(t, &result, append(proposeArgs, "--i-know-what-im-doing")...)
+	if result.Method != "migrateSingleton" || result.Proposal == nil {
+		t.Fatalf("unexpected migration result: %+v", result)
+	}
+	if common.HexToAddress(proposed["to"].(string)) != migration || proposed["operation"] != float64(safetx.DelegateCall) {
+		t.Fatalf("unexpected proposal: %v", proposed)
+	}
+	if proposed["data"] != result.Data {
+		t.Fatalf("proposed data %v, planned %s", proposed["data"], result.Data)
+	}
+}
+
+func TestMigrateSafeSimulationFailure(t *testing.T) {
+	chain := simtest.New(t, 2)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, 1)
+	migration := chain.DeployCode(t, migrationContract(t, deployment.SafeL2, deployment.SafeL2, common.Address{}, true))
+
+	err := runCLI(t, nil, "safe", "migrate", "--rpc", chain.Endpoint, "--safe", safeAddress.Hex(), "--migration", migration.Hex(), "--dry-run")
+	if err == nil || !strings.Contains(err.Error(), "simulation of the migration failed") {
+		t.Fatalf("expected the simulation to fail, got %v", err)
+	}
+}
+
+func TestCheckMigrationCompatibility(t *testing.T) {
+	for _, test := range []struct {
+		current    string
+		target     string
+		compatible bool
+	}{
+		{"1.3.0", "1.4.1", true},
+		{"1.1.1", "1.3.0", true},
+		{"1.4.1", "1.4.1", true},
+		{"1.4.1", "1.3.0", false},
+		{"0.1.0", "1.3.0", false},
+	} {
+		current, _ := safetx.ParseVersion(test.current)
+		target, _ := safetx.ParseVersion(test.target)
+		if err := CheckMigrationCompatibility(current, target); (err == nil) != test.compatible {
+			t.Fatalf("CheckMigrationCompatibility(%s, %s) = %v", test.current, test.target, err)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+// Package output renders the results of safes commands, either as human readable text or as JSON.
+//
+// The format is chosen once per invocation (through the root command's --output flag) with SetFormat.
+// Commands build a result value and hand it to Print, which encodes it as JSON or calls its Text method.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+)
+
+var currentFormat = Text
+
+// Sets the output format for the current invocation. Valid formats are "text" and "json".
+func SetFormat(format string) error {
+	switch Format(strings.ToLower(format)) {
+	case Text, "":
+		currentFormat = Text
+	case JSON:
+		currentFormat = JSON
+	default:
+		return fmt.Errorf("invalid output format: %s (must be \"text\" or \"json\")", format)
+	}
+	return nil
+}
+
+// Returns true if results should be printed as JSON.
+func IsJSON() bool {
+	return currentFormat == JSON
+}
+
+// Texter is implemented by results which know how to render themselves as text.
+type Texter interface {
+	Text() string
+}
+
+// Prints a result to the output stream of the given command, as JSON or as text depending on the
+// current format.
+func Print(cmd *cobra.Command, result interface{}) error {
+	return Fprint(cmd.OutOrStdout(), result)
+}
+
+// Prints a result to the given writer, as JSON or as text depending on the current format.
+func Fprint(w io.Writer, result interface{}) error {
+	if IsJSON() {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	if texter, ok := result.(Texter); ok {
+		_, err := fmt.Fprint(w, texter.Text())
+		return err
+	}
+
+	_, err := fmt.Fprintln(w, result)
+	return err
+}
+
+// Prints an error which terminated a command. In JSON mode, the error is written to stdout as a JSON
+// object so that scripts consuming the output can parse it. In text mode, it is written as is.
+func PrintError(err error) {
+	var reported ReportedError
+	if errors.As(err, &reported) {
+		return
+	}
+	if IsJSON() {
+		Fprint(os.Stdout, ErrorResult{Error: err.Error()})
+		return
+	}
+	fmt.Println(err.Error())
+}
+
+// ReportedError is an error whose details a command has already printed as part of its result, such
+// as a failed verification. It still fails the command, but PrintError does not print it again.
+type ReportedError struct {
+	Err error
+}
+
+func (e ReportedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ReportedError) Unwrap() error {
+	return e.Err
+}
+
+// Writes an informational message, such as a notice about a default value being used. Informational
+// messages go to stdout in text mode and to stderr in JSON mode, so that they never corrupt JSON
+// results.
+func Infoln(a ...interface{}) {
+	fmt.Fprintln(infoWriter(), a...)
+}
+
+// Formatted version of Infoln.
+func Infof(format string, a ...interface{}) {
+	fmt.Fprintf(infoWriter(), format, a...)
+}
+
+func infoWriter() io.Writer {
+	if IsJSON() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// ErrorResult is the JSON representation of a failed command.
+type ErrorResult struct {
+	Error string `json:"error"`
+}
+
+func (r ErrorResult) Text() string {
+	return r.Error + "\n"
+}
+
+// ViewResult holds the return values of a view method call.
+type ViewResult struct {
+	Outputs []interface{}
+}
+
+func (r ViewResult) Text() string {
+	var builder strings.Builder
+	for i, value := range r.Outputs {
+		fmt.Fprintf(&builder, "%d: %v\n", i, value)
+	}
+	return builder.String()
+}
+
+func (r ViewResult) MarshalJSON() ([]byte, error) {
+	outputs := make([]interface{}, len(r.Outputs))
+	for i, value := range r.Outputs {
+		outputs[i] = Normalize(value)
+	}
+	return json.Marshal(map[string]interface{}{"outputs": outputs})
+}
+
+// TransactionResult describes a transaction which was either submitted or (when simulating) only
+// built and estimated.
+type TransactionResult struct {
+	Hash            string `json:"hash"`
+	ContractAddress string `json:"contractAddress,omitempty"`
+	RawTransaction  string `json:"rawTransaction,omitempty"`
+	EstimatedGas    uint64 `json:"estimatedGas,omitempty"`
+	Submitted       bool   `json:"submitted"`
+}
+
+func (r TransactionResult) Text() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Transaction hash: %s\n", r.Hash)
+	if r.ContractAddress != "" {
+		fmt.Fprintf(&builder, "Contract address: %s\n", r.ContractAddress)
+	}
+	if r.Submitted {
+		builder.WriteString("Transaction submitted\n")
+	} else {
+		fmt.Fprintf(&builder, "Transaction: %s\nEstimated gas: %d\n", r.RawTransaction, r.EstimatedGas)
+	}
+	return builder.String()
+}
+
+// ProposalResult describes a transaction which was proposed to a Safe through the Safe Transaction
+// Service.
+type ProposalResult struct {
+	Safe       string `json:"safe"`
+	To         string `json:"to"`
+	Value      string `json:"value"`
+	Data       string `json:"data"`
+	Operation  uint8  `json:"operation"`
+	Nonce      uint64 `json:"nonce"`
+	SafeTxHash string `json:"safeTxHash"`
+	// The refund parameters of the transaction, which are only set if the Safe refunds its executor.
+	SafeTxGas      uint64      `json:"safeTxGas,omitempty"`
+	BaseGas        uint64      `json:"baseGas,omitempty"`
+	GasPrice       string      `json:"gasPrice,omitempty"`
+	GasToken       string      `json:"gasToken,omitempty"`
+	RefundReceiver string      `json:"refundReceiver,omitempty"`
+	Sender         string      `json:"sender"`
+	Signature      string      `json:"signature"`
+	ServiceURL     string      `json:"serviceUrl"`
+	StatusCode     int         `json:"statusCode"`
+	Response       interface{} `json:"response,omitempty"`
+	// ContractAddress is the address of the contract which the proposal deploys, if it deploys one.
+	ContractAddress string `json:"contractAddress,omitempty"`
+}
+
+func (r ProposalResult) Text() string {
+	text := fmt.Sprintf("Safe proposal created successfully\nSafeTxHash: %s\nNonce: %d\n", r.SafeTxHash, r.Nonce)
+	if r.SafeTxGas != 0 || r.BaseGas != 0 {
+		text += fmt.Sprintf("SafeTxGas: %d\nBaseGas: %d\nGas price: %s (token %s, refund receiver %s)\n", r.SafeTxGas, r.BaseGas, r.GasPrice, r.GasToken, r.RefundReceiver)
+	}
+	if r.ContractAddress != "" {
+		text += fmt.Sprintf("Contract address (once executed): %s\n", r.ContractAddress)
+	}
+	return text
+}
+
+// SimulationResult describes the simulated execution of a Safe transaction: its outcome, the logs it
+// emitted, the balances it changed and the changes it made to the Safe's configuration.
+type SimulationResult struct {
+	Safe       string `json:"safe"`
+	To         string `json:"to"`
+	Value      string `json:"value"`
+	Data       string `json:"data"`
+	Operation  uint8  `json:"operation"`
+	Nonce      uint64 `json:"nonce"`
+	SafeTxHash string `json:"safeTxHash"`
+	Success    bool   `json:"success"`
+	// Error is the revert reason of a transaction which failed.
+	Error   string `json:"error,omitempty"`
+	GasUsed uint64 `json:"gasUsed,omitempty"`
+	// Effects is false if the node could only report the outcome of the transaction, in which case
+	// Logs, BalanceChanges and SafeChanges are empty.
+	Effects        bool            `json:"effects"`
+	Logs           []SimulatedLog  `json:"logs"`
+	BalanceChanges []BalanceChange `json:"balanceChanges"`
+	SafeChanges    []SafeChange    `json:"safeChanges"`
+}
+
+// SimulatedLog is a log emitted during a simulation. Logs which are not decoded have the event
+// "Unknown", and their topics and data as arguments.
+type SimulatedLog struct {
+	Address   string                 `json:"address"`
+	Event     string                 `json:"event"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// BalanceChange is the change of the balance of an account in a token, or in the native currency if
+// Token is the zero address. Change is a signed decimal number of base units.
+type BalanceChange struct {
+	Account string `json:"account"`
+	Token   string `json:"token"`
+	Change  string `json:"change"`
+}
+
+// SafeChange is a change to the owners, threshold, modules or guard of a Safe.
+type SafeChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func (r SimulationResult) Text() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Simulation of Safe transaction %s (nonce %d)\n", r.SafeTxHash, r.Nonce)
+	if r.Success {
+		builder.WriteString("Result: success\n")
+	} else {
+		fmt.Fprintf(&builder, "Result: failure (%s)\n", r.Error)
+	}
+	if r.GasUsed != 0 {
+		fmt.Fprintf(&builder, "Gas used: %d\n", r.GasUsed)
+	}
+	if !r.Effects {
+		builder.WriteString("Effects: not available from this node (eth_simulateV1 is not supported)\n")
+		return builder.String()
+	}
+
+	fmt.Fprintf(&builder, "Logs: %d\n", len(r.Logs))
+	for _, log := range r.Logs {
+		names := make([]string, 0, len(log.Arguments))
+		for name := range log.Arguments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		arguments := make([]string, len(names))
+		for i, name := range names {
+			arguments[i] = fmt.Sprintf("%s=%v", name, log.Arguments[name])
+		}
+		fmt.Fprintf(&builder, "  %s %s %s\n", log.Address, log.Event, strings.Join(arguments, " "))
+	}
+	fmt.Fprintf(&builder, "Balance changes: %d\n", len(r.BalanceChanges))
+	for _, change := range r.BalanceChanges {
+		token := change.Token
+		if common.HexToAddress(token) == (common.Address{}) {
+			token = "native"
+		}
+		fmt.Fprintf(&builder, "  %s %s %s\n", change.Account, change.Change, token)
+	}
+	fmt.Fprintf(&builder, "Safe changes: %d\n", len(r.SafeChanges))
+	for _, change := range r.SafeChanges {
+		fmt.Fprintf(&builder, "  %s: %v -> %v\n", change.Field, change.Before, change.After)
+	}
+	return builder.String()
+}
+
+// Decodes the body of an HTTP response for inclusion in a result. JSON bodies are kept as JSON, other
+// bodies are kept as strings.
+func ResponseBody(body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		return decoded
+	}
+	return string(body)
+}
+
+// Converts values returned by contract bindings into values which encode naturally as JSON: byte
+// arrays and slices become hex strings, big integers become decimal strings (so that no precision is
+// lost by JSON parsers), addresses and hashes become their hex representations, and structs become
+// objects.
+func Normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case json.Marshaler:
+		return v
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Array:
+		if reflected.Type().Elem().Kind() == reflect.Uint8 {
+			raw := make([]byte, reflected.Len())
+			reflect.Copy(reflect.ValueOf(raw), reflected)
+			return hexutil.Encode(raw)
+		}
+		fallthrough
+	case reflect.Slice:
+		normalized := make([]interface{}, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			normalized[i] = Normalize(reflected.Index(i).Interface())
+		}
+		return normalized
+	case reflect.Struct:
+		normalized := map[string]interface{}{}
+		for i := 0; i < reflected.NumField(); i++ {
+			field := reflected.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+				name = tag
+			}
+			normalized[name] = Normalize(reflected.Field(i).Interface())
+		}
+		return normalized
+	case reflect.Ptr:
+		if reflected.IsNil() {
+			return nil
+		}
+		return Normalize(reflected.Elem().Interface())
+	}
+
+	return value
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func CreateSafeCmd() *cobra.Command {
+	safeCmd := &cobra.Command{
+		Use:   "safe",
+		Short: "Manage deployed Safes",
+		Long:  `Manage deployed Safes: inspect them and build the Safe transactions that maintain them.`,
+	}
+
+	safeCmd.AddCommand(createMigrateCmd())
+	safeCmd.AddCommand(createVerifyCmd())
+	safeCmd.AddCommand(createEventsCmd())
+	safeCmd.AddCommand(createHistoryCmd())
+	safeCmd.AddCommand(createWatchCmd())
+	safeCmd.AddCommand(createMessageCmd())
+
+	return safeCmd
+}
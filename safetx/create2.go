@@ -0,0 +1,95 @@
+package safetx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+)
+
+var hexDigitsPattern = regexp.MustCompile(`^[0-9a-fA-F]*$`)
+
+// Parses a CREATE2 salt. The salt may be given as hex with a 0x prefix (at most 32 bytes, left-padded
+// with zeros), or as a decimal number below 2^256. Input without the 0x prefix is always decimal, so that
+// a salt made only of decimal digits cannot be read as hex by mistake.
+func ParseSalt(raw string) ([32]byte, error) {
+	var salt [32]byte
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case strings.HasPrefix(raw, "0x") || strings.HasPrefix(raw, "0X"):
+		digits := raw[2:]
+		if len(digits)%2 == 1 {
+			digits = "0" + digits
+		}
+		if len(digits) > 64 || !hexDigitsPattern.MatchString(digits) {
+			return salt, fmt.Errorf("invalid salt: %s (expected at most 32 bytes of hex)", raw)
+		}
+		copy(salt[32-len(digits)/2:], common.FromHex(digits))
+	default:
+		value, ok := new(big.Int).SetString(raw, 10)
+		if !ok && raw != "" && hexDigitsPattern.MatchString(raw) {
+			return salt, fmt.Errorf("invalid salt: %s (hex salts need the 0x prefix)", raw)
+		}
+		if !ok || value.Sign() < 0 || value.BitLen() > 256 {
+			return salt, fmt.Errorf("invalid salt: %s (expected 0x-prefixed hex or a decimal number)", raw)
+		}
+		value.FillBytes(salt[:])
+	}
+	return salt, nil
+}
+
+// Returns the account which executes the CREATE2 of a CreateCall performCreate2 call made by a Safe.
+// When the Safe delegatecalls CreateCall, the Safe itself creates the contract.
+func CreateCallDeployer(safeAddress, createCall common.Address, operation OperationType) common.Address {
+	if operation == DelegateCall {
+		return safeAddress
+	}
+	return createCall
+}
+
+// Returns the address of the contract which deployer creates with CREATE2 from initCode and salt.
+func Create2Address(deployer common.Address, salt [32]byte, initCode []byte) common.Address {
+	return crypto.CreateAddress2(deployer, salt, crypto.Keccak256(initCode))
+}
+
+// Waits until the Safe has executed its transaction with the given nonce, polling every interval, and
+// then checks that there is code at contractAddress.
+func WaitForDeployment(ctx context.Context, caller bind.ContractCaller, safeAddress common.Address, nonce uint64, contractAddress common.Address, interval time.Duration) error {
+	safe, err := GnosisSafe.NewGnosisSafeCaller(safeAddress, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+	}
+
+	for {
+		currentNonce, err := safe.Nonce(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
+		}
+		if currentNonce.Uint64() > nonce {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the Safe transaction with nonce %d was not executed: %v", nonce, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+
+	code, err := caller.CodeAt(ctx, contractAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code of %s: %v", contractAddress.Hex(), err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("the Safe transaction with nonce %d was executed, but there is no code at %s", nonce, contractAddress.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package safetx_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/safes/safetx"
+)
+
+func TestParseSalt(t *testing.T) {
+	maxSalt := strings.Repeat("f", 64)
+	for input, expected := range map[string]common.Hash{
+		"0":            {},
+		"7":            common.BigToHash(big.NewInt(7)),
+		"256":          common.BigToHash(big.NewInt(256)),
+		"0x7":          common.BigToHash(big.NewInt(7)),
+		"0x0100":       common.BigToHash(big.NewInt(256)),
+		"0x" + maxSalt: common.HexToHash(maxSalt),
+		// Digits without the 0x prefix are decimal, even when there are 64 of them.
+		strings.Repeat("1", 64):                       common.BigToHash(decimal(t, strings.Repeat("1", 64))),
+		new(big.Int).Lsh(big.NewInt(1), 255).String(): common.BigToHash(new(big.Int).Lsh(big.NewInt(1), 255)),
+	} {
+		salt, err := safetx.ParseSalt(input)
+		if err != nil || common.Hash(salt) != expected {
+			t.Fatalf("ParseSalt(%q) = %x, %v", input, salt, err)
+		}
+	}
+
+	for _, input := range []string{"", "salt", "-1", "0x" + maxSalt + "00", "0xzz", new(big.Int).Lsh(big.NewInt(1), 256).String()} {
+		if _, err := safetx.ParseSalt(input); err == nil {
+			t.Fatalf("ParseSalt(%q) succeeded", input)
+		}
+	}
+	if _, err := safetx.ParseSalt(maxSalt); err == nil || !strings.Contains(err.Error(), "0x prefix") {
+		t.Fatalf("expected hex without the 0x prefix to be refused, got %v", err)
+	}
+}
+
+func decimal(t *testing.T, digits string) *big.Int {
+	t.Helper()
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		t.Fatalf("invalid decimal number %s", digits)
+	}
+	return value
+}
+
+func TestCreateCallDeployer(t *testing.T) {
+	safe := common.HexToAddress("0x5afe")
+	createCall := common.HexToAddress("0xc0de")
+	if deployer := safetx.CreateCallDeployer(safe, createCall, safetx.DelegateCall); deployer != safe {
+		t.Fatalf("a delegatecall to CreateCall deploys from %s", deployer.Hex())
+	}
+	if deployer := safetx.CreateCallDeployer(safe, createCall, safetx.Call); deployer != createCall {
+		t.Fatalf("a call to CreateCall deploys from %s", deployer.Hex())
+	}
+}
@@ -0,0 +1,157 @@
+package safetx
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/moonstream-to/seer/bindings/CreateCall"
+
+	"github.com/G7DAO/safes/output"
+)
+
+// Methods which Safe transactions commonly call besides those of the Safe itself: MultiSend,
+// SignMessageLib and ERC20 transfers and approvals.
+const decodeABIJSON = `[
+	{"inputs":[{"name":"transactions","type":"bytes"}],"name":"multiSend","outputs":[],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"name":"_data","type":"bytes"}],"name":"signMessage","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transferFrom","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// Byte arguments longer than this are abbreviated when calldata is described.
+const maxDescribedBytes = 64
+
+var decodeABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(decodeABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// The methods by which calldata is decoded, by selector.
+var knownMethods = func() map[[4]byte]abi.Method {
+	createCallABI, err := CreateCall.CreateCallMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	methods := map[[4]byte]abi.Method{}
+	for _, known := range []abi.ABI{decodeABI, policyABI, simulationABI, *createCallABI} {
+		for _, method := range known.Methods {
+			methods[[4]byte(method.ID)] = method
+		}
+	}
+	return methods
+}()
+
+// Registers the methods of a contract ABI so that calls of them are decoded when Safe transactions are
+// reviewed. Methods whose selectors are already known are skipped.
+func RegisterABI(contractABI abi.ABI) {
+	for _, method := range contractABI.Methods {
+		selector := [4]byte(method.ID)
+		if _, ok := knownMethods[selector]; !ok {
+			knownMethods[selector] = method
+		}
+	}
+}
+
+// Returns a human readable description of calldata, such as "changeThreshold(_threshold: 2)". The
+// transactions of a MultiSend batch are described one per line. Calldata of unknown methods is
+// described by its selector.
+func DescribeCalldata(data []byte) string {
+	if len(data) == 0 {
+		return "none"
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("unknown calldata %s", hexutil.Encode(data))
+	}
+	selector := [4]byte(data[:4])
+	method, ok := knownMethods[selector]
+	if !ok {
+		return fmt.Sprintf("unknown method %s", hexutil.Encode(data[:4]))
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return fmt.Sprintf("%s with invalid arguments: %v", method.Name, err)
+	}
+
+	if method.Sig == decodeABI.Methods["multiSend"].Sig {
+		transactions, err := describeMultiSend(args[0].([]byte))
+		if err != nil {
+			return fmt.Sprintf("multiSend with invalid transactions: %v", err)
+		}
+		return "multiSend(\n" + strings.Join(transactions, "\n") + "\n)"
+	}
+
+	described := make([]string, len(args))
+	for i, arg := range args {
+		described[i] = fmt.Sprintf("%s: %s", method.Inputs[i].Name, describeArgument(arg))
+	}
+	return fmt.Sprintf("%s(%s)", method.Name, strings.Join(described, ", "))
+}
+
+// Returns the details shown with the SafeTx typed data of a transaction when it is reviewed before
+// signing: its operation and its decoded calldata.
+func ReviewDetails(txData TransactionData) []string {
+	return []string{
+		fmt.Sprintf("Operation: %s", txData.Operation),
+		fmt.Sprintf("Decoded data: %s", DescribeCalldata(common.FromHex(txData.Data))),
+	}
+}
+
+func describeArgument(arg interface{}) string {
+	if raw, ok := arg.([]byte); ok && len(raw) > maxDescribedBytes {
+		return fmt.Sprintf("%s... (%d bytes)", hexutil.Encode(raw[:maxDescribedBytes]), len(raw))
+	}
+	return fmt.Sprint(output.Normalize(arg))
+}
+
+// multiSendTransaction is a single transaction of a MultiSend batch.
+type multiSendTransaction struct {
+	Operation OperationType
+	To        common.Address
+	Value     *big.Int
+	Data      []byte
+}
+
+// Unpacks the transactions of a MultiSend batch, which are packed as operation (1 byte), to (20 bytes),
+// value (32 bytes), data length (32 bytes) and data.
+func unpackMultiSend(packed []byte) ([]multiSendTransaction, error) {
+	var transactions []multiSendTransaction
+	for len(packed) > 0 {
+		if len(packed) < 85 {
+			return nil, fmt.Errorf("truncated transaction %d", len(transactions)+1)
+		}
+		length := new(big.Int).SetBytes(packed[53:85])
+		if !length.IsUint64() || length.Uint64() > uint64(len(packed)-85) {
+			return nil, fmt.Errorf("truncated data of transaction %d", len(transactions)+1)
+		}
+		end := 85 + int(length.Uint64())
+		transactions = append(transactions, multiSendTransaction{
+			Operation: OperationType(packed[0]),
+			To:        common.BytesToAddress(packed[1:21]),
+			Value:     new(big.Int).SetBytes(packed[21:53]),
+			Data:      packed[85:end],
+		})
+		packed = packed[end:]
+	}
+	return transactions, nil
+}
+
+// Describes the transactions of a MultiSend batch, one per line.
+func describeMultiSend(packed []byte) ([]string, error) {
+	transactions, err := unpackMultiSend(packed)
+	if err != nil {
+		return nil, err
+	}
+	described := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		described[i] = fmt.Sprintf("  %d. %s to %s, value %s: %s", i+1, transaction.Operation, transaction.To.Hex(), transaction.Value.String(), strings.ReplaceAll(DescribeCalldata(transaction.Data), "\n", "\n    "))
+	}
+	return described, nil
+}
@@ -0,0 +1,126 @@
+package safetx_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// A transaction of a MultiSend batch.
+type batchedTransaction struct {
+	operation safetx.OperationType
+	to        common.Address
+	value     int64
+	data      []byte
+}
+
+// Returns the calldata of a multiSend call which batches the given transactions.
+func multiSendCalldata(transactions ...batchedTransaction) []byte {
+	var packed []byte
+	for _, transaction := range transactions {
+		packed = append(packed, byte(transaction.operation))
+		packed = append(packed, transaction.to.Bytes()...)
+		packed = append(packed, common.LeftPadBytes(big.NewInt(transaction.value).Bytes(), 32)...)
+		packed = append(packed, common.LeftPadBytes(big.NewInt(int64(len(transaction.data))).Bytes(), 32)...)
+		packed = append(packed, transaction.data...)
+	}
+	data := append(crypto.Keccak256([]byte("multiSend(bytes)"))[:4], common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(int64(len(packed))).Bytes(), 32)...)
+	return append(data, common.RightPadBytes(packed, (len(packed)+31)/32*32)...)
+}
+
+func TestDescribeCalldata(t *testing.T) {
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	changeThreshold := safeCalldata(t, "changeThreshold", big.NewInt(2))
+
+	// A MultiSend batch of a plain transfer and a changeThreshold call.
+	multiSend := multiSendCalldata(batchedTransaction{safetx.Call, owner, 1, nil}, batchedTransaction{safetx.Call, owner, 1, changeThreshold})
+
+	cases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{"empty", nil, "none"},
+		{"unknown", common.FromHex("0xdeadbeef00"), "unknown method 0xdeadbeef"},
+		{"known", changeThreshold, "changeThreshold(_threshold: 2)"},
+		{"arguments", safeCalldata(t, "swapOwner", owner, owner, owner), "swapOwner(prevOwner: " + owner.Hex() + ", oldOwner: " + owner.Hex() + ", newOwner: " + owner.Hex() + ")"},
+		{"multiSend", multiSend, "multiSend(\n  1. Call to " + owner.Hex() + ", value 1: none\n  2. Call to " + owner.Hex() + ", value 1: changeThreshold(_threshold: 2)\n)"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if described := safetx.DescribeCalldata(c.data); described != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, described)
+			}
+		})
+	}
+}
+
+func TestReviewMatchesSafe(t *testing.T) {
+	chain := simtest.New(t, 3)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, 1)
+
+	txData := safetx.NewTransactionData(safeAddress, safeCalldata(t, "changeThreshold", big.NewInt(1)), big.NewInt(0), safetx.Call)
+	prepared, err := safetx.PrepareTransaction(context.Background(), chain.Client, safeAddress, txData, simtest.ChainID)
+	if err != nil {
+		t.Fatalf("could not prepare transaction: %v", err)
+	}
+	review, err := signer.ReviewTypedData(prepared.TypedData, safetx.ReviewDetails(txData)...)
+	if err != nil {
+		t.Fatalf("could not review typed data: %v", err)
+	}
+
+	safe, err := Safe.NewSafe(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not bind Safe: %v", err)
+	}
+	domainSeparator, err := safe.DomainSeparator(&bind.CallOpts{})
+	if err != nil {
+		t.Fatalf("could not get domain separator: %v", err)
+	}
+
+	fields := map[string]string{}
+	for _, line := range strings.Split(review, "\n") {
+		if name, value, found := strings.Cut(strings.TrimSpace(line), ": "); found {
+			fields[name] = value
+		}
+	}
+	if fields["Domain hash"] != common.Hash(domainSeparator).Hex() {
+		t.Fatalf("the domain hash %s is not the Safe's domain separator %s", fields["Domain hash"], common.Hash(domainSeparator).Hex())
+	}
+	signingHash := crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator[:], common.FromHex(fields["Message hash"]))
+	if signingHash != prepared.SafeTxHash || fields["Signing hash"] != prepared.SafeTxHash.Hex() {
+		t.Fatalf("the message hash %s does not produce the SafeTxHash %s", fields["Message hash"], prepared.SafeTxHash.Hex())
+	}
+
+	expected := map[string]string{
+		"chainId":           simtest.ChainID.String(),
+		"verifyingContract": safeAddress.Hex(),
+		"to":                safeAddress.Hex(),
+		"operation":         "0",
+		"nonce":             "0",
+		"Operation":         "Call",
+		"Decoded data":      "changeThreshold(_threshold: 1)",
+	}
+	for name, value := range expected {
+		if fields[name] != value {
+			t.Fatalf("expected %s to be %q in the review, got %q:\n%s", name, value, fields[name], review)
+		}
+	}
+	for _, field := range []string{"value", "data", "safeTxGas", "baseGas", "gasPrice", "gasToken", "refundReceiver"} {
+		if _, ok := fields[field]; !ok {
+			t.Fatalf("the review does not show %s:\n%s", field, review)
+		}
+	}
+}
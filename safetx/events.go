@@ -0,0 +1,53 @@
+package safetx
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/G7DAO/safes/output"
+)
+
+// Decodes a log with the events of eventsABI, and returns the name and arguments of its event. Safes
+// before 1.4.0 emit the same events without indexed arguments, so the leading arguments are decoded from
+// as many topics as the log has. Logs of other events are returned as Unknown, with their raw topics and
+// data, as are logs whose arguments cannot be decoded, for which an error is returned as well.
+func DecodeEvent(eventsABI *abi.ABI, log types.Log) (string, map[string]interface{}, error) {
+	topics := make([]string, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = topic.Hex()
+	}
+	raw := map[string]interface{}{"topics": topics, "data": hexutil.Encode(log.Data)}
+
+	var event *abi.Event
+	if len(log.Topics) > 0 {
+		event, _ = eventsABI.EventByID(log.Topics[0])
+	}
+	if event == nil || len(log.Topics)-1 > len(event.Inputs) {
+		return "Unknown", raw, nil
+	}
+
+	var indexed, nonIndexed abi.Arguments
+	for i, input := range event.Inputs {
+		input.Indexed = i < len(log.Topics)-1
+		if input.Indexed {
+			indexed = append(indexed, input)
+		} else {
+			nonIndexed = append(nonIndexed, input)
+		}
+	}
+
+	arguments := map[string]interface{}{}
+	if err := nonIndexed.UnpackIntoMap(arguments, log.Data); err != nil {
+		return "Unknown", raw, fmt.Errorf("failed to decode %s: %v", event.Name, err)
+	}
+	if err := abi.ParseTopicsIntoMap(arguments, indexed, log.Topics[1:]); err != nil {
+		return "Unknown", raw, fmt.Errorf("failed to decode %s: %v", event.Name, err)
+	}
+	for name, value := range arguments {
+		arguments[name] = output.Normalize(value)
+	}
+	return event.Name, arguments, nil
+}
@@ -0,0 +1,251 @@
+package safetx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+)
+
+// GasOptions are the refund parameters of a Safe transaction. With a nonzero GasPrice, the Safe pays
+// the executor of the transaction (or RefundReceiver, if it is set) for the gas it used, in GasToken or
+// in the native currency if GasToken is the zero address.
+type GasOptions struct {
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+}
+
+// Parses the refund parameters of a Safe transaction from the values of command line flags. An empty
+// gas price is zero and empty addresses are the zero address.
+func ParseGasOptions(gasPrice, gasToken, refundReceiver string) (GasOptions, error) {
+	var options GasOptions
+	if gasPrice != "" {
+		parsed, ok := new(big.Int).SetString(gasPrice, 0)
+		if !ok || parsed.Sign() < 0 {
+			return options, fmt.Errorf("invalid Safe gas price: %s", gasPrice)
+		}
+		options.GasPrice = parsed
+	}
+	for _, address := range []struct {
+		raw    string
+		name   string
+		target *common.Address
+	}{{gasToken, "gas token", &options.GasToken}, {refundReceiver, "refund receiver", &options.RefundReceiver}} {
+		if address.raw == "" {
+			continue
+		}
+		if !common.IsHexAddress(address.raw) {
+			return options, fmt.Errorf("invalid %s address: %s", address.name, address.raw)
+		}
+		*address.target = common.HexToAddress(address.raw)
+	}
+	if !options.Refunded() && (options.GasToken != (common.Address{}) || options.RefundReceiver != (common.Address{})) {
+		return options, fmt.Errorf("a gas token or refund receiver requires a nonzero Safe gas price")
+	}
+	return options, nil
+}
+
+// Returns true if the Safe refunds the executor of the transaction.
+func (o GasOptions) Refunded() bool {
+	return o.GasPrice != nil && o.GasPrice.Sign() > 0
+}
+
+// Sets the refund parameters of txData. Transactions without a refund keep a safeTxGas and baseGas of
+// zero, so that their call may use all the gas of the execution and the execution reverts if the call
+// fails. Refunded transactions get an estimated safeTxGas and baseGas, which the Safe refunds at most.
+func ApplyGasOptions(ctx context.Context, client *ethclient.Client, safeAddress common.Address, txData *TransactionData, options GasOptions) error {
+	if !options.Refunded() {
+		return nil
+	}
+
+	safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
+	if err != nil {
+		return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	nonce, err := safeInstance.Nonce(opts)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
+	}
+	threshold, err := safeInstance.GetThreshold(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get threshold: %v", err)
+	}
+
+	txData.GasPrice = options.GasPrice.String()
+	txData.GasToken = options.GasToken.Hex()
+	txData.RefundReceiver = options.RefundReceiver.Hex()
+	if txData.SafeTxGas, err = EstimateSafeTxGas(ctx, client, safeAddress, *txData); err != nil {
+		return err
+	}
+	if txData.BaseGas, err = EstimateBaseGas(*txData, nonce.Uint64(), int(threshold.Int64())); err != nil {
+		return err
+	}
+	return nil
+}
+
+// The address at which safeTxGasAccessor is put (with a state override) to be delegatecalled by the Safe.
+var safeTxGasAccessorAddress = common.HexToAddress("0x5afe0000000000000000000000000000000e5717")
+
+// safeTxGasAccessor makes the call of a Safe transaction and measures its gas. Its calldata is the
+// operation, the target and the value of the call as words, followed by the data of the call. It returns
+// the gas used, whether the call succeeded and what it returned.
+var safeTxGasAccessor = []byte{
+	0x36, 0x60, 0x60, 0x90, 0x03, // len := calldatasize() - 0x60
+	0x80, 0x60, 0x60, 0x60, 0x00, 0x37, // calldatacopy(0, 0x60, len)
+	0x5a,                               // before := gas()
+	0x60, 0x00, 0x35, 0x60, 0x24, 0x57, // if calldataload(0) { jump to delegatecall }
+	0x60, 0x00, 0x60, 0x00, 0x83, 0x60, 0x00, 0x60, 0x40, 0x35, 0x60, 0x20, 0x35, 0x5a, 0xf1, // call(gas(), to, value, 0, len, 0, 0)
+	0x60, 0x31, 0x56, // jump to result
+	0x5b, 0x60, 0x00, 0x60, 0x00, 0x83, 0x60, 0x00, 0x60, 0x20, 0x35, 0x5a, 0xf4, // delegatecall(gas(), to, 0, len, 0, 0)
+	0x5b, 0x5a, 0x82, 0x03, 0x60, 0x00, 0x52, // mstore(0, before - gas())
+	0x60, 0x20, 0x52, // mstore(0x20, success)
+	0x3d, 0x60, 0x00, 0x60, 0x40, 0x3e, // returndatacopy(0x40, 0, returndatasize())
+	0x3d, 0x60, 0x40, 0x01, 0x60, 0x00, 0xf3, // return(0, returndatasize() + 0x40)
+}
+
+const gasEstimationABIJSON = `[
+	{"inputs":[{"name":"targetContract","type":"address"},{"name":"calldataPayload","type":"bytes"}],"name":"simulateAndRevert","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"}],"name":"requiredTxGas","outputs":[{"name":"","type":"uint256"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var gasEstimationABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(gasEstimationABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// Estimates the safeTxGas of a Safe transaction: the gas of its call, with a margin for the gas which
+// calls made by the call keep back (a call passes on at most 63/64 of the remaining gas).
+//
+// From Safe 1.3.0, the call is made by the Safe through its simulateAndRevert method, which delegatecalls
+// an accessor contract that measures the gas of the call. Earlier Safes measure it with requiredTxGas.
+// Both revert, so the estimation does not change the Safe.
+func EstimateSafeTxGas(ctx context.Context, client *ethclient.Client, safeAddress common.Address, txData TransactionData) (uint64, error) {
+	version, err := DetectVersion(ctx, client, safeAddress)
+	if err != nil {
+		return 0, err
+	}
+	to := common.HexToAddress(txData.To)
+	value, ok := new(big.Int).SetString(txData.Value, 10)
+	if !ok {
+		return 0, fmt.Errorf("invalid value: %s", txData.Value)
+	}
+	data := common.FromHex(txData.Data)
+
+	var used uint64
+	if version.AtLeast(1, 3, 0) {
+		payload := append(common.BigToHash(big.NewInt(int64(txData.Operation))).Bytes(), common.LeftPadBytes(to.Bytes(), 32)...)
+		payload = append(payload, common.BigToHash(value).Bytes()...)
+		payload = append(payload, data...)
+		callData, err := gasEstimationABI.Pack("simulateAndRevert", safeTxGasAccessorAddress, payload)
+		if err != nil {
+			return 0, fmt.Errorf("failed to pack simulateAndRevert call: %v", err)
+		}
+		overrides := map[common.Address]gethclient.OverrideAccount{safeTxGasAccessorAddress: {Code: safeTxGasAccessor}}
+		_, err = gethclient.New(client.Client()).CallContract(ctx, ethereum.CallMsg{To: &safeAddress, Data: callData}, nil, &overrides)
+		if err == nil {
+			return 0, fmt.Errorf("simulateAndRevert of Safe %s did not revert", safeAddress.Hex())
+		}
+		// simulateAndRevert reverts with whether the accessor succeeded, the size of what it returned and
+		// what it returned: the gas used, whether the call succeeded and what the call returned.
+		result := revertData(err)
+		if len(result) < 128 || result[31] != 1 {
+			return 0, fmt.Errorf("failed to estimate safeTxGas: %v", err)
+		}
+		if result[127] != 1 {
+			return 0, fmt.Errorf("the call of the Safe transaction fails: %s", revertReason(result[128:], "no revert reason"))
+		}
+		used = new(big.Int).SetBytes(result[64:96]).Uint64()
+	} else {
+		callData, err := gasEstimationABI.Pack("requiredTxGas", to, value, data, uint8(txData.Operation))
+		if err != nil {
+			return 0, fmt.Errorf("failed to pack requiredTxGas call: %v", err)
+		}
+		_, err = client.CallContract(ctx, ethereum.CallMsg{From: safeAddress, To: &safeAddress, Data: callData}, nil)
+		if err == nil {
+			return 0, fmt.Errorf("requiredTxGas of Safe %s did not revert", safeAddress.Hex())
+		}
+		// requiredTxGas reverts with the gas as the 32 bytes of a revert reason, and with GS013 (or no
+		// reason) if the call fails.
+		reason, unpackErr := abi.UnpackRevert(revertData(err))
+		if unpackErr != nil || len(reason) != 32 {
+			return 0, fmt.Errorf("the call of the Safe transaction fails: %v", err)
+		}
+		used = new(big.Int).SetBytes([]byte(reason)).Uint64()
+	}
+	return used + used/10, nil
+}
+
+// The gas of the parts of an execution which baseGas covers.
+const (
+	txIntrinsicGas = 21000
+	// An ecrecover and the checks of the owner which signed.
+	signatureCheckGas = 6000
+	// The nonce is read and written. Writing to a zero slot costs more.
+	nonceUpdateGas      = 5000
+	firstNonceUpdateGas = 22100
+	// The rest of the execution which the Safe does not measure: hashing the transaction, checking its
+	// guard, emitting its events and the memory it uses.
+	executionOverheadGas = 15000
+	// The payment of a refund in the native currency, or in an ERC20 token.
+	nativeRefundGas = 12000
+	tokenRefundGas  = 50000
+)
+
+// Estimates the baseGas of a Safe transaction at the given nonce of a Safe with the given threshold:
+// the gas of the execution which is not spent by its call, which is the intrinsic gas of the transaction
+// and the gas of its calldata, the checks of its signatures, the update of the nonce, the overhead of
+// execTransaction and the payment of the refund.
+func EstimateBaseGas(txData TransactionData, nonce uint64, threshold int) (uint64, error) {
+	value, ok := new(big.Int).SetString(txData.Value, 10)
+	if !ok {
+		return 0, fmt.Errorf("invalid value: %s", txData.Value)
+	}
+	gasPrice, ok := new(big.Int).SetString(txData.GasPrice, 10)
+	if !ok {
+		return 0, fmt.Errorf("invalid gas price: %s", txData.GasPrice)
+	}
+	// Signatures are priced as nonzero bytes, which is what they mostly are.
+	signatures := bytes.Repeat([]byte{0xff}, 65*threshold)
+	calldata, err := simulationABI.Pack("execTransaction",
+		common.HexToAddress(txData.To), value, common.FromHex(txData.Data), uint8(txData.Operation),
+		new(big.Int).SetUint64(txData.SafeTxGas), new(big.Int).SetUint64(txData.BaseGas), gasPrice,
+		common.HexToAddress(txData.GasToken), common.HexToAddress(txData.RefundReceiver), signatures)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack execTransaction call: %v", err)
+	}
+
+	gas := uint64(txIntrinsicGas + executionOverheadGas)
+	for _, b := range calldata {
+		if b == 0 {
+			gas += 4
+		} else {
+			gas += 16
+		}
+	}
+	gas += uint64(threshold) * signatureCheckGas
+	if nonce == 0 {
+		gas += firstNonceUpdateGas
+	} else {
+		gas += nonceUpdateGas
+	}
+	if common.HexToAddress(txData.GasToken) == (common.Address{}) {
+		gas += nativeRefundGas
+	} else {
+		gas += tokenRefundGas
+	}
+	return gas, nil
+}
@@ -0,0 +1,109 @@
+package safetx_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+func TestParseGasOptions(t *testing.T) {
+	options, err := safetx.ParseGasOptions("", "", "")
+	if err != nil || options.Refunded() {
+		t.Fatalf("empty gas options: %+v, %v", options, err)
+	}
+	options, err = safetx.ParseGasOptions("0x10", "0x000000000000000000000000000000000000dEaD", "")
+	if err != nil || !options.Refunded() || options.GasPrice.Int64() != 16 || options.GasToken != common.HexToAddress("0xdead") {
+		t.Fatalf("unexpected gas options: %+v, %v", options, err)
+	}
+	for _, invalid := range [][3]string{{"-1", "", ""}, {"gwei", "", ""}, {"1", "token", ""}, {"1", "", "receiver"}, {"0", "0x000000000000000000000000000000000000dEaD", ""}} {
+		if _, err := safetx.ParseGasOptions(invalid[0], invalid[1], invalid[2]); err == nil {
+			t.Fatalf("ParseGasOptions(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestRefundedExecution(t *testing.T) {
+	chain := simtest.New(t, 4)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, 1)
+	fundSafe(t, chain, safeAddress)
+
+	client, err := ethclient.Dial(chain.Endpoint)
+	if err != nil {
+		t.Fatalf("could not connect to chain: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	// Calls which fail cannot be estimated.
+	changeThreshold := safeCalldata(t, "changeThreshold", big.NewInt(5))
+	if _, err := safetx.EstimateSafeTxGas(ctx, client, safeAddress, safetx.NewTransactionData(safeAddress, changeThreshold, big.NewInt(0), safetx.Call)); err == nil || !strings.Contains(err.Error(), "GS201") {
+		t.Fatalf("expected the estimation to fail with GS201, got %v", err)
+	}
+
+	// Without a gas price, nothing is estimated.
+	recipient, receiver := chain.Address(2), chain.Address(3)
+	txData := safetx.NewTransactionData(recipient, nil, big.NewInt(1000), safetx.Call)
+	if err := safetx.ApplyGasOptions(ctx, client, safeAddress, &txData, safetx.GasOptions{}); err != nil || txData.SafeTxGas != 0 || txData.BaseGas != 0 {
+		t.Fatalf("unexpected gas without refund: %+v, %v", txData, err)
+	}
+
+	gasPrice := big.NewInt(1e9)
+	if err := safetx.ApplyGasOptions(ctx, client, safeAddress, &txData, safetx.GasOptions{GasPrice: gasPrice, RefundReceiver: receiver}); err != nil {
+		t.Fatalf("could not apply gas options: %v", err)
+	}
+	if txData.SafeTxGas == 0 || txData.BaseGas <= 21000 || txData.GasPrice != gasPrice.String() || common.HexToAddress(txData.RefundReceiver) != receiver {
+		t.Fatalf("unexpected refund parameters: %+v", txData)
+	}
+
+	// The owner executes the transaction with a pre-validated signature.
+	safe, err := Safe.NewSafe(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not bind Safe: %v", err)
+	}
+	signature := append(common.LeftPadBytes(chain.Address(1).Bytes(), 32), make([]byte, 32)...)
+	opts := chain.TransactOpts(t, 1)
+	opts.GasPrice = big.NewInt(10e9)
+	before, err := client.BalanceAt(ctx, receiver, nil)
+	if err != nil {
+		t.Fatalf("could not get balance: %v", err)
+	}
+	tx, err := safe.ExecTransaction(opts, recipient, big.NewInt(1000), nil, uint8(safetx.Call), new(big.Int).SetUint64(txData.SafeTxGas), new(big.Int).SetUint64(txData.BaseGas), gasPrice, common.Address{}, receiver, append(signature, 1))
+	if err != nil {
+		t.Fatalf("could not execute transaction: %v", err)
+	}
+	receipt := chain.Receipt(t, tx.Hash())
+
+	filterer, err := Safe.NewSafeFilterer(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not create filterer: %v", err)
+	}
+	var payment *big.Int
+	for _, log := range simtest.Logs(receipt, safeAddress) {
+		if event, err := filterer.ParseExecutionSuccess(log); err == nil {
+			payment = event.Payment
+		}
+	}
+	if payment == nil {
+		t.Fatalf("the transaction was not executed successfully")
+	}
+	after, err := client.BalanceAt(ctx, receiver, nil)
+	if err != nil {
+		t.Fatalf("could not get balance: %v", err)
+	}
+	if new(big.Int).Sub(after, before).Cmp(payment) != 0 {
+		t.Fatalf("the refund receiver was paid %s, the Safe reported %s", new(big.Int).Sub(after, before), payment)
+	}
+	// baseGas covers the gas of the execution which the Safe does not measure.
+	if cost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice); payment.Cmp(cost) < 0 {
+		t.Fatalf("the refund %s does not cover the gas of the execution (%d gas, %s)", payment, receipt.GasUsed, cost)
+	}
+}
@@ -0,0 +1,228 @@
+package safetx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+// PreparedMessage is a message of a Safe ready to be signed by an owner of the Safe.
+type PreparedMessage struct {
+	// Version is the version of the Safe.
+	Version Version
+	// TypedData is the EIP-712 SafeMessage typed data which owners sign.
+	TypedData apitypes.TypedData
+	// SafeMessageHash is the hash of TypedData, which owner signatures sign and which SignMessageLib
+	// marks as signed in the Safe's signedMessages.
+	SafeMessageHash common.Hash
+}
+
+// Returns the EIP-712 SafeMessage typed data for a message of a Safe of the given version. The message
+// is the data which is passed to the bytes variant of isValidSignature. For a hash passed to the bytes32
+// variant, it is the hash itself. Safes before 1.3.0 do not include the chain ID in their domain.
+func MessageTypedDataForVersion(safeAddress common.Address, message []byte, chainID *big.Int, version Version) apitypes.TypedData {
+	domainType := []apitypes.Type{
+		{Name: "verifyingContract", Type: "address"},
+	}
+	domain := apitypes.TypedDataDomain{
+		VerifyingContract: safeAddress.Hex(),
+	}
+	if version.DomainHasChainID() {
+		domainType = append([]apitypes.Type{{Name: "chainId", Type: "uint256"}}, domainType...)
+		domain.ChainId = (*math.HexOrDecimal256)(chainID)
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainType,
+			"SafeMessage": []apitypes.Type{
+				{Name: "message", Type: "bytes"},
+			},
+		},
+		Domain:      domain,
+		PrimaryType: "SafeMessage",
+		Message: apitypes.TypedDataMessage{
+			"message": hexutil.Encode(message),
+		},
+	}
+}
+
+// Prepares a message of a Safe for signing: detects the version of the Safe, builds the SafeMessage
+// typed data for that version, and checks that its domain matches the domain separator of the Safe.
+func PrepareMessage(ctx context.Context, caller bind.ContractCaller, safeAddress common.Address, message []byte, chainID *big.Int) (*PreparedMessage, error) {
+	version, err := DetectVersion(ctx, caller, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	typedData := MessageTypedDataForVersion(safeAddress, message, chainID, version)
+	safeMessageHash, err := signer.TypedDataHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate SafeMessage hash: %v", err)
+	}
+
+	domainHash, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash EIP-712 domain: %v", err)
+	}
+	contract, err := boundSafe(caller, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	var results []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &results, "domainSeparator"); err != nil {
+		return nil, fmt.Errorf("failed to get domain separator of Safe %s: %v", safeAddress.Hex(), err)
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("unexpected domainSeparator result from Safe %s", safeAddress.Hex())
+	}
+	domainSeparator, ok := results[0].([32]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected domainSeparator result from Safe %s", safeAddress.Hex())
+	}
+	if common.Hash(domainSeparator) != common.BytesToHash(domainHash) {
+		return nil, fmt.Errorf("EIP-712 domain calculated for Safe version %s (%s) does not match the domain separator of the Safe (%s)", version, common.BytesToHash(domainHash).Hex(), common.Hash(domainSeparator).Hex())
+	}
+
+	return &PreparedMessage{Version: version, TypedData: typedData, SafeMessageHash: safeMessageHash}, nil
+}
+
+// OwnerSignature is the signature of a single owner of a Safe.
+type OwnerSignature struct {
+	Owner     common.Address
+	Signature []byte
+}
+
+// Splits signatures, packed as the Safe expects them, into 65 byte signatures.
+func SplitSignatures(packed []byte) ([][]byte, error) {
+	if len(packed)%65 != 0 {
+		return nil, fmt.Errorf("invalid signatures: %d bytes is not a multiple of 65", len(packed))
+	}
+	signatures := make([][]byte, len(packed)/65)
+	for i := range signatures {
+		signatures[i] = packed[i*65 : (i+1)*65]
+	}
+	return signatures, nil
+}
+
+// Recovers the signer of an ECDSA signature of hash, in [R || S || V] format with V being 27 or 28.
+func RecoverSigner(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != 65 || (signature[64] != 27 && signature[64] != 28) {
+		return common.Address{}, fmt.Errorf("invalid ECDSA signature %s", hexutil.Encode(signature))
+	}
+	normalized := common.CopyBytes(signature)
+	normalized[64] -= 27
+	publicKey, err := crypto.SigToPub(hash.Bytes(), normalized)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer of %s: %v", hexutil.Encode(signature), err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// Sorts owner signatures by owner address, which is the order in which the Safe expects them.
+func SortSignatures(signatures []OwnerSignature) {
+	sort.Slice(signatures, func(i, j int) bool {
+		return bytes.Compare(signatures[i].Owner.Bytes(), signatures[j].Owner.Bytes()) < 0
+	})
+}
+
+// Packs owner signatures as the Safe expects them: ordered by owner address and concatenated.
+func PackSignatures(signatures []OwnerSignature) []byte {
+	sorted := append([]OwnerSignature(nil), signatures...)
+	SortSignatures(sorted)
+	var packed []byte
+	for _, signature := range sorted {
+		packed = append(packed, signature.Signature...)
+	}
+	return packed
+}
+
+// Types of the signatures which the Safe accepts, by their v value.
+const (
+	// ContractSignature (v = 0) is an EIP-1271 signature of an owner which is a contract.
+	ContractSignature = "contract"
+	// ApprovedHashSignature (v = 1) refers to a hash which the owner approved with approveHash.
+	ApprovedHashSignature = "approvedHash"
+	// EthSignSignature (v > 30) is an ECDSA signature of the hash as an EIP-191 message.
+	EthSignSignature = "ethSign"
+	// ECDSASignature (v = 27 or 28) is an ECDSA signature of the hash.
+	ECDSASignature = "ecdsa"
+)
+
+// DecodedSignature is a single owner signature decoded from the packed signatures of a Safe.
+type DecodedSignature struct {
+	Type string
+	// Owner is the owner which the signature claims (contract and approved hash signatures) or
+	// recovers to (ECDSA and eth_sign signatures).
+	Owner     common.Address
+	Signature []byte
+	// ContractSignature is the signature which is passed to the owner's isValidSignature, for
+	// contract signatures.
+	ContractSignature []byte
+}
+
+// Decodes packed signatures of hash as the Safe's checkNSignatures does, without checking the owners.
+// The 65 byte signatures are followed by the dynamic data of contract signatures, whose offsets are
+// stored in their s value.
+func DecodeSignatures(hash common.Hash, packed []byte) ([]DecodedSignature, error) {
+	var decoded []DecodedSignature
+	staticEnd := len(packed)
+	for offset := 0; offset+65 <= staticEnd; offset += 65 {
+		index := len(decoded) + 1
+		signature := packed[offset : offset+65]
+		r, s, v := signature[:32], new(big.Int).SetBytes(signature[32:64]), signature[64]
+		entry := DecodedSignature{Signature: signature}
+		switch {
+		case v == 0:
+			entry.Type = ContractSignature
+			entry.Owner = common.BytesToAddress(r)
+			if !s.IsUint64() || s.Uint64() < uint64(offset+65) || s.Uint64()+32 > uint64(len(packed)) {
+				return nil, fmt.Errorf("signature %d: contract signature data at offset %s is out of bounds", index, s.String())
+			}
+			start := int(s.Uint64())
+			length := new(big.Int).SetBytes(packed[start : start+32])
+			if !length.IsUint64() || length.Uint64() > uint64(len(packed)-start-32) {
+				return nil, fmt.Errorf("signature %d: contract signature data is out of bounds", index)
+			}
+			entry.ContractSignature = packed[start+32 : start+32+int(length.Uint64())]
+			if start < staticEnd {
+				staticEnd = start
+			}
+		case v == 1:
+			entry.Type = ApprovedHashSignature
+			entry.Owner = common.BytesToAddress(r)
+		case v > 30:
+			entry.Type = EthSignSignature
+			normalized := common.CopyBytes(signature)
+			normalized[64] -= 4
+			owner, err := RecoverSigner(crypto.Keccak256Hash([]byte("\x19Ethereum Signed Message:\n32"), hash.Bytes()), normalized)
+			if err != nil {
+				return nil, fmt.Errorf("signature %d: %v", index, err)
+			}
+			entry.Owner = owner
+		default:
+			entry.Type = ECDSASignature
+			owner, err := RecoverSigner(hash, signature)
+			if err != nil {
+				return nil, fmt.Errorf("signature %d: %v", index, err)
+			}
+			entry.Owner = owner
+		}
+		decoded = append(decoded, entry)
+	}
+	if len(decoded) == 0 && len(packed) > 0 {
+		return nil, fmt.Errorf("invalid signatures: %d bytes is shorter than a signature", len(packed))
+	}
+	return decoded, nil
+}
@@ -0,0 +1,211 @@
+package safetx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/output"
+)
+
+// Policy decides which dangerous Safe transactions are proposed. A dangerous transaction is one which
+// delegatecalls a contract which is not a known Safe library, lowers the threshold of the Safe to 1,
+// removes the proposer from the owners, or sets a guard or fallback handler without code.
+type Policy struct {
+	// Dangerous transactions are only proposed (with warnings) if Override is set.
+	Override bool
+	// Contracts which may be delegatecalled in addition to the Safe libraries in the chain registry.
+	AllowedDelegateCalls []common.Address
+}
+
+var currentPolicy Policy
+
+// The methods with which a Safe administers itself which the policy checks.
+const policyABIJSON = `[
+	{"inputs":[{"name":"owner","type":"address"},{"name":"_threshold","type":"uint256"}],"name":"addOwnerWithThreshold","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"prevOwner","type":"address"},{"name":"owner","type":"address"},{"name":"_threshold","type":"uint256"}],"name":"removeOwner","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"prevOwner","type":"address"},{"name":"oldOwner","type":"address"},{"name":"newOwner","type":"address"}],"name":"swapOwner","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"_threshold","type":"uint256"}],"name":"changeThreshold","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"guard","type":"address"}],"name":"setGuard","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"handler","type":"address"}],"name":"setFallbackHandler","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var policyABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(policyABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// Sets the policy of the current invocation (through the root command's --i-know-what-im-doing flag).
+func SetPolicy(policy Policy) {
+	currentPolicy = policy
+}
+
+// Allows Safe transactions of the current invocation to delegatecall the given contract, which the
+// command has checked by other means.
+func AllowDelegateCall(target common.Address) {
+	currentPolicy.AllowedDelegateCalls = append(currentPolicy.AllowedDelegateCalls, target)
+}
+
+// Returns the reasons for which a Safe transaction proposed by proposer is dangerous, if it is.
+func CheckPolicy(ctx context.Context, client *ethclient.Client, safeAddress common.Address, proposer common.Address, txData TransactionData) ([]string, error) {
+	return checkCall(ctx, client, safeAddress, proposer, common.HexToAddress(txData.To), common.FromHex(txData.Data), txData.Operation)
+}
+
+// Checks a single call of a Safe transaction. The transactions of a MultiSend batch which the Safe
+// delegatecalls are checked one by one, as the Safe executes them.
+func checkCall(ctx context.Context, client *ethclient.Client, safeAddress common.Address, proposer common.Address, to common.Address, data []byte, operation OperationType) ([]string, error) {
+	if operation == DelegateCall {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID: %v", err)
+		}
+		known := false
+		for _, allowed := range append(chains.DelegateCallTargets(chainID), currentPolicy.AllowedDelegateCalls...) {
+			if allowed == to {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return []string{fmt.Sprintf("it delegatecalls %s, which is not a known Safe library (MultiSend, MultiSendCallOnly, CreateCall or SignMessageLib), and can take over or destroy the Safe", to.Hex())}, nil
+		}
+		return checkMultiSend(ctx, client, safeAddress, proposer, data)
+	}
+	if to != safeAddress {
+		return nil, nil
+	}
+
+	if len(data) < 4 {
+		return nil, nil
+	}
+	method, err := policyABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil
+	}
+
+	var findings []string
+	lowersThreshold := func(threshold *big.Int) error {
+		if threshold.Cmp(big.NewInt(1)) != 0 {
+			return nil
+		}
+		safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
+		if err != nil {
+			return fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+		}
+		current, err := safeInstance.GetThreshold(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return fmt.Errorf("failed to get threshold: %v", err)
+		}
+		if current.Cmp(threshold) > 0 {
+			findings = append(findings, fmt.Sprintf("it lowers the threshold of the Safe from %s to 1, so that any single owner controls the Safe", current.String()))
+		}
+		return nil
+	}
+	removesProposer := func(owner common.Address) {
+		if owner == proposer {
+			findings = append(findings, fmt.Sprintf("it removes the proposer %s from the owners of the Safe", proposer.Hex()))
+		}
+	}
+	setsCodelessContract := func(role string, target common.Address) error {
+		if target == (common.Address{}) {
+			return nil
+		}
+		code, err := client.CodeAt(ctx, target, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get code of %s: %v", target.Hex(), err)
+		}
+		if len(code) == 0 {
+			findings = append(findings, fmt.Sprintf("it sets the %s of the Safe to %s, which has no code", role, target.Hex()))
+		}
+		return nil
+	}
+
+	switch method.Name {
+	case "addOwnerWithThreshold":
+		err = lowersThreshold(args[1].(*big.Int))
+	case "removeOwner":
+		removesProposer(args[1].(common.Address))
+		err = lowersThreshold(args[2].(*big.Int))
+	case "swapOwner":
+		removesProposer(args[1].(common.Address))
+	case "changeThreshold":
+		err = lowersThreshold(args[0].(*big.Int))
+	case "setGuard":
+		// A guard without code makes every transaction revert, which locks the Safe.
+		err = setsCodelessContract("guard", args[0].(common.Address))
+	case "setFallbackHandler":
+		err = setsCodelessContract("fallback handler", args[0].(common.Address))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// Checks the transactions of a MultiSend batch which the Safe delegatecalls. Calldata other than a
+// multiSend call is not checked.
+func checkMultiSend(ctx context.Context, client *ethclient.Client, safeAddress common.Address, proposer common.Address, data []byte) ([]string, error) {
+	multiSend := decodeABI.Methods["multiSend"]
+	if len(data) < 4 || [4]byte(data[:4]) != [4]byte(multiSend.ID) {
+		return nil, nil
+	}
+	args, err := multiSend.Inputs.Unpack(data[4:])
+	if err != nil {
+		return []string{fmt.Sprintf("it calls multiSend with invalid arguments: %v", err)}, nil
+	}
+	transactions, err := unpackMultiSend(args[0].([]byte))
+	if err != nil {
+		return []string{fmt.Sprintf("it calls multiSend with invalid transactions: %v", err)}, nil
+	}
+
+	var findings []string
+	for i, transaction := range transactions {
+		to := transaction.To
+		// MultiSend calls the Safe itself for transactions to the zero address.
+		if to == (common.Address{}) {
+			to = safeAddress
+		}
+		inner, err := checkCall(ctx, client, safeAddress, proposer, to, transaction.Data, transaction.Operation)
+		if err != nil {
+			return nil, err
+		}
+		for _, finding := range inner {
+			findings = append(findings, fmt.Sprintf("transaction %d of its MultiSend batch: %s", i+1, finding))
+		}
+	}
+	return findings, nil
+}
+
+// Checks a Safe transaction against the policy of the current invocation. Dangerous transactions are
+// refused, unless the policy overrides them, in which case warnings are written instead.
+func EnforcePolicy(ctx context.Context, client *ethclient.Client, safeAddress common.Address, proposer common.Address, txData TransactionData) error {
+	findings, err := CheckPolicy(ctx, client, safeAddress, proposer, txData)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	if currentPolicy.Override {
+		for _, finding := range findings {
+			output.Infoln("Warning: the Safe transaction is dangerous:", finding)
+		}
+		return nil
+	}
+	return fmt.Errorf("refusing to propose a dangerous Safe transaction (pass --i-know-what-im-doing to propose it anyway):\n  - %s", strings.Join(findings, "\n  - "))
+}
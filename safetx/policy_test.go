@@ -0,0 +1,94 @@
+package safetx_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	chain := simtest.New(t, 4)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 2, 1, 2)
+	proposer, other, stranger := chain.Address(1), chain.Address(2), chain.Address(3)
+	sentinel := common.HexToAddress("0x1")
+	// The canonical MultiSend of Safe 1.4.1.
+	multiSend := common.HexToAddress("0x38869bf66a61cF6bDB996A6aE40D5853Fd43B526")
+
+	client, err := ethclient.Dial(chain.Endpoint)
+	if err != nil {
+		t.Fatalf("could not connect to chain: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	defer safetx.SetPolicy(safetx.Policy{})
+
+	cases := []struct {
+		name      string
+		to        common.Address
+		data      []byte
+		operation safetx.OperationType
+		findings  []string
+	}{
+		{"transfer", stranger, nil, safetx.Call, nil},
+		{"known library", multiSend, nil, safetx.DelegateCall, nil},
+		{"unknown delegatecall", stranger, nil, safetx.DelegateCall, []string{"not a known Safe library"}},
+		{"threshold kept", safeAddress, safeCalldata(t, "changeThreshold", big.NewInt(2)), safetx.Call, nil},
+		{"threshold lowered", safeAddress, safeCalldata(t, "changeThreshold", big.NewInt(1)), safetx.Call, []string{"from 2 to 1"}},
+		{"owner added", safeAddress, safeCalldata(t, "addOwnerWithThreshold", stranger, big.NewInt(1)), safetx.Call, []string{"from 2 to 1"}},
+		{"other owner removed", safeAddress, safeCalldata(t, "removeOwner", proposer, other, big.NewInt(1)), safetx.Call, []string{"from 2 to 1"}},
+		{"proposer removed", safeAddress, safeCalldata(t, "removeOwner", other, proposer, big.NewInt(1)), safetx.Call, []string{"removes the proposer", "from 2 to 1"}},
+		{"proposer swapped", safeAddress, safeCalldata(t, "swapOwner", other, proposer, stranger), safetx.Call, []string{"removes the proposer"}},
+		{"other owner swapped", safeAddress, safeCalldata(t, "swapOwner", sentinel, other, stranger), safetx.Call, nil},
+		{"guard without code", safeAddress, safeCalldata(t, "setGuard", stranger), safetx.Call, []string{"guard of the Safe"}},
+		{"guard removed", safeAddress, safeCalldata(t, "setGuard", common.Address{}), safetx.Call, nil},
+		{"guard with code", safeAddress, safeCalldata(t, "setGuard", deployment.Factory), safetx.Call, nil},
+		{"fallback handler without code", safeAddress, safeCalldata(t, "setFallbackHandler", stranger), safetx.Call, []string{"fallback handler of the Safe"}},
+		{"batched transfer", multiSend, multiSendCalldata(batchedTransaction{safetx.Call, stranger, 1, nil}), safetx.DelegateCall, nil},
+		{"batched threshold lowered", multiSend, multiSendCalldata(
+			batchedTransaction{safetx.Call, stranger, 1, nil},
+			batchedTransaction{safetx.Call, safeAddress, 0, safeCalldata(t, "changeThreshold", big.NewInt(1))},
+		), safetx.DelegateCall, []string{"transaction 2 of its MultiSend batch: it lowers the threshold of the Safe from 2 to 1"}},
+		{"batched call of the Safe by the zero address", multiSend, multiSendCalldata(batchedTransaction{safetx.Call, common.Address{}, 0, safeCalldata(t, "removeOwner", other, proposer, big.NewInt(1))}), safetx.DelegateCall, []string{"removes the proposer", "from 2 to 1"}},
+		{"batched unknown delegatecall", multiSend, multiSendCalldata(batchedTransaction{safetx.DelegateCall, stranger, 0, nil}), safetx.DelegateCall, []string{"transaction 1 of its MultiSend batch: it delegatecalls"}},
+		{"invalid batch", multiSend, multiSendCalldata(batchedTransaction{safetx.Call, stranger, 0, nil})[:4+32+32+40], safetx.DelegateCall, []string{"invalid"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			txData := safetx.NewTransactionData(c.to, c.data, big.NewInt(0), c.operation)
+			findings, err := safetx.CheckPolicy(ctx, client, safeAddress, proposer, txData)
+			if err != nil {
+				t.Fatalf("could not check policy: %v", err)
+			}
+			if len(findings) != len(c.findings) {
+				t.Fatalf("expected findings %q, got %q", c.findings, findings)
+			}
+			for i, finding := range findings {
+				if !strings.Contains(finding, c.findings[i]) {
+					t.Fatalf("expected findings %q, got %q", c.findings, findings)
+				}
+			}
+		})
+	}
+
+	dangerous := safetx.NewTransactionData(stranger, nil, big.NewInt(0), safetx.DelegateCall)
+	safetx.SetPolicy(safetx.Policy{})
+	if err := safetx.EnforcePolicy(ctx, client, safeAddress, proposer, dangerous); err == nil || !strings.Contains(err.Error(), "--i-know-what-im-doing") {
+		t.Fatalf("expected the dangerous transaction to be refused, got %v", err)
+	}
+	safetx.AllowDelegateCall(stranger)
+	if err := safetx.EnforcePolicy(ctx, client, safeAddress, proposer, dangerous); err != nil {
+		t.Fatalf("an allowed delegatecall was refused: %v", err)
+	}
+	safetx.SetPolicy(safetx.Policy{Override: true})
+	if err := safetx.EnforcePolicy(ctx, client, safeAddress, proposer, dangerous); err != nil {
+		t.Fatalf("an overridden dangerous transaction was refused: %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+// Package safetx builds, hashes, signs and proposes Safe transactions. The generated contract commands
+// use it to turn a contract call into a proposal for the Safe Transaction Service.
+package safetx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/moonstream-to/seer/bindings/CreateCall"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/signer"
+)
+
+// OperationType represents the type of operation for a Safe transaction
+type OperationType uint8
+
+const (
+	Call         OperationType = 0
+	DelegateCall OperationType = 1
+)
+
+// String returns the string representation of the OperationType
+func (o OperationType) String() string {
+	switch o {
+	case Call:
+		return "Call"
+	case DelegateCall:
+		return "DelegateCall"
+	default:
+		return "Unknown"
+	}
+}
+
+// TransactionData represents the data for a Safe transaction
+type TransactionData struct {
+	To             string        `json:"to"`
+	Value          string        `json:"value"`
+	Data           string        `json:"data"`
+	Operation      OperationType `json:"operation"`
+	SafeTxGas      uint64        `json:"safeTxGas"`
+	BaseGas        uint64        `json:"baseGas"`
+	GasPrice       string        `json:"gasPrice"`
+	GasToken       string        `json:"gasToken"`
+	RefundReceiver string        `json:"refundReceiver"`
+	Nonce          uint64        `json:"nonce"`
+	SafeTxHash     string        `json:"safeTxHash"`
+	Sender         string        `json:"sender"`
+	Signature      string        `json:"signature"`
+	Origin         string        `json:"origin"`
+}
+
+const (
+	NativeTokenAddress = "0x0000000000000000000000000000000000000000"
+)
+
+// Proposes a CreateCall performCreate2 deployment of deployBytecode through the Safe at safeAddress. The
+// result includes the address at which the contract will be deployed once the proposal is executed.
+func DeployWithSafe(client *ethclient.Client, txSigner signer.Signer, safeAddress common.Address, factoryAddress common.Address, value *big.Int, safeApi string, deployBytecode []byte, operation OperationType, salt [32]byte, gasOptions GasOptions) (*output.ProposalResult, error) {
+	safeCreateCallTxData, err := PerformCreate2Data(value, deployBytecode, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := Propose(client, txSigner, safeAddress, factoryAddress, safeCreateCallTxData, value, safeApi, operation, gasOptions)
+	if err != nil {
+		return nil, err
+	}
+	proposal.ContractAddress = Create2Address(CreateCallDeployer(safeAddress, factoryAddress, operation), salt, deployBytecode).Hex()
+	return proposal, nil
+}
+
+// Returns the calldata of a CreateCall performCreate2 deployment of deployBytecode.
+func PerformCreate2Data(value *big.Int, deployBytecode []byte, salt [32]byte) ([]byte, error) {
+	abi, err := CreateCall.CreateCallMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ABI: %v", err)
+	}
+
+	data, err := abi.Pack("performCreate2", value, deployBytecode, salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack performCreate2 transaction: %v", err)
+	}
+	return data, nil
+}
+
+// Proposes a CreateCall performCreate deployment of deployBytecode through the Safe at safeAddress. The
+// result includes the address at which the contract will be deployed if the proposal is executed before
+// its deployer (see CreateCallDeployer) creates any other contract.
+func DeployWithSafeCreate(client *ethclient.Client, txSigner signer.Signer, safeAddress common.Address, factoryAddress common.Address, value *big.Int, safeApi string, deployBytecode []byte, operation OperationType, gasOptions GasOptions) (*output.ProposalResult, error) {
+	abi, err := CreateCall.CreateCallMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ABI: %v", err)
+	}
+
+	safeCreateCallTxData, err := abi.Pack("performCreate", value, deployBytecode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack performCreate transaction: %v", err)
+	}
+
+	// Contracts create contracts at addresses derived from their account nonce.
+	deployer := CreateCallDeployer(safeAddress, factoryAddress, operation)
+	deployerNonce, err := client.NonceAt(context.Background(), deployer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce of %s: %v", deployer.Hex(), err)
+	}
+
+	proposal, err := Propose(client, txSigner, safeAddress, factoryAddress, safeCreateCallTxData, value, safeApi, operation, gasOptions)
+	if err != nil {
+		return nil, err
+	}
+	proposal.ContractAddress = crypto.CreateAddress(deployer, deployerNonce).Hex()
+	return proposal, nil
+}
+
+// Returns the Safe transaction for the given call, without gas refunds and at nonce zero.
+func NewTransactionData(to common.Address, data []byte, value *big.Int, operation OperationType) TransactionData {
+	return TransactionData{
+		To:             to.Hex(),
+		Value:          value.String(),
+		Data:           common.Bytes2Hex(data),
+		Operation:      operation,
+		SafeTxGas:      0,
+		BaseGas:        0,
+		GasPrice:       "0",
+		GasToken:       NativeTokenAddress,
+		RefundReceiver: NativeTokenAddress,
+	}
+}
+
+// Builds a Safe transaction for the given call at the Safe's current nonce, with the refund parameters
+// of gasOptions (see ApplyGasOptions), signs it with txSigner and submits it to the Safe Transaction
+// Service at safeApi.
+func Propose(client *ethclient.Client, txSigner signer.Signer, safeAddress common.Address, to common.Address, data []byte, value *big.Int, safeApi string, operation OperationType, gasOptions GasOptions) (*output.ProposalResult, error) {
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
+
+	// Create a new instance of the GnosisSafe contract
+	safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+	}
+
+	// Fetch the current nonce from the Safe contract
+	nonce, err := safeInstance.Nonce(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
+	}
+
+	safeTransactionData := NewTransactionData(to, data, value, operation)
+	safeTransactionData.Nonce = nonce.Uint64()
+	if err := EnforcePolicy(context.Background(), client, safeAddress, txSigner.Address(), safeTransactionData); err != nil {
+		return nil, err
+	}
+	if err := ApplyGasOptions(context.Background(), client, safeAddress, &safeTransactionData, gasOptions); err != nil {
+		return nil, err
+	}
+
+	// Build the SafeTx typed data for the Safe's version, checked against the Safe's own SafeTxHash
+	prepared, err := PrepareTransaction(context.Background(), client, safeAddress, safeTransactionData, chainID)
+	if err != nil {
+		return nil, err
+	}
+	safeTxHash := prepared.SafeTxHash
+
+	// Show the typed data and have the signer confirm it, then sign it, which signs the SafeTxHash
+	if err := signer.ConfirmTypedData(prepared.TypedData, ReviewDetails(safeTransactionData)...); err != nil {
+		return nil, err
+	}
+	signature, err := txSigner.SignTypedData(prepared.TypedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign SafeTxHash: %v", err)
+	}
+
+	// Convert signature to hex
+	senderSignature := "0x" + common.Bytes2Hex(signature)
+
+	// Prepare the request body
+	requestBody := map[string]interface{}{
+		"to":                      safeTransactionData.To,
+		"value":                   safeTransactionData.Value,
+		"data":                    "0x" + safeTransactionData.Data,
+		"operation":               int(safeTransactionData.Operation),
+		"safeTxGas":               fmt.Sprintf("%d", safeTransactionData.SafeTxGas),
+		"baseGas":                 fmt.Sprintf("%d", safeTransactionData.BaseGas),
+		"gasPrice":                safeTransactionData.GasPrice,
+		"gasToken":                safeTransactionData.GasToken,
+		"refundReceiver":          safeTransactionData.RefundReceiver,
+		"nonce":                   fmt.Sprintf("%d", safeTransactionData.Nonce),
+		"safeTxHash":              safeTxHash.Hex(),
+		"contractTransactionHash": safeTxHash.Hex(),
+		"sender":                  txSigner.Address().Hex(),
+		"signature":               senderSignature,
+		"origin":                  fmt.Sprintf("{\"url\":\"%s\",\"name\":\"TokenSender Deployment\"}", safeApi),
+	}
+
+	// Marshal the request body to JSON
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %v", err)
+	}
+
+	// Send the request to the Safe Transaction Service
+	req, err := http.NewRequest("POST", safeApi, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return &output.ProposalResult{
+		Safe:           safeAddress.Hex(),
+		To:             safeTransactionData.To,
+		Value:          safeTransactionData.Value,
+		Data:           "0x" + safeTransactionData.Data,
+		Operation:      uint8(safeTransactionData.Operation),
+		Nonce:          safeTransactionData.Nonce,
+		SafeTxHash:     safeTxHash.Hex(),
+		SafeTxGas:      safeTransactionData.SafeTxGas,
+		BaseGas:        safeTransactionData.BaseGas,
+		GasPrice:       safeTransactionData.GasPrice,
+		GasToken:       safeTransactionData.GasToken,
+		RefundReceiver: safeTransactionData.RefundReceiver,
+		Sender:         txSigner.Address().Hex(),
+		Signature:      senderSignature,
+		ServiceURL:     safeApi,
+		StatusCode:     resp.StatusCode,
+		Response:       output.ResponseBody(responseBody),
+	}, nil
+}
+
+// Returns the EIP-712 SafeTx typed data for a transaction of a Safe of version 1.3.0 or later.
+func TypedData(safeAddress common.Address, txData TransactionData, chainID *big.Int) apitypes.TypedData {
+	return TypedDataForVersion(safeAddress, txData, chainID, currentHashingVersion)
+}
+
+// Calculates the SafeTxHash of a transaction of a Safe of version 1.3.0 or later.
+func CalculateSafeTxHash(safeAddress common.Address, txData TransactionData, chainID *big.Int) (common.Hash, error) {
+	return signer.TypedDataHash(TypedData(safeAddress, txData, chainID))
+}
@@ -0,0 +1,483 @@
+package safetx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/moonstream-to/seer/bindings/GnosisSafe"
+
+	"github.com/G7DAO/safes/output"
+)
+
+// The Safe methods a simulation calls and the events it decodes: those of the Safe (with the indexed
+// arguments of Safe 1.4.1), of ERC20 tokens and of CreateCall.
+const simulationABIJSON = `[
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"name":"execTransaction","outputs":[{"name":"success","type":"bool"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[],"name":"getOwners","outputs":[{"name":"","type":"address[]"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"getThreshold","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"start","type":"address"},{"name":"pageSize","type":"uint256"}],"name":"getModulesPaginated","outputs":[{"name":"array","type":"address[]"},{"name":"next","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"offset","type":"uint256"},{"name":"length","type":"uint256"}],"name":"getStorageAt","outputs":[{"name":"","type":"bytes"}],"stateMutability":"view","type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"}],"name":"AddedOwner","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"}],"name":"RemovedOwner","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"threshold","type":"uint256"}],"name":"ChangedThreshold","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"module","type":"address"}],"name":"EnabledModule","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"module","type":"address"}],"name":"DisabledModule","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"guard","type":"address"}],"name":"ChangedGuard","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"moduleGuard","type":"address"}],"name":"ChangedModuleGuard","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"handler","type":"address"}],"name":"ChangedFallbackHandler","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"txHash","type":"bytes32"},{"indexed":false,"name":"payment","type":"uint256"}],"name":"ExecutionSuccess","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"txHash","type":"bytes32"},{"indexed":false,"name":"payment","type":"uint256"}],"name":"ExecutionFailure","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"module","type":"address"}],"name":"ExecutionFromModuleSuccess","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"module","type":"address"}],"name":"ExecutionFromModuleFailure","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"SafeReceived","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"msgHash","type":"bytes32"}],"name":"SignMsg","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"approvedHash","type":"bytes32"},{"indexed":true,"name":"owner","type":"address"}],"name":"ApproveHash","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"data","type":"bytes"},{"indexed":false,"name":"operation","type":"uint8"},{"indexed":false,"name":"safeTxGas","type":"uint256"},{"indexed":false,"name":"baseGas","type":"uint256"},{"indexed":false,"name":"gasPrice","type":"uint256"},{"indexed":false,"name":"gasToken","type":"address"},{"indexed":false,"name":"refundReceiver","type":"address"},{"indexed":false,"name":"signatures","type":"bytes"},{"indexed":false,"name":"additionalInfo","type":"bytes"}],"name":"SafeMultiSigTransaction","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"module","type":"address"},{"indexed":false,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"data","type":"bytes"},{"indexed":false,"name":"operation","type":"uint8"}],"name":"SafeModuleTransaction","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Approval","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"newContract","type":"address"}],"name":"ContractCreation","type":"event"}
+]`
+
+var simulationABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(simulationABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+var (
+	// eth_simulateV1 reports transfers of the native currency as ERC20 Transfer logs of this address.
+	nativeTransferAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	transferEventID       = simulationABI.Events["Transfer"].ID
+)
+
+// The number of modules read with each getModulesPaginated call.
+var modulesPageSize = big.NewInt(100)
+
+// The request and response of eth_simulateV1.
+type simulationCall struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Input hexutil.Bytes  `json:"input"`
+}
+
+type simulationOverride struct {
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+type simulationBlock struct {
+	StateOverrides map[common.Address]simulationOverride `json:"stateOverrides,omitempty"`
+	Calls          []simulationCall                      `json:"calls"`
+}
+
+type simulationRequest struct {
+	BlockStateCalls []simulationBlock `json:"blockStateCalls"`
+	TraceTransfers  bool              `json:"traceTransfers"`
+}
+
+type simulatedLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type simulatedCall struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Logs       []simulatedLog `json:"logs"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	Status     hexutil.Uint64 `json:"status"`
+	Error      *struct {
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+type simulatedBlock struct {
+	Calls []simulatedCall `json:"calls"`
+}
+
+// Simulates the Safe transaction which Propose would propose for the given call.
+func SimulateProposal(client *ethclient.Client, safeAddress common.Address, to common.Address, data []byte, value *big.Int, operation OperationType, gasOptions GasOptions) (*output.SimulationResult, error) {
+	txData := NewTransactionData(to, data, value, operation)
+	if err := ApplyGasOptions(context.Background(), client, safeAddress, &txData, gasOptions); err != nil {
+		return nil, err
+	}
+	return Simulate(context.Background(), client, safeAddress, txData)
+}
+
+// Simulates the execution of a Safe transaction at the Safe's current nonce. No signatures are needed:
+// the simulation overrides the Safe's approvedHashes so that as many owners as the threshold requires
+// have approved the transaction, so the Safe checks signatures and calls its guard as it would for a
+// real execution.
+//
+// The transaction is simulated with eth_simulateV1, which reports the logs it emits (including transfers
+// of the native currency), and the Safe's owners, threshold, modules and guard are read before and after
+// it in the same simulated block. Further pages of modules are read in further simulations. Nodes without
+// eth_simulateV1 only report whether the transaction succeeds, through eth_call. The revert reason of a
+// transaction whose call fails is that of the call, made again with eth_call as the Safe would make it:
+// from the Safe, or for a delegate call, with the code of its target at the Safe's address.
+func Simulate(ctx context.Context, client *ethclient.Client, safeAddress common.Address, txData TransactionData) (*output.SimulationResult, error) {
+	safeInstance, err := GnosisSafe.NewGnosisSafe(safeAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GnosisSafe instance: %v", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	nonce, err := safeInstance.Nonce(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce from Safe contract: %v", err)
+	}
+	owners, err := safeInstance.GetOwners(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owners: %v", err)
+	}
+	threshold, err := safeInstance.GetThreshold(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get threshold: %v", err)
+	}
+	if threshold.Sign() == 0 || threshold.Cmp(big.NewInt(int64(len(owners)))) > 0 {
+		return nil, fmt.Errorf("Safe %s has %d owners and a threshold of %s", safeAddress.Hex(), len(owners), threshold.String())
+	}
+
+	txData.Nonce = nonce.Uint64()
+	safeTxHash, err := ContractTransactionHash(ctx, client, safeAddress, txData)
+	if err != nil {
+		return nil, err
+	}
+	execData, approvals, err := approvedExecution(safeTxHash, txData, owners, int(threshold.Int64()))
+	if err != nil {
+		return nil, err
+	}
+	executor := approvals.signers[0]
+	overrides := map[common.Address]simulationOverride{safeAddress: {StateDiff: approvals.stateDiff}}
+
+	result := &output.SimulationResult{
+		Safe:           safeAddress.Hex(),
+		To:             common.HexToAddress(txData.To).Hex(),
+		Value:          txData.Value,
+		Data:           hexutil.Encode(common.FromHex(txData.Data)),
+		Operation:      uint8(txData.Operation),
+		Nonce:          txData.Nonce,
+		SafeTxHash:     safeTxHash.Hex(),
+		Logs:           []output.SimulatedLog{},
+		BalanceChanges: []output.BalanceChange{},
+		SafeChanges:    []output.SafeChange{},
+	}
+
+	views, err := safeViews()
+	if err != nil {
+		return nil, err
+	}
+	var calls []simulationCall
+	for _, view := range views {
+		calls = append(calls, simulationCall{From: executor, To: safeAddress, Input: view.data})
+	}
+	calls = append(calls, simulationCall{From: executor, To: safeAddress, Input: execData})
+	for _, view := range views {
+		calls = append(calls, simulationCall{From: executor, To: safeAddress, Input: view.data})
+	}
+
+	results, err := simulateCalls(ctx, client, overrides, calls)
+	if err != nil {
+		// The node does not support eth_simulateV1, so only the outcome is simulated.
+		gethOverrides := map[common.Address]gethclient.OverrideAccount{safeAddress: {StateDiff: approvals.stateDiff}}
+		returned, err := gethclient.New(client.Client()).CallContract(ctx, ethereum.CallMsg{From: executor, To: &safeAddress, Data: execData}, nil, &gethOverrides)
+		if err != nil {
+			result.Error = revertReason(revertData(err), err.Error())
+		} else {
+			result.Success = len(returned) == 32 && returned[31] == 1
+		}
+	} else {
+		execution := results[len(views)]
+		result.Effects = true
+		result.GasUsed = uint64(execution.GasUsed)
+		result.Success = execution.Status == 1 && len(execution.ReturnData) == 32 && execution.ReturnData[31] == 1
+		if execution.Error != nil {
+			result.Error = revertReason(common.FromHex(execution.Error.Data), execution.Error.Message)
+		}
+		if execution.Status == 1 {
+			result.Logs, result.BalanceChanges = simulatedEffects(execution.Logs)
+			// Views which take more than one call read their further pages with further simulations, before
+			// and after the execution.
+			pageBefore := func(data []byte) simulatedCall {
+				pages, err := simulateCalls(ctx, client, overrides, []simulationCall{{From: executor, To: safeAddress, Input: data}})
+				if err != nil {
+					return simulatedCall{}
+				}
+				return pages[0]
+			}
+			pageAfter := func(data []byte) simulatedCall {
+				pages, err := simulateCalls(ctx, client, overrides, []simulationCall{calls[len(views)], {From: executor, To: safeAddress, Input: data}})
+				if err != nil {
+					return simulatedCall{}
+				}
+				return pages[1]
+			}
+			for i, view := range views {
+				before, after := view.decode(results[i], pageBefore), view.decode(results[len(views)+1+i], pageAfter)
+				if before != nil && after != nil && fmt.Sprint(before) != fmt.Sprint(after) {
+					result.SafeChanges = append(result.SafeChanges, output.SafeChange{Field: view.field, Before: before, After: after})
+				}
+			}
+		}
+	}
+
+	if !result.Success {
+		// With no safeTxGas and no gas price, a failed call reverts the Safe transaction (GS013), and
+		// otherwise it only makes execTransaction return false. Either way, its reason is that of the call.
+		if reason := callRevertReason(ctx, client, safeAddress, executor, txData); reason != "" {
+			result.Error = reason
+		} else if result.Error == "" {
+			result.Error = "the Safe transaction failed"
+		}
+	}
+	return result, nil
+}
+
+// Simulates calls in a single block with eth_simulateV1, and returns their results.
+func simulateCalls(ctx context.Context, client *ethclient.Client, overrides map[common.Address]simulationOverride, calls []simulationCall) ([]simulatedCall, error) {
+	var blocks []simulatedBlock
+	request := simulationRequest{BlockStateCalls: []simulationBlock{{StateOverrides: overrides, Calls: calls}}, TraceTransfers: true}
+	if err := client.Client().CallContext(ctx, &blocks, "eth_simulateV1", request, "latest"); err != nil {
+		return nil, err
+	}
+	if len(blocks) != 1 || len(blocks[0].Calls) != len(calls) {
+		return nil, fmt.Errorf("unexpected eth_simulateV1 response: %d blocks", len(blocks))
+	}
+	return blocks[0].Calls, nil
+}
+
+type approvals struct {
+	signers   []common.Address
+	stateDiff map[common.Hash]common.Hash
+}
+
+// Returns the execTransaction calldata of a transaction signed by the first threshold owners (in address
+// order) with pre-validated signatures, and the storage which records their approvals of safeTxHash.
+func approvedExecution(safeTxHash common.Hash, txData TransactionData, owners []common.Address, threshold int) ([]byte, *approvals, error) {
+	signers := append([]common.Address{}, owners...)
+	sort.Slice(signers, func(i, j int) bool { return bytes.Compare(signers[i].Bytes(), signers[j].Bytes()) < 0 })
+	signers = signers[:threshold]
+
+	result := &approvals{signers: signers, stateDiff: map[common.Hash]common.Hash{}}
+	var signatures []byte
+	for _, signer := range signers {
+		// A pre-validated signature: r is the owner, s is zero and v is one.
+		signatures = append(signatures, common.LeftPadBytes(signer.Bytes(), 32)...)
+		signatures = append(signatures, make([]byte, 32)...)
+		signatures = append(signatures, 1)
+
+		// approvedHashes[signer][safeTxHash] = 1
+		inner := crypto.Keccak256(common.LeftPadBytes(signer.Bytes(), 32), ApprovedHashesStorageSlot.Bytes())
+		result.stateDiff[crypto.Keccak256Hash(safeTxHash.Bytes(), inner)] = common.BigToHash(big.NewInt(1))
+	}
+
+	value, ok := new(big.Int).SetString(txData.Value, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid value: %s", txData.Value)
+	}
+	gasPrice, ok := new(big.Int).SetString(txData.GasPrice, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid gas price: %s", txData.GasPrice)
+	}
+	data, err := simulationABI.Pack("execTransaction",
+		common.HexToAddress(txData.To), value, common.FromHex(txData.Data), uint8(txData.Operation),
+		new(big.Int).SetUint64(txData.SafeTxGas), new(big.Int).SetUint64(txData.BaseGas), gasPrice,
+		common.HexToAddress(txData.GasToken), common.HexToAddress(txData.RefundReceiver), signatures)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack execTransaction call: %v", err)
+	}
+	return data, result, nil
+}
+
+// safeView is a view of the Safe whose changes a simulation reports. Its value is decoded from the result
+// of the call of data, and views which are read in pages make the call of each further page with page.
+type safeView struct {
+	field  string
+	data   []byte
+	decode func(call simulatedCall, page func(data []byte) simulatedCall) interface{}
+}
+
+func safeViews() ([]safeView, error) {
+	unpack := func(method string, call simulatedCall) []interface{} {
+		if call.Status != 1 {
+			return nil
+		}
+		values, err := simulationABI.Unpack(method, call.ReturnData)
+		if err != nil {
+			return nil
+		}
+		return values
+	}
+	addresses := func(values []common.Address) interface{} {
+		hexes := make([]string, len(values))
+		for i, address := range values {
+			hexes[i] = address.Hex()
+		}
+		return hexes
+	}
+
+	views := []safeView{
+		{field: "owners", decode: func(call simulatedCall, _ func([]byte) simulatedCall) interface{} {
+			if values := unpack("getOwners", call); values != nil {
+				return addresses(values[0].([]common.Address))
+			}
+			return nil
+		}},
+		{field: "threshold", decode: func(call simulatedCall, _ func([]byte) simulatedCall) interface{} {
+			if values := unpack("getThreshold", call); values != nil {
+				return values[0].(*big.Int).String()
+			}
+			return nil
+		}},
+		{field: "modules", decode: func(call simulatedCall, page func([]byte) simulatedCall) interface{} {
+			var modules []common.Address
+			for {
+				values := unpack("getModulesPaginated", call)
+				if values == nil {
+					return nil
+				}
+				array, next := values[0].([]common.Address), values[1].(common.Address)
+				modules = append(modules, array...)
+				if next == SentinelAddress || next == (common.Address{}) || len(array) == 0 {
+					return addresses(modules)
+				}
+				data, err := simulationABI.Pack("getModulesPaginated", next, modulesPageSize)
+				if err != nil {
+					return nil
+				}
+				call = page(data)
+			}
+		}},
+		{field: "guard", decode: func(call simulatedCall, _ func([]byte) simulatedCall) interface{} {
+			if values := unpack("getStorageAt", call); values != nil {
+				return common.BytesToAddress(values[0].([]byte)).Hex()
+			}
+			return nil
+		}},
+	}
+
+	var err error
+	if views[0].data, err = simulationABI.Pack("getOwners"); err != nil {
+		return nil, fmt.Errorf("failed to pack getOwners call: %v", err)
+	}
+	if views[1].data, err = simulationABI.Pack("getThreshold"); err != nil {
+		return nil, fmt.Errorf("failed to pack getThreshold call: %v", err)
+	}
+	if views[2].data, err = simulationABI.Pack("getModulesPaginated", SentinelAddress, modulesPageSize); err != nil {
+		return nil, fmt.Errorf("failed to pack getModulesPaginated call: %v", err)
+	}
+	if views[3].data, err = simulationABI.Pack("getStorageAt", GuardStorageSlot.Big(), big.NewInt(1)); err != nil {
+		return nil, fmt.Errorf("failed to pack getStorageAt call: %v", err)
+	}
+	return views, nil
+}
+
+// Decodes the logs of a simulated transaction, and sums the ERC20 and native transfers among them into
+// balance changes.
+func simulatedEffects(logs []simulatedLog) ([]output.SimulatedLog, []output.BalanceChange) {
+	decoded := make([]output.SimulatedLog, len(logs))
+	changes := map[common.Address]map[common.Address]*big.Int{}
+	change := func(token, account common.Address, amount *big.Int) {
+		if changes[token] == nil {
+			changes[token] = map[common.Address]*big.Int{}
+		}
+		if changes[token][account] == nil {
+			changes[token][account] = new(big.Int)
+		}
+		changes[token][account].Add(changes[token][account], amount)
+	}
+
+	for i, log := range logs {
+		// Logs whose arguments cannot be decoded are reported as Unknown, with their raw topics and data.
+		decoded[i] = output.SimulatedLog{Address: log.Address.Hex()}
+		decoded[i].Event, decoded[i].Arguments, _ = DecodeEvent(&simulationABI, types.Log{Address: log.Address, Topics: log.Topics, Data: log.Data})
+		// ERC721 transfers have the same signature, with the token ID as a third topic.
+		if len(log.Topics) == 3 && log.Topics[0] == transferEventID && len(log.Data) == 32 {
+			token := log.Address
+			if token == nativeTransferAddress {
+				token = common.Address{}
+			}
+			amount := new(big.Int).SetBytes(log.Data)
+			change(token, common.BytesToAddress(log.Topics[1].Bytes()), new(big.Int).Neg(amount))
+			change(token, common.BytesToAddress(log.Topics[2].Bytes()), amount)
+		}
+	}
+
+	balanceChanges := []output.BalanceChange{}
+	for token, accounts := range changes {
+		for account, amount := range accounts {
+			if amount.Sign() != 0 {
+				balanceChanges = append(balanceChanges, output.BalanceChange{Account: account.Hex(), Token: token.Hex(), Change: amount.String()})
+			}
+		}
+	}
+	sort.Slice(balanceChanges, func(i, j int) bool {
+		if balanceChanges[i].Token != balanceChanges[j].Token {
+			return balanceChanges[i].Token < balanceChanges[j].Token
+		}
+		return balanceChanges[i].Account < balanceChanges[j].Account
+	})
+	return decoded, balanceChanges
+}
+
+// Returns the revert reason of the call of a Safe transaction, made as the Safe makes it, or an empty
+// string if the call succeeds on its own.
+func callRevertReason(ctx context.Context, client *ethclient.Client, safeAddress, executor common.Address, txData TransactionData) string {
+	to := common.HexToAddress(txData.To)
+	value, _ := new(big.Int).SetString(txData.Value, 10)
+	msg := ethereum.CallMsg{From: safeAddress, To: &to, Value: value, Data: common.FromHex(txData.Data)}
+	overrides := map[common.Address]gethclient.OverrideAccount{}
+	if txData.Operation == DelegateCall {
+		code, err := client.CodeAt(ctx, to, nil)
+		if err != nil {
+			return ""
+		}
+		msg = ethereum.CallMsg{From: executor, To: &safeAddress, Data: msg.Data}
+		overrides[safeAddress] = gethclient.OverrideAccount{Code: code}
+	}
+
+	_, err := gethclient.New(client.Client()).CallContract(ctx, msg, nil, &overrides)
+	if err == nil {
+		return ""
+	}
+	return revertReason(revertData(err), err.Error())
+}
+
+// Returns the data of a reverted call from the error of eth_call.
+func revertData(err error) []byte {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			return common.FromHex(data)
+		}
+	}
+	return nil
+}
+
+// Returns the reason of a revert: its Error(string) message, its raw data, or fallback if it has none.
+func revertReason(data []byte, fallback string) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) > 0 {
+		return hexutil.Encode(data)
+	}
+	return fallback
+}
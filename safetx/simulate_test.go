@@ -0,0 +1,190 @@
+package safetx_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/output"
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// Sends 1 ether to the Safe.
+func fundSafe(t *testing.T, chain *simtest.Chain, safeAddress common.Address) {
+	t.Helper()
+	opts := chain.TransactOpts(t, 0)
+	opts.Value = big.NewInt(1e18)
+	tx, err := bind.NewBoundContract(safeAddress, abi.ABI{}, chain.Client, chain.Client, chain.Client).Transfer(opts)
+	if err != nil {
+		t.Fatalf("could not fund Safe: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+}
+
+// Returns the calldata of a call of a Safe method.
+func safeCalldata(t *testing.T, method string, args ...interface{}) []byte {
+	t.Helper()
+	safeABI, err := Safe.SafeMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("could not get Safe ABI: %v", err)
+	}
+	data, err := safeABI.Pack(method, args...)
+	if err != nil {
+		t.Fatalf("could not pack %s: %v", method, err)
+	}
+	return data
+}
+
+func TestSimulate(t *testing.T) {
+	chain := simtest.New(t, 4)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 2, 1, 2)
+
+	fundSafe(t, chain, safeAddress)
+
+	client, err := ethclient.Dial(chain.Endpoint)
+	if err != nil {
+		t.Fatalf("could not connect to chain: %v", err)
+	}
+	defer client.Close()
+	simulate := func(to common.Address, data []byte, value *big.Int) *output.SimulationResult {
+		t.Helper()
+		result, err := safetx.Simulate(context.Background(), client, safeAddress, safetx.NewTransactionData(to, data, value, safetx.Call))
+		if err != nil {
+			t.Fatalf("could not simulate: %v", err)
+		}
+		if !result.Effects {
+			t.Fatalf("the simulated chain supports eth_simulateV1, but no effects were reported")
+		}
+		return result
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		recipient := chain.Address(3)
+		result := simulate(recipient, nil, big.NewInt(1000))
+		if !result.Success || result.GasUsed == 0 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+		expected := map[string]string{safeAddress.Hex(): "-1000", recipient.Hex(): "1000"}
+		if len(result.BalanceChanges) != len(expected) {
+			t.Fatalf("unexpected balance changes: %+v", result.BalanceChanges)
+		}
+		for _, change := range result.BalanceChanges {
+			if change.Token != safetx.NativeTokenAddress || expected[change.Account] != change.Change {
+				t.Fatalf("unexpected balance change: %+v", change)
+			}
+		}
+		if len(result.SafeChanges) != 0 {
+			t.Fatalf("unexpected Safe changes: %+v", result.SafeChanges)
+		}
+	})
+
+	t.Run("configuration", func(t *testing.T) {
+		newOwner := chain.Address(3)
+		result := simulate(safeAddress, safeCalldata(t, "addOwnerWithThreshold", newOwner, big.NewInt(3)), big.NewInt(0))
+		if !result.Success {
+			t.Fatalf("unexpected failure: %s", result.Error)
+		}
+		var events []string
+		for _, log := range result.Logs {
+			events = append(events, log.Event)
+			if log.Event == "AddedOwner" && log.Arguments["owner"] != newOwner.Hex() {
+				t.Fatalf("unexpected AddedOwner arguments: %v", log.Arguments)
+			}
+		}
+		if strings.Join(events, ",") != "AddedOwner,ChangedThreshold,ExecutionSuccess" {
+			t.Fatalf("unexpected events: %v", events)
+		}
+		if len(result.SafeChanges) != 2 || result.SafeChanges[0].Field != "owners" || result.SafeChanges[1].Field != "threshold" {
+			t.Fatalf("unexpected Safe changes: %+v", result.SafeChanges)
+		}
+		if owners := result.SafeChanges[0].After.([]string); len(owners) != 3 || owners[0] != newOwner.Hex() {
+			t.Fatalf("unexpected owners: %v", owners)
+		}
+		if result.SafeChanges[1].Before != "2" || result.SafeChanges[1].After != "3" {
+			t.Fatalf("unexpected threshold change: %+v", result.SafeChanges[1])
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		result := simulate(safeAddress, safeCalldata(t, "changeThreshold", big.NewInt(5)), big.NewInt(0))
+		if result.Success || !strings.Contains(result.Error, "GS201") {
+			t.Fatalf("expected a GS201 failure, got %+v", result)
+		}
+	})
+
+	nonce, err := client.NonceAt(context.Background(), chain.Address(1), nil)
+	if err != nil || nonce != 0 {
+		t.Fatalf("simulations sent transactions: nonce %d, %v", nonce, err)
+	}
+}
+
+func TestSimulateModulePages(t *testing.T) {
+	chain := simtest.New(t, 2)
+	deployment := chain.Deploy(t)
+
+	// Code which the Safe delegatecalls during its setup to enable more modules than fit in a page, by
+	// writing the linked list of modules at slot 1 of its storage.
+	modules := make([]common.Address, 101)
+	for i := range modules {
+		modules[i] = common.BigToAddress(big.NewInt(int64(0x1000 + i)))
+	}
+	var code []byte
+	store := func(key, value common.Address) {
+		slot := crypto.Keccak256(common.LeftPadBytes(key.Bytes(), 32), common.LeftPadBytes([]byte{1}, 32))
+		code = append(code, 0x7f)
+		code = append(code, common.LeftPadBytes(value.Bytes(), 32)...)
+		code = append(code, 0x7f)
+		code = append(code, slot...)
+		code = append(code, 0x55)
+	}
+	previous := safetx.SentinelAddress
+	for _, module := range modules {
+		store(previous, module)
+		previous = module
+	}
+	store(previous, safetx.SentinelAddress)
+	code = append(code, 0x00)
+	enabler := chain.DeployCode(t, code)
+
+	safeAddress := chain.DeployProxy(t, deployment.Safe)
+	safe, err := Safe.NewSafe(safeAddress, chain.Client)
+	if err != nil {
+		t.Fatalf("could not bind Safe: %v", err)
+	}
+	tx, err := safe.Setup(chain.TransactOpts(t, 0), []common.Address{chain.Address(1)}, big.NewInt(1), enabler, []byte{}, common.Address{}, common.Address{}, big.NewInt(0), common.Address{})
+	if err != nil {
+		t.Fatalf("could not set up Safe: %v", err)
+	}
+	chain.Receipt(t, tx.Hash())
+
+	client, err := ethclient.Dial(chain.Endpoint)
+	if err != nil {
+		t.Fatalf("could not connect to chain: %v", err)
+	}
+	defer client.Close()
+	module := chain.Address(0)
+	result, err := safetx.Simulate(context.Background(), client, safeAddress, safetx.NewTransactionData(safeAddress, safeCalldata(t, "enableModule", module), big.NewInt(0), safetx.Call))
+	if err != nil {
+		t.Fatalf("could not simulate: %v", err)
+	}
+	if !result.Success || len(result.SafeChanges) != 1 || result.SafeChanges[0].Field != "modules" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	before, after := result.SafeChanges[0].Before.([]string), result.SafeChanges[0].After.([]string)
+	if len(before) != len(modules) || before[len(modules)-1] != modules[len(modules)-1].Hex() {
+		t.Fatalf("read %d modules before the transaction, expected %d", len(before), len(modules))
+	}
+	if len(after) != len(modules)+1 || after[0] != module.Hex() || after[len(modules)] != modules[len(modules)-1].Hex() {
+		t.Fatalf("read %d modules after the transaction, expected %d", len(after), len(modules)+1)
+	}
+}
@@ -0,0 +1,24 @@
+package safetx
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Storage slots of a Safe proxy. The singleton is stored at slot 0 of every Safe, and the slots of the
+// owner count, threshold, nonce and approved hashes are the same in every Safe from 1.0.0 on. The
+// fallback handler and guard are stored at the hashes of their names.
+var (
+	SingletonStorageSlot       = common.BigToHash(big.NewInt(0))
+	OwnerCountStorageSlot      = common.BigToHash(big.NewInt(3))
+	ThresholdStorageSlot       = common.BigToHash(big.NewInt(4))
+	NonceStorageSlot           = common.BigToHash(big.NewInt(5))
+	ApprovedHashesStorageSlot  = common.BigToHash(big.NewInt(8))
+	FallbackHandlerStorageSlot = crypto.Keccak256Hash([]byte("fallback_manager.handler.address"))
+	GuardStorageSlot           = crypto.Keccak256Hash([]byte("guard_manager.guard.address"))
+)
+
+// SentinelAddress starts and ends the linked lists of owners and modules in a Safe.
+var SentinelAddress = common.HexToAddress("0x0000000000000000000000000000000000000001")
@@ -0,0 +1,247 @@
+package safetx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+// Version is the version of a Safe singleton, as reported by its VERSION() method.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// The version whose SafeTx hashing TypedData implements. All later versions hash in the same way.
+var currentHashingVersion = Version{Major: 1, Minor: 3, Patch: 0}
+
+// Parses a Safe version string such as "1.3.0" or "1.3.0+L2".
+func ParseVersion(version string) (Version, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	core, _, _ = strings.Cut(core, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid Safe version: %q", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Version{}, fmt.Errorf("invalid Safe version: %q", version)
+		}
+		numbers[i] = number
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Returns true if v is the given version or a later one.
+func (v Version) AtLeast(major, minor, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// Returns true if the Safe's EIP-712 domain includes the chain ID, which is the case from Safe 1.3.0.
+func (v Version) DomainHasChainID() bool {
+	return v.AtLeast(1, 3, 0)
+}
+
+// Returns true if the SafeTx type calls its baseGas field dataGas, which is the case before Safe 1.0.0.
+func (v Version) UsesDataGas() bool {
+	return !v.AtLeast(1, 0, 0)
+}
+
+// The methods of the Safe which are used to detect its version and to cross-check SafeTx and
+// SafeMessage hashes. They have the same signatures in every Safe version.
+const safeVersionABI = `[
+	{"inputs":[],"name":"VERSION","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"domainSeparator","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"uint8","name":"operation","type":"uint8"},{"internalType":"uint256","name":"safeTxGas","type":"uint256"},{"internalType":"uint256","name":"baseGas","type":"uint256"},{"internalType":"uint256","name":"gasPrice","type":"uint256"},{"internalType":"address","name":"gasToken","type":"address"},{"internalType":"address","name":"refundReceiver","type":"address"},{"internalType":"uint256","name":"_nonce","type":"uint256"}],"name":"getTransactionHash","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"}
+]`
+
+func boundSafe(caller bind.ContractCaller, safeAddress common.Address) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(safeVersionABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Safe ABI: %v", err)
+	}
+	return bind.NewBoundContract(safeAddress, parsed, caller, nil, nil), nil
+}
+
+// Detects the version of the Safe at safeAddress by calling its VERSION() method.
+func DetectVersion(ctx context.Context, caller bind.ContractCaller, safeAddress common.Address) (Version, error) {
+	contract, err := boundSafe(caller, safeAddress)
+	if err != nil {
+		return Version{}, err
+	}
+
+	var results []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &results, "VERSION"); err != nil {
+		return Version{}, fmt.Errorf("failed to get version of Safe %s: %v", safeAddress.Hex(), err)
+	}
+	if len(results) != 1 {
+		return Version{}, fmt.Errorf("unexpected VERSION() result from Safe %s", safeAddress.Hex())
+	}
+	versionString, ok := results[0].(string)
+	if !ok {
+		return Version{}, fmt.Errorf("unexpected VERSION() result from Safe %s", safeAddress.Hex())
+	}
+	return ParseVersion(versionString)
+}
+
+// Returns the SafeTxHash of a Safe transaction as calculated by the Safe itself, with its
+// getTransactionHash method.
+func ContractTransactionHash(ctx context.Context, caller bind.ContractCaller, safeAddress common.Address, txData TransactionData) (common.Hash, error) {
+	contract, err := boundSafe(caller, safeAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	value, ok := new(big.Int).SetString(txData.Value, 0)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("invalid value: %s", txData.Value)
+	}
+	gasPrice, ok := new(big.Int).SetString(txData.GasPrice, 0)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("invalid gas price: %s", txData.GasPrice)
+	}
+
+	var results []interface{}
+	err = contract.Call(&bind.CallOpts{Context: ctx}, &results, "getTransactionHash",
+		common.HexToAddress(txData.To),
+		value,
+		common.FromHex(txData.Data),
+		uint8(txData.Operation),
+		new(big.Int).SetUint64(txData.SafeTxGas),
+		new(big.Int).SetUint64(txData.BaseGas),
+		gasPrice,
+		common.HexToAddress(txData.GasToken),
+		common.HexToAddress(txData.RefundReceiver),
+		new(big.Int).SetUint64(txData.Nonce),
+	)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get transaction hash from Safe %s: %v", safeAddress.Hex(), err)
+	}
+	if len(results) != 1 {
+		return common.Hash{}, fmt.Errorf("unexpected getTransactionHash result from Safe %s", safeAddress.Hex())
+	}
+	hash, ok := results[0].([32]byte)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("unexpected getTransactionHash result from Safe %s", safeAddress.Hex())
+	}
+	return common.Hash(hash), nil
+}
+
+// PreparedTransaction is a Safe transaction ready to be signed by an owner of the Safe.
+type PreparedTransaction struct {
+	// Version is the version of the Safe.
+	Version Version
+	// TypedData is the EIP-712 typed data which owners sign, in the form the Safe's version expects.
+	TypedData apitypes.TypedData
+	// SafeTxHash is the hash of TypedData, which the Safe has confirmed with getTransactionHash.
+	SafeTxHash common.Hash
+}
+
+// Prepares a Safe transaction for signing: detects the version of the Safe, builds the typed data for
+// that version, and checks that its hash matches the hash which the Safe itself calculates. Signing
+// typed data whose hash the Safe does not agree with would produce a useless (or, worse, misleading)
+// signature.
+func PrepareTransaction(ctx context.Context, caller bind.ContractCaller, safeAddress common.Address, txData TransactionData, chainID *big.Int) (*PreparedTransaction, error) {
+	version, err := DetectVersion(ctx, caller, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	typedData := TypedDataForVersion(safeAddress, txData, chainID, version)
+	safeTxHash, err := signer.TypedDataHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate SafeTxHash: %v", err)
+	}
+
+	contractHash, err := ContractTransactionHash(ctx, caller, safeAddress, txData)
+	if err != nil {
+		return nil, err
+	}
+	if contractHash != safeTxHash {
+		return nil, fmt.Errorf("SafeTxHash %s calculated for Safe version %s does not match the hash calculated by the Safe (%s)", safeTxHash.Hex(), version, contractHash.Hex())
+	}
+
+	return &PreparedTransaction{Version: version, TypedData: typedData, SafeTxHash: safeTxHash}, nil
+}
+
+// Returns the EIP-712 SafeTx typed data for a transaction of a Safe of the given version. Safes before
+// 1.3.0 do not include the chain ID in their domain, and Safes before 1.0.0 call the baseGas field
+// dataGas.
+func TypedDataForVersion(safeAddress common.Address, txData TransactionData, chainID *big.Int, version Version) apitypes.TypedData {
+	domainType := []apitypes.Type{
+		{Name: "verifyingContract", Type: "address"},
+	}
+	domain := apitypes.TypedDataDomain{
+		VerifyingContract: safeAddress.Hex(),
+	}
+	if version.DomainHasChainID() {
+		domainType = append([]apitypes.Type{{Name: "chainId", Type: "uint256"}}, domainType...)
+		domain.ChainId = (*math.HexOrDecimal256)(chainID)
+	}
+
+	baseGasField := "baseGas"
+	if version.UsesDataGas() {
+		baseGasField = "dataGas"
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainType,
+			"SafeTx": []apitypes.Type{
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: baseGasField, Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		Domain:      domain,
+		PrimaryType: "SafeTx",
+		Message: apitypes.TypedDataMessage{
+			"to":             txData.To,
+			"value":          txData.Value,
+			"data":           "0x" + txData.Data,
+			"operation":      fmt.Sprintf("%d", txData.Operation),
+			"safeTxGas":      fmt.Sprintf("%d", txData.SafeTxGas),
+			baseGasField:     fmt.Sprintf("%d", txData.BaseGas),
+			"gasPrice":       txData.GasPrice,
+			"gasToken":       txData.GasToken,
+			"refundReceiver": txData.RefundReceiver,
+			"nonce":          fmt.Sprintf("%d", txData.Nonce),
+		},
+	}
+}
+
+// Calculates the SafeTxHash of a transaction of a Safe of the given version.
+func CalculateSafeTxHashForVersion(safeAddress common.Address, txData TransactionData, chainID *big.Int, version Version) (common.Hash, error) {
+	return signer.TypedDataHash(TypedDataForVersion(safeAddress, txData, chainID, version))
+}
@@ -0,0 +1,206 @@
+package safetx_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/simtest"
+)
+
+// Type hashes as they are hard-coded in the Safe contracts.
+var (
+	domainTypeHash        = common.HexToHash("0x035aff83d86937d35b32e04f0ddc6ff469290eef2f1b692d8a815c89404d4749") // EIP712Domain(address verifyingContract)
+	domainChainIDTypeHash = common.HexToHash("0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218") // EIP712Domain(uint256 chainId,address verifyingContract)
+	safeTxTypeHash        = common.HexToHash("0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8") // SafeTx(... uint256 baseGas ...)
+	safeTxDataGasTypeHash = common.HexToHash("0x14d461bc7412367e924637b363c7bf29b8f47e2f84869f4426e5633d8af47b20") // SafeTx(... uint256 dataGas ...)
+)
+
+var testSafe = common.HexToAddress("0x5afe00000000000000000000000000000000cafe")
+
+func testTransaction() safetx.TransactionData {
+	return safetx.TransactionData{
+		To:             "0x000000000000000000000000000000000000dEaD",
+		Value:          "1000",
+		Data:           "694e80c30000000000000000000000000000000000000000000000000000000000000002",
+		Operation:      safetx.Call,
+		SafeTxGas:      50000,
+		BaseGas:        21000,
+		GasPrice:       "7",
+		GasToken:       safetx.NativeTokenAddress,
+		RefundReceiver: "0x000000000000000000000000000000000000bEEF",
+		Nonce:          12,
+	}
+}
+
+// Hashes a SafeTx the way the Safe contracts do, with abi.encode and the hard-coded type hashes.
+func contractStyleHash(t *testing.T, txData safetx.TransactionData, chainID *big.Int, structTypeHash common.Hash, withChainID bool) common.Hash {
+	word := func(value *big.Int) []byte { return common.LeftPadBytes(value.Bytes(), 32) }
+	address := func(hex string) []byte { return common.LeftPadBytes(common.HexToAddress(hex).Bytes(), 32) }
+	number := func(decimal string) []byte {
+		value, ok := new(big.Int).SetString(decimal, 10)
+		if !ok {
+			t.Fatalf("invalid number: %s", decimal)
+		}
+		return word(value)
+	}
+
+	var domainSeparator common.Hash
+	if withChainID {
+		domainSeparator = crypto.Keccak256Hash(domainChainIDTypeHash.Bytes(), word(chainID), address(testSafe.Hex()))
+	} else {
+		domainSeparator = crypto.Keccak256Hash(domainTypeHash.Bytes(), address(testSafe.Hex()))
+	}
+
+	structHash := crypto.Keccak256Hash(
+		structTypeHash.Bytes(),
+		address(txData.To),
+		number(txData.Value),
+		crypto.Keccak256(common.FromHex(txData.Data)),
+		word(big.NewInt(int64(txData.Operation))),
+		word(new(big.Int).SetUint64(txData.SafeTxGas)),
+		word(new(big.Int).SetUint64(txData.BaseGas)),
+		number(txData.GasPrice),
+		address(txData.GasToken),
+		address(txData.RefundReceiver),
+		word(new(big.Int).SetUint64(txData.Nonce)),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash.Bytes())
+}
+
+func TestParseVersion(t *testing.T) {
+	for input, expected := range map[string]safetx.Version{
+		"1.4.1":    {Major: 1, Minor: 4, Patch: 1},
+		"1.3.0+L2": {Major: 1, Minor: 3, Patch: 0},
+		"0.1.0":    {Major: 0, Minor: 1, Patch: 0},
+	} {
+		version, err := safetx.ParseVersion(input)
+		if err != nil || version != expected {
+			t.Fatalf("ParseVersion(%q) = %v, %v", input, version, err)
+		}
+	}
+	for _, input := range []string{"", "1.3", "one.two.three"} {
+		if _, err := safetx.ParseVersion(input); err == nil {
+			t.Fatalf("ParseVersion(%q) succeeded", input)
+		}
+	}
+}
+
+func TestSafeTxHashForVersions(t *testing.T) {
+	chainID := big.NewInt(11155111)
+	txData := testTransaction()
+
+	for _, test := range []struct {
+		version        string
+		structTypeHash common.Hash
+		withChainID    bool
+	}{
+		{"0.1.0", safeTxDataGasTypeHash, false},
+		{"1.0.0", safeTxTypeHash, false},
+		{"1.2.0", safeTxTypeHash, false},
+		{"1.3.0", safeTxTypeHash, true},
+		{"1.4.1", safeTxTypeHash, true},
+	} {
+		version, err := safetx.ParseVersion(test.version)
+		if err != nil {
+			t.Fatalf("could not parse version: %v", err)
+		}
+		hash, err := safetx.CalculateSafeTxHashForVersion(testSafe, txData, chainID, version)
+		if err != nil {
+			t.Fatalf("could not hash SafeTx for version %s: %v", test.version, err)
+		}
+		if expected := contractStyleHash(t, txData, chainID, test.structTypeHash, test.withChainID); hash != expected {
+			t.Fatalf("SafeTxHash for version %s is %s, the Safe calculates %s", test.version, hash.Hex(), expected.Hex())
+		}
+	}
+
+	// TypedData and CalculateSafeTxHash hash as Safes from 1.3.0 on do.
+	hash, err := safetx.CalculateSafeTxHash(testSafe, txData, chainID)
+	if err != nil {
+		t.Fatalf("could not hash SafeTx: %v", err)
+	}
+	if expected := contractStyleHash(t, txData, chainID, safeTxTypeHash, true); hash != expected {
+		t.Fatalf("CalculateSafeTxHash returned %s, the Safe calculates %s", hash.Hex(), expected.Hex())
+	}
+}
+
+// A contract caller which answers VERSION() and getTransactionHash like a Safe of the given version.
+type fakeSafe struct {
+	version         string
+	transactionHash common.Hash
+}
+
+func (s *fakeSafe) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60, 0x80}, nil
+}
+
+func (s *fakeSafe) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if len(call.Data) >= 4 && common.Bytes2Hex(call.Data[:4]) == common.Bytes2Hex(crypto.Keccak256([]byte("VERSION()"))[:4]) {
+		stringType, _ := abi.NewType("string", "", nil)
+		return abi.Arguments{{Type: stringType}}.Pack(s.version)
+	}
+	return s.transactionHash.Bytes(), nil
+}
+
+func TestPrepareTransactionUsesDetectedVersion(t *testing.T) {
+	chainID := big.NewInt(11155111)
+	txData := testTransaction()
+	legacyHash := contractStyleHash(t, txData, chainID, safeTxTypeHash, false)
+
+	prepared, err := safetx.PrepareTransaction(context.Background(), &fakeSafe{version: "1.1.1", transactionHash: legacyHash}, testSafe, txData, chainID)
+	if err != nil {
+		t.Fatalf("could not prepare transaction for a 1.1.1 Safe: %v", err)
+	}
+	if prepared.Version != (safetx.Version{Major: 1, Minor: 1, Patch: 1}) || prepared.SafeTxHash != legacyHash {
+		t.Fatalf("unexpected prepared transaction: version %s, hash %s", prepared.Version, prepared.SafeTxHash.Hex())
+	}
+	if _, ok := prepared.TypedData.Domain.Map()["chainId"]; ok {
+		t.Fatalf("the typed data for a 1.1.1 Safe includes the chain ID")
+	}
+
+	// A Safe which reports a version whose hashing does not match its getTransactionHash is refused.
+	_, err = safetx.PrepareTransaction(context.Background(), &fakeSafe{version: "1.3.0", transactionHash: legacyHash}, testSafe, txData, chainID)
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected a SafeTxHash mismatch, got %v", err)
+	}
+}
+
+func TestPrepareTransactionOnSimulatedSafe(t *testing.T) {
+	chain := simtest.New(t, 2)
+	deployment := chain.Deploy(t)
+
+	for _, singleton := range []common.Address{deployment.Safe, deployment.SafeL2} {
+		safeAddress := chain.SetupSafe(t, singleton, 1, 1)
+		version, err := safetx.DetectVersion(context.Background(), chain.Client, safeAddress)
+		if err != nil {
+			t.Fatalf("could not detect Safe version: %v", err)
+		}
+		if !version.AtLeast(1, 3, 0) {
+			t.Fatalf("unexpected version of the simulated Safe: %s", version)
+		}
+
+		txData := testTransaction()
+		prepared, err := safetx.PrepareTransaction(context.Background(), chain.Client, safeAddress, txData, simtest.ChainID)
+		if err != nil {
+			t.Fatalf("could not prepare transaction: %v", err)
+		}
+		expected, err := safetx.CalculateSafeTxHash(safeAddress, txData, simtest.ChainID)
+		if err != nil {
+			t.Fatalf("could not hash SafeTx: %v", err)
+		}
+		if prepared.SafeTxHash != expected || prepared.Version != version {
+			t.Fatalf("unexpected prepared transaction: version %s, hash %s", prepared.Version, prepared.SafeTxHash.Hex())
+		}
+	}
+
+	if _, err := safetx.DetectVersion(context.Background(), chain.Client, chain.Address(0)); err == nil {
+		t.Fatalf("detected a Safe version for an account without code")
+	}
+}
@@ -0,0 +1,171 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ExternalSigner delegates signing to an external signer process, such as geth's Clef, which speaks the
+// account_* JSON-RPC API (account_list, account_signTypedData and account_signTransaction). Keys never
+// enter the safes process, and the external signer can show the user what they are signing and ask
+// for confirmation. External signers only sign structured data, never raw digests.
+type ExternalSigner struct {
+	client   *rpc.Client
+	endpoint string
+	address  common.Address
+}
+
+// How long to wait for an external signer to respond to the initial account listing. Signing requests
+// have no timeout, since the external signer may be waiting for a human to confirm them.
+const externalSignerDialTimeout = 30 * time.Second
+
+// Creates a signer which signs for the given account using the external signer at endpoint. The endpoint
+// is an HTTP(S) or WebSocket URL, or the path to an IPC socket (e.g. ~/.clef/clef.ipc).
+func NewExternalSigner(endpoint string, address common.Address) (*ExternalSigner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerDialTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, expandHome(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to external signer at %s: %v", endpoint, err)
+	}
+
+	var addresses []common.Address
+	if err := client.CallContext(ctx, &addresses, "account_list"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("could not list accounts of external signer at %s: %v", endpoint, err)
+	}
+
+	for _, managed := range addresses {
+		if managed == address {
+			return &ExternalSigner{client: client, endpoint: endpoint, address: address}, nil
+		}
+	}
+
+	client.Close()
+	return nil, fmt.Errorf("external signer at %s does not manage account %s", endpoint, address.Hex())
+}
+
+func (s *ExternalSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *ExternalSigner) SignDigest(digest common.Hash) ([]byte, error) {
+	return nil, ErrDigestSigningUnsupported
+}
+
+func (s *ExternalSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	signAddress := common.NewMixedcaseAddress(s.address)
+
+	var signature hexutil.Bytes
+	if err := s.client.Call(&signature, "account_signTypedData", &signAddress, typedData); err != nil {
+		return nil, fmt.Errorf("external signer at %s did not sign typed data: %v", s.endpoint, err)
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("external signer at %s returned a signature of %d bytes", s.endpoint, len(signature))
+	}
+
+	// Clef returns V as 27 or 28 already, other signers may return 0 or 1.
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	return signature, nil
+}
+
+func (s *ExternalSigner) TransactOpts(chainID *big.Int) (*bind.TransactOpts, error) {
+	return &bind.TransactOpts{
+		From: s.address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.signTransaction(tx, chainID)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+// The result of account_signTransaction.
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+func (s *ExternalSigner) signTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:  common.NewMixedcaseAddress(s.address),
+		Gas:   hexutil.Uint64(tx.Gas()),
+		Value: hexutil.Big(*tx.Value()),
+		Nonce: hexutil.Uint64(tx.Nonce()),
+		Input: &data,
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	if chainID != nil && chainID.Sign() != 0 {
+		args.ChainID = (*hexutil.Big)(chainID)
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	default:
+		return nil, fmt.Errorf("external signer does not support transactions of type %d", tx.Type())
+	}
+
+	var result signTransactionResult
+	if err := s.client.Call(&result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("external signer at %s did not sign transaction: %v", s.endpoint, err)
+	}
+
+	signedTx := result.Tx
+	if signedTx == nil {
+		if len(result.Raw) == 0 {
+			return nil, errors.New("external signer returned neither a raw nor a decoded transaction")
+		}
+		signedTx = new(types.Transaction)
+		if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+			return nil, fmt.Errorf("could not decode transaction signed by external signer: %v", err)
+		}
+	}
+
+	// Make sure that the external signer signed what we asked it to sign, with the right account.
+	sender, err := types.Sender(types.LatestSignerForChainID(signedTx.ChainId()), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover sender of transaction signed by external signer: %v", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("external signer signed transaction with %s instead of %s", sender.Hex(), s.address.Hex())
+	}
+	// The user may adjust gas parameters in the external signer, but not what the transaction does.
+	if signedTx.Value().Cmp(tx.Value()) != 0 || !bytes.Equal(signedTx.Data(), tx.Data()) || !addressesEqual(signedTx.To(), tx.To()) {
+		return nil, errors.New("transaction signed by external signer does not match the requested transaction")
+	}
+
+	return signedTx, nil
+}
+
+func addressesEqual(a, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,200 @@
+package signer_test
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/G7DAO/safes/signer"
+	"github.com/G7DAO/safes/signer/signertest"
+)
+
+func testTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Delegate": []apitypes.Type{
+				{Name: "delegateAddress", Type: "address"},
+				{Name: "totp", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Delegate",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "Safe Transaction Service",
+			Version: "1.0",
+			ChainId: math.NewHexOrDecimal256(11155111),
+		},
+		Message: apitypes.TypedDataMessage{
+			"delegateAddress": "0x000000000000000000000000000000000000dEaD",
+			"totp":            "480000",
+		},
+	}
+}
+
+func TestExternalSignerSignsTypedDataOverHTTP(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	clef := signertest.NewClefAPI(key)
+	server := clef.StartHTTP()
+	defer server.Close()
+
+	externalSigner, err := signer.NewExternalSigner(server.URL, address)
+	if err != nil {
+		t.Fatalf("could not create external signer: %v", err)
+	}
+	if externalSigner.Address() != address {
+		t.Fatalf("unexpected address: %s", externalSigner.Address().Hex())
+	}
+
+	signature, err := externalSigner.SignTypedData(testTypedData())
+	if err != nil {
+		t.Fatalf("could not sign typed data: %v", err)
+	}
+
+	expected, err := signer.NewKeySigner(key).SignTypedData(testTypedData())
+	if err != nil {
+		t.Fatalf("could not sign typed data locally: %v", err)
+	}
+	if common.Bytes2Hex(signature) != common.Bytes2Hex(expected) {
+		t.Fatalf("external signature %x does not match local signature %x", signature, expected)
+	}
+	if requests := clef.Requests(); len(requests) != 1 || requests[0] != "account_signTypedData" {
+		t.Fatalf("unexpected requests to external signer: %v", requests)
+	}
+}
+
+func TestExternalSignerSignsTypedDataOverIPC(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	clef := signertest.NewClefAPI(key)
+	endpoint := filepath.Join(t.TempDir(), "clef.ipc")
+	listener, err := clef.StartIPC(endpoint)
+	if err != nil {
+		t.Fatalf("could not start IPC endpoint: %v", err)
+	}
+	defer listener.Close()
+
+	externalSigner, err := signer.LoadWithOptions(signer.Options{Spec: "clef:" + endpoint, Address: address.Hex()}, "", "")
+	if err != nil {
+		t.Fatalf("could not load external signer: %v", err)
+	}
+
+	signature, err := externalSigner.SignTypedData(testTypedData())
+	if err != nil {
+		t.Fatalf("could not sign typed data: %v", err)
+	}
+	hash, err := signer.TypedDataHash(testTypedData())
+	if err != nil {
+		t.Fatalf("could not hash typed data: %v", err)
+	}
+	signature[64] -= 27
+	publicKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		t.Fatalf("could not recover public key: %v", err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != address {
+		t.Fatalf("signature was not made by %s", address.Hex())
+	}
+}
+
+func TestExternalSignerRefusesDigests(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	server := signertest.NewClefAPI(key).StartHTTP()
+	defer server.Close()
+
+	externalSigner, err := signer.NewExternalSigner(server.URL, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("could not create external signer: %v", err)
+	}
+	if _, err := externalSigner.SignDigest(common.Hash{1}); !errors.Is(err, signer.ErrDigestSigningUnsupported) {
+		t.Fatalf("expected ErrDigestSigningUnsupported, got %v", err)
+	}
+}
+
+func TestExternalSignerUnknownAccount(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	server := signertest.NewClefAPI(key).StartHTTP()
+	defer server.Close()
+
+	_, err := signer.NewExternalSigner(server.URL, common.HexToAddress("0x000000000000000000000000000000000000dEaD"))
+	if err == nil || !strings.Contains(err.Error(), "does not manage account") {
+		t.Fatalf("expected unknown account error, got %v", err)
+	}
+}
+
+func TestExternalSignerDenied(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	clef := signertest.NewClefAPI(key)
+	server := clef.StartHTTP()
+	defer server.Close()
+
+	externalSigner, err := signer.NewExternalSigner(server.URL, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("could not create external signer: %v", err)
+	}
+
+	clef.Deny(true)
+	if _, err := externalSigner.SignTypedData(testTypedData()); err == nil || !strings.Contains(err.Error(), signertest.ErrDenied.Error()) {
+		t.Fatalf("expected denied error, got %v", err)
+	}
+}
+
+func TestExternalSignerTransactOpts(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	clef := signertest.NewClefAPI(key)
+	server := clef.StartHTTP()
+	defer server.Close()
+
+	externalSigner, err := signer.NewExternalSigner(server.URL, address)
+	if err != nil {
+		t.Fatalf("could not create external signer: %v", err)
+	}
+
+	chainID := big.NewInt(1337)
+	opts, err := externalSigner.TransactOpts(chainID)
+	if err != nil {
+		t.Fatalf("could not create transaction options: %v", err)
+	}
+	if opts.From != address {
+		t.Fatalf("unexpected sender in transaction options: %s", opts.From.Hex())
+	}
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	transactions := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1e9), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 2, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(2e9), Gas: 50000, To: &to, Data: []byte{0xde, 0xad}}),
+	}
+	for _, tx := range transactions {
+		signed, err := opts.Signer(address, tx)
+		if err != nil {
+			t.Fatalf("could not sign transaction of type %d: %v", tx.Type(), err)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil {
+			t.Fatalf("could not recover sender: %v", err)
+		}
+		if sender != address {
+			t.Fatalf("transaction of type %d signed by %s instead of %s", tx.Type(), sender.Hex(), address.Hex())
+		}
+		if signed.Nonce() != tx.Nonce() || *signed.To() != to {
+			t.Fatalf("signed transaction does not match requested transaction")
+		}
+	}
+
+	if _, err := opts.Signer(to, transactions[0]); err == nil {
+		t.Fatalf("expected signing for another account to fail")
+	}
+}
@@ -0,0 +1,198 @@
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// AliasesFile is the name of the file in a keystore directory which maps aliases to addresses.
+const AliasesFile = "aliases.yaml"
+
+// KeyInfo describes a keystore file in a keystore directory.
+type KeyInfo struct {
+	Address common.Address `json:"address"`
+	Path    string         `json:"path"`
+	Alias   string         `json:"alias,omitempty"`
+}
+
+// Returns the default keystore directory: $XDG_CONFIG_HOME/safes/keystore.
+func DefaultKeystoreDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "safes", "keystore")
+}
+
+// Reads the address of a keystore file without decrypting it.
+func KeystoreAddress(path string) (common.Address, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var keystoreJSON struct {
+		Address string          `json:"address"`
+		Crypto  json.RawMessage `json:"crypto"`
+	}
+	if err := json.Unmarshal(contents, &keystoreJSON); err != nil {
+		return common.Address{}, fmt.Errorf("%s is not a keystore file: %v", path, err)
+	}
+	if keystoreJSON.Crypto == nil || !common.IsHexAddress(keystoreJSON.Address) {
+		return common.Address{}, fmt.Errorf("%s is not a keystore file", path)
+	}
+	return common.HexToAddress(keystoreJSON.Address), nil
+}
+
+// Lists the keystore files in the given directory, ordered by address. Files which are not keystore
+// files are skipped.
+func ListKeys(dir string) ([]KeyInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read keystore directory (%s): %v", dir, err)
+	}
+
+	aliases, err := LoadAliases(dir)
+	if err != nil {
+		return nil, err
+	}
+	aliasOf := map[common.Address]string{}
+	for alias, address := range aliases {
+		aliasOf[address] = alias
+	}
+
+	var keys []KeyInfo
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || entry.Name() == AliasesFile {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		address, err := KeystoreAddress(path)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, KeyInfo{Address: address, Path: path, Alias: aliasOf[address]})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Address == keys[j].Address {
+			return keys[i].Path < keys[j].Path
+		}
+		return keys[i].Address.Hex() < keys[j].Address.Hex()
+	})
+	return keys, nil
+}
+
+// Loads the aliases defined in the given keystore directory.
+func LoadAliases(dir string) (map[string]common.Address, error) {
+	aliases := map[string]common.Address{}
+	contents, err := os.ReadFile(filepath.Join(dir, AliasesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return aliases, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read key aliases: %v", err)
+	}
+
+	var raw map[string]string
+	if err := yaml.Unmarshal(contents, &raw); err != nil {
+		return nil, fmt.Errorf("could not parse key aliases (%s): %v", filepath.Join(dir, AliasesFile), err)
+	}
+	for alias, address := range raw {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("alias %s refers to an invalid address: %s", alias, address)
+		}
+		aliases[alias] = common.HexToAddress(address)
+	}
+	return aliases, nil
+}
+
+// Sets (or, if address is nil, removes) an alias in the given keystore directory.
+func SetAlias(dir, alias string, address *common.Address) error {
+	if alias == "" || common.IsHexAddress(alias) || strings.ContainsAny(alias, `/\`) {
+		return fmt.Errorf("invalid alias: %q (aliases must not be empty, addresses or paths)", alias)
+	}
+
+	aliases, err := LoadAliases(dir)
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		if _, ok := aliases[alias]; !ok {
+			return fmt.Errorf("no such alias: %s", alias)
+		}
+		delete(aliases, alias)
+	} else {
+		for existing, existingAddress := range aliases {
+			if existingAddress == *address && existing != alias {
+				delete(aliases, existing)
+			}
+		}
+		aliases[alias] = *address
+	}
+
+	raw := map[string]string{}
+	for name, aliasAddress := range aliases {
+		raw[name] = aliasAddress.Hex()
+	}
+	contents, err := yaml.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("could not create keystore directory (%s): %v", dir, err)
+	}
+	return os.WriteFile(filepath.Join(dir, AliasesFile), contents, 0600)
+}
+
+// Resolves a reference to a keystore file, which can be a path to the file, or the address or alias of
+// a key in the given keystore directory.
+func ResolveKeyfile(dir, reference string) (string, error) {
+	reference = expandHome(reference)
+	if info, err := os.Stat(reference); err == nil && !info.IsDir() {
+		return reference, nil
+	}
+
+	var address common.Address
+	if common.IsHexAddress(reference) {
+		address = common.HexToAddress(reference)
+	} else {
+		aliases, err := LoadAliases(dir)
+		if err != nil {
+			return "", err
+		}
+		aliasAddress, ok := aliases[reference]
+		if !ok {
+			return "", fmt.Errorf("no keystore file, address or alias %q (keystore directory: %s)", reference, dir)
+		}
+		address = aliasAddress
+	}
+
+	keys, err := ListKeys(dir)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, key := range keys {
+		if key.Address == address {
+			matches = append(matches, key.Path)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no key for %s in keystore directory %s", address.Hex(), dir)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("several keystore files for %s in %s: %s", address.Hex(), dir, strings.Join(matches, ", "))
+}
@@ -0,0 +1,54 @@
+package signer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+func TestResolveKeyfile(t *testing.T) {
+	dir := t.TempDir()
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	account, err := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "password")
+	if err != nil {
+		t.Fatalf("could not store key: %v", err)
+	}
+	if err := signer.SetAlias(dir, "owner", &address); err != nil {
+		t.Fatalf("could not set alias: %v", err)
+	}
+
+	for _, reference := range []string{account.URL.Path, address.Hex(), strings.ToLower(address.Hex()), "owner"} {
+		path, err := signer.ResolveKeyfile(dir, reference)
+		if err != nil {
+			t.Fatalf("could not resolve %s: %v", reference, err)
+		}
+		if path != account.URL.Path {
+			t.Fatalf("%s resolved to %s instead of %s", reference, path, account.URL.Path)
+		}
+	}
+
+	if _, err := signer.ResolveKeyfile(dir, "nobody"); err == nil {
+		t.Fatalf("expected an error for an unknown alias")
+	}
+
+	keys, err := signer.ListKeys(dir)
+	if err != nil {
+		t.Fatalf("could not list keys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Address != address || keys[0].Alias != "owner" {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	keySigner, err := signer.LoadWithOptions(signer.Options{KeystoreDir: dir}, "owner", "password")
+	if err != nil {
+		t.Fatalf("could not load signer by alias: %v", err)
+	}
+	if keySigner.Address() != address {
+		t.Fatalf("loaded %s instead of %s", keySigner.Address().Hex(), address.Hex())
+	}
+}
@@ -0,0 +1,96 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// KeySigner signs with a private key held in memory. It backs the keystore, private key and mnemonic
+// signers.
+type KeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// Creates a signer for the given private key.
+func NewKeySigner(privateKey *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// Creates a signer from an Ethereum keystore file. If password is empty, it is read as by KeyFromFile.
+func NewKeystoreSigner(keystoreFile, password string) (*KeySigner, error) {
+	key, err := KeyFromFile(keystoreFile, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *KeySigner) SignDigest(digest common.Hash) ([]byte, error) {
+	signature, err := crypto.Sign(digest.Bytes(), s.privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust V value for Ethereum's replay protection
+	signature[64] += 27
+	return signature, nil
+}
+
+func (s *KeySigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, err := TypedDataHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return s.SignDigest(hash)
+}
+
+func (s *KeySigner) TransactOpts(chainID *big.Int) (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(s.privateKey, chainID)
+}
+
+// Unlocks a key from a keystore (byte contents of a keystore file) with the given password.
+func UnlockKeystore(keystoreData []byte, password string) (*keystore.Key, error) {
+	key, err := keystore.DecryptKey(keystoreData, password)
+	return key, err
+}
+
+// Loads a key from file. If password is empty, it is read from the default password sources
+// (--password-file, --password-env or --password-command), or else the user is prompted for it.
+func KeyFromFile(keystoreFile string, password string) (*keystore.Key, error) {
+	var emptyKey *keystore.Key
+	keystoreContent, readErr := os.ReadFile(keystoreFile)
+	if readErr != nil {
+		return emptyKey, readErr
+	}
+
+	if password == "" {
+		var inputErr error
+		password, inputErr = ReadPassword(keystorePrompt(keystoreFile))
+		if inputErr != nil {
+			return emptyKey, inputErr
+		}
+	}
+
+	key, err := UnlockKeystore(keystoreContent, password)
+	return key, err
+}
+
+func keystorePrompt(keystoreFile string) string {
+	return fmt.Sprintf("Please provide a password for keystore (%s)", keystoreFile)
+}
@@ -0,0 +1,126 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Creates a signer from a BIP-39 mnemonic stored in the given environment variable, deriving the key at
+// the given BIP-32 path.
+func NewMnemonicSignerFromEnv(envVar, hdPath string) (*KeySigner, error) {
+	mnemonic := os.Getenv(envVar)
+	if mnemonic == "" {
+		return nil, fmt.Errorf("environment variable %s is empty or not set", envVar)
+	}
+	return NewMnemonicSigner(mnemonic, hdPath)
+}
+
+// Creates a signer from a BIP-39 mnemonic stored in the given file, deriving the key at the given BIP-32
+// path.
+func NewMnemonicSignerFromFile(path, hdPath string) (*KeySigner, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read mnemonic file: %v", err)
+	}
+	return NewMnemonicSigner(string(contents), hdPath)
+}
+
+// Creates a signer from a BIP-39 mnemonic, deriving the key at the given BIP-32 path (DefaultHDPath if
+// empty).
+func NewMnemonicSigner(mnemonic, hdPath string) (*KeySigner, error) {
+	privateKey, err := DeriveKeyFromMnemonic(mnemonic, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(ecdsaKey), nil
+}
+
+// Derives the raw private key at the given BIP-32 path from a BIP-39 mnemonic (without passphrase).
+func DeriveKeyFromMnemonic(mnemonic, hdPath string) ([]byte, error) {
+	if hdPath == "" {
+		hdPath = DefaultHDPath
+	}
+
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %v", err)
+	}
+	return DeriveKeyFromSeed(seed, hdPath)
+}
+
+// Derives the raw private key at the given BIP-32 path from a BIP-32 seed.
+func DeriveKeyFromSeed(seed []byte, hdPath string) ([]byte, error) {
+	path, err := accounts.ParseDerivationPath(hdPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path: %v", err)
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	master := mac.Sum(nil)
+	key, chainCode := master[:32], master[32:]
+
+	for _, index := range path {
+		key, chainCode, err = deriveChild(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// Implements BIP-32 private parent key to private child key derivation.
+func deriveChild(parentKey, parentChainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= 0x80000000 {
+		data = append([]byte{0x00}, parentKey...)
+	} else {
+		parentECDSA, err := crypto.ToECDSA(parentKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&parentECDSA.PublicKey)
+	}
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, parentChainCode)
+	mac.Write(data)
+	derived := mac.Sum(nil)
+
+	curveOrder := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(derived[:32])
+	if tweak.Cmp(curveOrder) >= 0 {
+		return nil, nil, errors.New("invalid derived key, try the next index")
+	}
+	child := new(big.Int).Add(tweak, new(big.Int).SetBytes(parentKey))
+	child.Mod(child, curveOrder)
+	if child.Sign() == 0 {
+		return nil, nil, errors.New("invalid derived key, try the next index")
+	}
+
+	return leftPad32(child), derived[32:], nil
+}
+
+func leftPad32(value *big.Int) []byte {
+	out := make([]byte, 32)
+	value.FillBytes(out)
+	return out
+}
@@ -0,0 +1,48 @@
+package signer_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+func TestDeriveKeyFromMnemonic(t *testing.T) {
+	// The first account of the Hardhat and Anvil test mnemonic.
+	key, err := signer.DeriveKeyFromMnemonic("test test test test test test test test test test test junk", "m/44'/60'/0'/0/0")
+	if err != nil {
+		t.Fatalf("could not derive key: %v", err)
+	}
+	ecdsaKey, err := crypto.ToECDSA(key)
+	if err != nil {
+		t.Fatalf("derived an invalid key: %v", err)
+	}
+	if address := crypto.PubkeyToAddress(ecdsaKey.PublicKey); address != common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") {
+		t.Fatalf("derived the key of %s", address.Hex())
+	}
+}
+
+func TestDeriveKeyFromSeed(t *testing.T) {
+	// Test vector 1 of BIP-32.
+	seed := common.FromHex("0x000102030405060708090a0b0c0d0e0f")
+	for _, test := range []struct {
+		path string
+		key  string
+	}{
+		{"m/0'", "0xedb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "0x3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "0xcbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0'/1/2'/2", "0x0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0'/1/2'/2/1000000000", "0x471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	} {
+		key, err := signer.DeriveKeyFromSeed(seed, test.path)
+		if err != nil {
+			t.Fatalf("could not derive %s: %v", test.path, err)
+		}
+		if common.Bytes2Hex(key) != test.key[2:] {
+			t.Fatalf("derived %x at %s, expected %s", key, test.path, test.key)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/G7DAO/safes/output"
+)
+
+// ErrNoTerminal is returned when a password is needed, none of the non-interactive password sources is
+// configured, and standard input is not a terminal to prompt on.
+var ErrNoTerminal = errors.New("a password is required but standard input is not a terminal -- use --password-file, --password-env or --password-command to provide it non-interactively")
+
+// PasswordSources configure where passwords are read from when they are not passed directly. At most
+// one of the sources may be set.
+type PasswordSources struct {
+	// File is the path of a file containing the password.
+	File string
+	// Env is the name of an environment variable containing the password.
+	Env string
+	// Command is a shell command which prints the password, for example "pass show safes/deployer".
+	Command string
+}
+
+// Returns true if any password source is set.
+func (s PasswordSources) Configured() bool {
+	return s.File != "" || s.Env != "" || s.Command != ""
+}
+
+// Reads the password from the configured source.
+func (s PasswordSources) Read() (string, error) {
+	configured := 0
+	for _, source := range []string{s.File, s.Env, s.Command} {
+		if source != "" {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return "", errors.New("only one of --password-file, --password-env and --password-command may be specified")
+	}
+
+	switch {
+	case s.File != "":
+		contents, err := os.ReadFile(expandHome(s.File))
+		if err != nil {
+			return "", fmt.Errorf("could not read password file: %v", err)
+		}
+		return trimNewline(string(contents)), nil
+	case s.Env != "":
+		password, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("password environment variable %s is not set", s.Env)
+		}
+		return password, nil
+	case s.Command != "":
+		return passwordFromCommand(s.Command)
+	}
+	return "", errors.New("no password source configured")
+}
+
+// Runs a shell command and returns the first line of its output as the password. The command inherits
+// standard input and standard error, so that password managers can ask for confirmation.
+func passwordFromCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("password command failed: %v", err)
+	}
+
+	password, _, _ := strings.Cut(stdout.String(), "\n")
+	password = strings.TrimSuffix(password, "\r")
+	if password == "" {
+		return "", errors.New("password command did not print a password")
+	}
+	return password, nil
+}
+
+// Removes a single trailing line break, as left by editors and by "echo" when writing password files.
+func trimNewline(password string) string {
+	password = strings.TrimSuffix(password, "\n")
+	return strings.TrimSuffix(password, "\r")
+}
+
+// Returns a password from the default password sources if one is configured, and otherwise prompts the
+// user for it on the terminal.
+func ReadPassword(prompt string) (string, error) {
+	return defaultOptions.Passwords.readOrPrompt(prompt, PromptPassword)
+}
+
+// Returns a password from the default password sources if one is configured, and otherwise prompts the
+// user for a new password (twice) on the terminal.
+func ReadNewPassword(prompt string) (string, error) {
+	return defaultOptions.Passwords.readOrPrompt(prompt, PromptNewPassword)
+}
+
+func (s PasswordSources) readOrPrompt(prompt string, promptFunc func(string) (string, error)) (string, error) {
+	if s.Configured() {
+		return s.Read()
+	}
+	return promptFunc(prompt)
+}
+
+// Prompts the user for a password on the terminal, without echoing it.
+func PromptPassword(prompt string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", ErrNoTerminal
+	}
+	output.Infof("%s: ", prompt)
+	passwordRaw, inputErr := term.ReadPassword(int(os.Stdin.Fd()))
+	output.Infof("\n")
+	if inputErr != nil {
+		return "", fmt.Errorf("error reading password: %s", inputErr.Error())
+	}
+	return string(passwordRaw), nil
+}
+
+// Prompts the user for a new password twice, and checks that both entries match.
+func PromptNewPassword(prompt string) (string, error) {
+	password, err := PromptPassword(prompt)
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := PromptPassword("Repeat the password")
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		return "", errors.New("passwords do not match")
+	}
+	return password, nil
+}
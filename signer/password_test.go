@@ -0,0 +1,69 @@
+package signer_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/signer"
+)
+
+func TestPasswordSources(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("correct horse\n"), 0600); err != nil {
+		t.Fatalf("could not write password file: %v", err)
+	}
+	t.Setenv("SAFES_TEST_PASSWORD", "correct horse")
+
+	sources := []signer.PasswordSources{
+		{File: passwordFile},
+		{Env: "SAFES_TEST_PASSWORD"},
+	}
+	if runtime.GOOS != "windows" {
+		sources = append(sources, signer.PasswordSources{Command: "echo 'correct horse'"})
+	}
+	for _, source := range sources {
+		password, err := source.Read()
+		if err != nil {
+			t.Fatalf("could not read password from %+v: %v", source, err)
+		}
+		if password != "correct horse" {
+			t.Fatalf("read %q from %+v", password, source)
+		}
+	}
+
+	if _, err := (signer.PasswordSources{Env: "SAFES_TEST_UNSET_PASSWORD"}).Read(); err == nil {
+		t.Fatalf("expected an error for an unset environment variable")
+	}
+	if _, err := (signer.PasswordSources{File: passwordFile, Env: "SAFES_TEST_PASSWORD"}).Read(); err == nil {
+		t.Fatalf("expected an error for several password sources")
+	}
+	if runtime.GOOS != "windows" {
+		if _, err := (signer.PasswordSources{Command: "exit 1"}).Read(); err == nil {
+			t.Fatalf("expected an error for a failing password command")
+		}
+	}
+}
+
+func TestKeystoreSignerUsesPasswordSources(t *testing.T) {
+	dir := t.TempDir()
+	key, _ := crypto.GenerateKey()
+	account, err := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "correct horse")
+	if err != nil {
+		t.Fatalf("could not store key: %v", err)
+	}
+	t.Setenv("SAFES_TEST_PASSWORD", "correct horse")
+
+	options := signer.Options{Passwords: signer.PasswordSources{Env: "SAFES_TEST_PASSWORD"}}
+	keySigner, err := signer.LoadWithOptions(options, account.URL.Path, "")
+	if err != nil {
+		t.Fatalf("could not load keystore signer: %v", err)
+	}
+	if keySigner.Address() != account.Address {
+		t.Fatalf("loaded %s instead of %s", keySigner.Address().Hex(), account.Address.Hex())
+	}
+}
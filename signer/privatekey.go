@@ -0,0 +1,36 @@
+package signer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Creates a signer from a hex-encoded private key stored in the given environment variable.
+func NewPrivateKeySignerFromEnv(envVar string) (*KeySigner, error) {
+	privateKeyHex := os.Getenv(envVar)
+	if privateKeyHex == "" {
+		return nil, fmt.Errorf("environment variable %s is empty or not set", envVar)
+	}
+	return newPrivateKeySigner(privateKeyHex)
+}
+
+// Creates a signer from a hex-encoded private key stored in the given file.
+func NewPrivateKeySignerFromFile(path string) (*KeySigner, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read private key file: %v", err)
+	}
+	return newPrivateKeySigner(string(contents))
+}
+
+func newPrivateKeySigner(privateKeyHex string) (*KeySigner, error) {
+	privateKeyHex = strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x")
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	return NewKeySigner(privateKey), nil
+}
@@ -0,0 +1,101 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/G7DAO/safes/output"
+)
+
+// ErrNotConfirmed is returned when the user declines to sign reviewed typed data.
+var ErrNotConfirmed = errors.New("signature not confirmed (pass --yes to sign without confirmation)")
+
+var assumeYes bool
+
+// Sets whether typed data is signed without asking for confirmation. The root command calls this with
+// the value of its --yes flag.
+func SetAssumeYes(yes bool) {
+	assumeYes = yes
+}
+
+// Returns a review of EIP-712 typed data in the form hardware wallets show it: every field of the
+// domain and of the message, the domain hash, the message hash and the hash which is signed. The
+// details (for example, decoded calldata) are appended to the review.
+func ReviewTypedData(typedData apitypes.TypedData, details ...string) (string, error) {
+	domainHash, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return "", fmt.Errorf("failed to hash EIP-712 domain: %v", err)
+	}
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash EIP-712 message: %v", err)
+	}
+	signingHash, err := TypedDataHash(typedData)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("Domain:\n")
+	writeTypedFields(&b, typedData.Types, "EIP712Domain", typedData.Domain.Map(), "  ")
+	fmt.Fprintf(&b, "Message (%s):\n", typedData.PrimaryType)
+	writeTypedFields(&b, typedData.Types, typedData.PrimaryType, typedData.Message, "  ")
+	for _, detail := range details {
+		b.WriteString(detail + "\n")
+	}
+	fmt.Fprintf(&b, "Domain hash: %s\n", domainHash.String())
+	fmt.Fprintf(&b, "Message hash: %s\n", messageHash.String())
+	fmt.Fprintf(&b, "Signing hash: %s\n", signingHash.Hex())
+	return b.String(), nil
+}
+
+// Writes the fields of a struct of typed data in the order of its type, descending into nested structs.
+func writeTypedFields(b *strings.Builder, types apitypes.Types, typeName string, data map[string]interface{}, indent string) {
+	for _, field := range types[typeName] {
+		value := data[field.Name]
+		if nested, ok := value.(map[string]interface{}); ok && types[field.Type] != nil {
+			fmt.Fprintf(b, "%s%s:\n", indent, field.Name)
+			writeTypedFields(b, types, field.Type, nested, indent+"  ")
+			continue
+		}
+		fmt.Fprintf(b, "%s%s: %s\n", indent, field.Name, typedValue(value))
+	}
+}
+
+func typedValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case *math.HexOrDecimal256:
+		return (*big.Int)(v).String()
+	}
+	return fmt.Sprint(output.Normalize(value))
+}
+
+// Shows the review of typed data (see ReviewTypedData) and, unless --yes was given, asks the user to
+// confirm that it should be signed. Anything but "y" or "yes" declines with ErrNotConfirmed.
+func ConfirmTypedData(typedData apitypes.TypedData, details ...string) error {
+	review, err := ReviewTypedData(typedData, details...)
+	if err != nil {
+		return err
+	}
+	output.Infoln("Review the EIP-712 typed data to sign:")
+	output.Infof("%s", review)
+	if assumeYes {
+		return nil
+	}
+
+	output.Infof("Sign? (y/n): ")
+	var confirm string
+	fmt.Scanln(&confirm)
+	confirm = strings.ToLower(strings.TrimSpace(confirm))
+	if confirm != "y" && confirm != "yes" {
+		return ErrNotConfirmed
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Package signer abstracts over the ways in which safes can produce signatures: Safe transaction
+// hashes, EIP-712 messages for the Safe Transaction Service, and on-chain transactions.
+//
+// A Signer is selected with a signer specification of the form <backend>:<parameter>, for example
+// "privatekey-env:DEPLOYER_KEY". Commands which accept a --keyfile fall back to the keystore backend for
+// that file when no specification is configured.
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signer produces signatures on behalf of a single account.
+type Signer interface {
+	// Address returns the address of the account which the signer signs for.
+	Address() common.Address
+	// SignDigest signs a 32 byte digest. The signature is 65 bytes long, in [R || S || V] format, with V
+	// being 27 or 28.
+	SignDigest(digest common.Hash) ([]byte, error)
+	// SignTypedData signs EIP-712 typed data. The signature has the same format as for SignDigest.
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+	// TransactOpts returns transaction options which sign transactions for the given chain.
+	TransactOpts(chainID *big.Int) (*bind.TransactOpts, error)
+}
+
+// ErrDigestSigningUnsupported is returned by signers which only sign structured data and refuse to
+// sign raw digests.
+var ErrDigestSigningUnsupported = errors.New("this signer does not support signing raw digests")
+
+// Options configure how Load selects and builds a signer.
+type Options struct {
+	// Spec is the signer specification, of the form <backend>:<parameter>.
+	Spec string
+	// HDPath is the derivation path for mnemonic signers.
+	HDPath string
+	// Address selects the account to use for signers which manage multiple accounts.
+	Address string
+	// KeystoreDir is the directory in which keys referred to by address or alias are looked up.
+	KeystoreDir string
+	// Passwords configure where keystore passwords are read from when none is passed to Load.
+	Passwords PasswordSources
+}
+
+// DefaultHDPath is the derivation path used for mnemonic signers when none is specified.
+const DefaultHDPath = "m/44'/60'/0'/0/0"
+
+var defaultOptions Options
+
+// Sets the options used by Load. The root command calls this with the values of its signer flags.
+func SetDefaults(options Options) {
+	defaultOptions = options
+}
+
+// Returns true if a signer specification has been configured, in which case commands do not need a
+// --keyfile to be able to sign.
+func Configured() bool {
+	return defaultOptions.Spec != ""
+}
+
+// Loads the signer selected by the default options. If no signer specification is configured, the
+// keystore file at keyfile is used, unlocked with password (or, if it is empty, with the password from
+// the default password sources or typed in at a prompt).
+func Load(keyfile, password string) (Signer, error) {
+	return LoadWithOptions(defaultOptions, keyfile, password)
+}
+
+// Loads the signer selected by the given options. If options.Spec is empty, the keystore file at
+// keyfile is used, unlocked with password.
+func LoadWithOptions(options Options, keyfile, password string) (Signer, error) {
+	if options.Spec == "" {
+		if keyfile == "" {
+			return nil, errors.New("no signer configured -- pass --keyfile or --signer")
+		}
+		return loadKeystoreSigner(options, keyfile, password)
+	}
+
+	backend, parameter, found := strings.Cut(options.Spec, ":")
+	if !found || parameter == "" {
+		// Allow "--signer keystore" to mean the keystore given by --keyfile.
+		if backend == "keystore" && keyfile != "" {
+			return loadKeystoreSigner(options, keyfile, password)
+		}
+		return nil, fmt.Errorf("invalid signer specification: %s (expected <backend>:<parameter>)", options.Spec)
+	}
+
+	switch backend {
+	case "keystore":
+		return loadKeystoreSigner(options, parameter, password)
+	case "privatekey-env":
+		return NewPrivateKeySignerFromEnv(parameter)
+	case "privatekey-file":
+		return NewPrivateKeySignerFromFile(parameter)
+	case "mnemonic-env":
+		return NewMnemonicSignerFromEnv(parameter, options.HDPath)
+	case "mnemonic-file":
+		return NewMnemonicSignerFromFile(parameter, options.HDPath)
+	case "web3signer":
+		address := common.Address{}
+		if options.Address != "" {
+			if !common.IsHexAddress(options.Address) {
+				return nil, fmt.Errorf("invalid --signer-address: %s", options.Address)
+			}
+			address = common.HexToAddress(options.Address)
+		}
+		return NewWeb3Signer(parameter, address)
+	case "external", "clef":
+		if !common.IsHexAddress(options.Address) {
+			return nil, errors.New("the external signer requires --signer-address to select an account")
+		}
+		return NewExternalSigner(parameter, common.HexToAddress(options.Address))
+	}
+
+	return nil, fmt.Errorf("unknown signer backend: %s", backend)
+}
+
+// Loads a keystore signer for a keystore file given by path, or by the address or alias of a key in the
+// keystore directory.
+func loadKeystoreSigner(options Options, reference, password string) (Signer, error) {
+	keystoreDir := options.KeystoreDir
+	if keystoreDir == "" {
+		keystoreDir = DefaultKeystoreDir()
+	}
+	keyfile, err := ResolveKeyfile(keystoreDir, reference)
+	if err != nil {
+		return nil, err
+	}
+	if password == "" {
+		password, err = options.Passwords.readOrPrompt(keystorePrompt(keyfile), PromptPassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewKeystoreSigner(keyfile, password)
+}
+
+// Returns the EIP-712 hash of the given typed data.
+func TypedDataHash(typedData apitypes.TypedData) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash typed data: %v", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// Expands a leading ~/ in a path to the user's home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
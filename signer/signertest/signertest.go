@@ -0,0 +1,246 @@
+// Package signertest provides stand-ins for the remote signers which safes can use, so that commands
+// which sign can be tested without Clef or a remote signing service.
+package signertest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrDenied is returned by the stand-in signers when they are told to deny requests, as a user would in
+// Clef.
+var ErrDenied = errors.New("request denied")
+
+// ClefAPI implements the subset of Clef's account_* JSON-RPC API which safes uses, signing with private
+// keys held in memory.
+type ClefAPI struct {
+	mu       sync.Mutex
+	keys     map[common.Address]*ecdsa.PrivateKey
+	order    []common.Address
+	deny     bool
+	requests []string
+}
+
+// Creates a stand-in for Clef which manages the given keys.
+func NewClefAPI(keys ...*ecdsa.PrivateKey) *ClefAPI {
+	api := &ClefAPI{keys: map[common.Address]*ecdsa.PrivateKey{}}
+	for _, key := range keys {
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		api.keys[address] = key
+		api.order = append(api.order, address)
+	}
+	return api
+}
+
+// Makes the stand-in deny (or stop denying) all signing requests.
+func (api *ClefAPI) Deny(deny bool) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.deny = deny
+}
+
+// Returns the names of the signing requests the stand-in has received, in order.
+func (api *ClefAPI) Requests() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]string(nil), api.requests...)
+}
+
+func (api *ClefAPI) record(request string, address common.Address) (*ecdsa.PrivateKey, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.requests = append(api.requests, request)
+	if api.deny {
+		return nil, ErrDenied
+	}
+	key, ok := api.keys[address]
+	if !ok {
+		return nil, errors.New("unknown account")
+	}
+	return key, nil
+}
+
+// List implements account_list.
+func (api *ClefAPI) List(ctx context.Context) ([]common.Address, error) {
+	return api.order, nil
+}
+
+// Version implements account_version.
+func (api *ClefAPI) Version(ctx context.Context) (string, error) {
+	return "6.1.0", nil
+}
+
+// SignTypedData implements account_signTypedData.
+func (api *ClefAPI) SignTypedData(ctx context.Context, address common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	key, err := api.record("account_signTypedData", address.Address())
+	if err != nil {
+		return nil, err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// SignTransactionResult is the result of account_signTransaction.
+type SignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// SignTransaction implements account_signTransaction.
+func (api *ClefAPI) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*SignTransactionResult, error) {
+	key, err := api.record("account_signTransaction", args.From.Address())
+	if err != nil {
+		return nil, err
+	}
+	if args.ChainID == nil {
+		return nil, errors.New("chain id not specified")
+	}
+	unsigned, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignTx(unsigned, types.LatestSignerForChainID(args.ChainID.ToInt()), key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &SignTransactionResult{Raw: raw, Tx: signed}, nil
+}
+
+// Creates a JSON-RPC server which serves the API under the account namespace, like Clef does.
+func (api *ClefAPI) Server() *rpc.Server {
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", api); err != nil {
+		panic(err)
+	}
+	return server
+}
+
+// Starts serving the API over HTTP. The URL of the server can be used as the endpoint of an external
+// signer. The caller must close the server.
+func (api *ClefAPI) StartHTTP() *httptest.Server {
+	return httptest.NewServer(api.Server())
+}
+
+// Starts serving the API over an IPC socket at the given path. The caller must close the returned
+// listener.
+func (api *ClefAPI) StartIPC(path string) (net.Listener, error) {
+	listener, _, err := rpc.StartIPCEndpoint(path, []rpc.API{{Namespace: "account", Service: api}})
+	return listener, err
+}
+
+// Web3SignerAPI is a stand-in for a Web3Signer-compatible remote signing service. It serves the eth1
+// public key listing and signing endpoints, signing keccak256 of the submitted data with keys held in
+// memory.
+type Web3SignerAPI struct {
+	mu       sync.Mutex
+	keys     map[string]*ecdsa.PrivateKey
+	order    []string
+	deny     bool
+	payloads [][]byte
+}
+
+// Creates a stand-in for a Web3Signer service which holds the given keys.
+func NewWeb3SignerAPI(keys ...*ecdsa.PrivateKey) *Web3SignerAPI {
+	api := &Web3SignerAPI{keys: map[string]*ecdsa.PrivateKey{}}
+	for _, key := range keys {
+		// Web3Signer identifies secp256k1 keys by their uncompressed public key, without the 0x04 prefix.
+		identifier := hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey)[1:])
+		api.keys[identifier] = key
+		api.order = append(api.order, identifier)
+	}
+	return api
+}
+
+// Makes the stand-in deny (or stop denying) all signing requests.
+func (api *Web3SignerAPI) Deny(deny bool) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.deny = deny
+}
+
+// Returns the data the stand-in has been asked to sign, in order.
+func (api *Web3SignerAPI) Payloads() [][]byte {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([][]byte(nil), api.payloads...)
+}
+
+func (api *Web3SignerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const signPrefix = "/api/v1/eth1/sign/"
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/eth1/publicKeys":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(api.order)
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, signPrefix):
+		var request struct {
+			Data string `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		data, err := hexutil.Decode(request.Data)
+		if err != nil {
+			http.Error(w, "invalid data", http.StatusBadRequest)
+			return
+		}
+
+		api.mu.Lock()
+		key, ok := api.keys[strings.TrimPrefix(r.URL.Path, signPrefix)]
+		deny := api.deny
+		api.payloads = append(api.payloads, data)
+		api.mu.Unlock()
+
+		if !ok {
+			http.Error(w, "Signer not found for identifier", http.StatusNotFound)
+			return
+		}
+		if deny {
+			http.Error(w, ErrDenied.Error(), http.StatusForbidden)
+			return
+		}
+
+		signature, err := crypto.Sign(crypto.Keccak256(data), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		signature[64] += 27
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(hexutil.Encode(signature)))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Starts serving the API over HTTP. The caller must close the server.
+func (api *Web3SignerAPI) StartHTTP() *httptest.Server {
+	return httptest.NewServer(api)
+}
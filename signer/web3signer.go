@@ -0,0 +1,234 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Web3Signer signs with a key held by a remote signing service exposing the Web3Signer eth1 API. The
+// service signs keccak256 of the data it is given, so typed data is sent as its EIP-712 encoding and
+// transactions as their signing payload. Raw digests cannot be signed, since their preimage is unknown.
+type Web3Signer struct {
+	url        string
+	identifier string
+	address    common.Address
+	httpClient *http.Client
+}
+
+// How long to wait for the remote signer to respond to a request.
+const web3SignerTimeout = 60 * time.Second
+
+// Creates a signer for the key with the given address held by the Web3Signer-compatible service at
+// url. If address is the zero address, the service must hold exactly one secp256k1 key.
+func NewWeb3Signer(url string, address common.Address) (*Web3Signer, error) {
+	s := &Web3Signer{
+		url:        strings.TrimSuffix(url, "/"),
+		httpClient: &http.Client{Timeout: web3SignerTimeout},
+	}
+
+	identifiers, err := s.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []string
+	for _, identifier := range identifiers {
+		keyAddress, err := publicKeyAddress(identifier)
+		if err != nil {
+			return nil, fmt.Errorf("remote signer at %s returned an invalid public key (%s): %v", s.url, identifier, err)
+		}
+		if keyAddress == address || (address == (common.Address{}) && len(identifiers) == 1) {
+			s.identifier, s.address = identifier, keyAddress
+			return s, nil
+		}
+		candidates = append(candidates, keyAddress.Hex())
+	}
+
+	if address == (common.Address{}) {
+		return nil, fmt.Errorf("remote signer at %s holds %d keys, select one with --signer-address (available: %s)", s.url, len(identifiers), strings.Join(candidates, ", "))
+	}
+	return nil, fmt.Errorf("remote signer at %s does not hold a key for %s", s.url, address.Hex())
+}
+
+func (s *Web3Signer) Address() common.Address {
+	return s.address
+}
+
+func (s *Web3Signer) SignDigest(digest common.Hash) ([]byte, error) {
+	return nil, ErrDigestSigningUnsupported
+}
+
+func (s *Web3Signer) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, rawData, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %v", err)
+	}
+
+	// rawData is 0x1901 || domainSeparator || hashStruct(message), whose keccak256 is the EIP-712 hash.
+	signature, err := s.sign([]byte(rawData), common.BytesToHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+func (s *Web3Signer) TransactOpts(chainID *big.Int) (*bind.TransactOpts, error) {
+	txSigner := types.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From: s.address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.address {
+				return nil, bind.ErrNotAuthorized
+			}
+			payload, err := transactionSigningPayload(tx, chainID)
+			if err != nil {
+				return nil, err
+			}
+			signature, err := s.sign(payload, txSigner.Hash(tx))
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(txSigner, signature)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+// Asks the remote signer to sign keccak256(data), checks that the signature was made by the signer's
+// key over expectedHash and returns it with V normalized to 0 or 1.
+func (s *Web3Signer) sign(data []byte, expectedHash common.Hash) ([]byte, error) {
+	if crypto.Keccak256Hash(data) != expectedHash {
+		return nil, errors.New("signing payload does not hash to the expected digest")
+	}
+
+	requestBody, err := json.Marshal(map[string]string{"data": hexutil.Encode(data)})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/eth1/sign/%s", s.url, s.identifier), bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "text/plain")
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach remote signer at %s: %v", s.url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response of remote signer: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer at %s did not sign: status code %d, body: %s", s.url, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	signatureHex := strings.Trim(strings.TrimSpace(string(body)), "\"")
+	signature, err := hexutil.Decode(signatureHex)
+	if err != nil || len(signature) != 65 {
+		return nil, fmt.Errorf("remote signer at %s returned an invalid signature: %s", s.url, signatureHex)
+	}
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(expectedHash.Bytes(), signature)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover signer of remote signature: %v", err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != s.address {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", crypto.PubkeyToAddress(*publicKey).Hex(), s.address.Hex())
+	}
+
+	return signature, nil
+}
+
+// Lists the identifiers (public keys) of the secp256k1 keys held by the remote signer.
+func (s *Web3Signer) publicKeys() ([]string, error) {
+	response, err := s.httpClient.Get(s.url + "/api/v1/eth1/publicKeys")
+	if err != nil {
+		return nil, fmt.Errorf("could not reach remote signer at %s: %v", s.url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response of remote signer: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not list keys of remote signer at %s: status code %d, body: %s", s.url, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var identifiers []string
+	if err := json.Unmarshal(body, &identifiers); err != nil {
+		return nil, fmt.Errorf("could not parse keys of remote signer at %s: %v", s.url, err)
+	}
+	return identifiers, nil
+}
+
+// Returns the address of a hex-encoded secp256k1 public key, which may be compressed, uncompressed, or
+// uncompressed without its 0x04 prefix.
+func publicKeyAddress(publicKeyHex string) (common.Address, error) {
+	publicKeyBytes, err := hexutil.Decode(publicKeyHex)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var publicKey *ecdsa.PublicKey
+	switch len(publicKeyBytes) {
+	case 33:
+		publicKey, err = crypto.DecompressPubkey(publicKeyBytes)
+	case 64:
+		publicKey, err = crypto.UnmarshalPubkey(append([]byte{0x04}, publicKeyBytes...))
+	default:
+		publicKey, err = crypto.UnmarshalPubkey(publicKeyBytes)
+	}
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// Returns the payload whose keccak256 hash is signed to sign the given transaction on the given chain.
+func transactionSigningPayload(tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return rlp.EncodeToBytes([]interface{}{
+			tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(),
+			chainID, uint(0), uint(0),
+		})
+	case types.AccessListTxType:
+		payload, err := rlp.EncodeToBytes([]interface{}{
+			chainID, tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList(),
+		})
+		return append([]byte{types.AccessListTxType}, payload...), err
+	case types.DynamicFeeTxType:
+		payload, err := rlp.EncodeToBytes([]interface{}{
+			chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList(),
+		})
+		return append([]byte{types.DynamicFeeTxType}, payload...), err
+	}
+	return nil, fmt.Errorf("remote signer does not support transactions of type %d", tx.Type())
+}
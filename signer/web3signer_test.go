@@ -0,0 +1,187 @@
+package signer_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/safetx"
+	"github.com/G7DAO/safes/signer"
+	"github.com/G7DAO/safes/signer/signertest"
+)
+
+func TestWeb3SignerSignsSafeTxHash(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	remote := signertest.NewWeb3SignerAPI(key)
+	server := remote.StartHTTP()
+	defer server.Close()
+
+	web3Signer, err := signer.LoadWithOptions(signer.Options{Spec: "web3signer:" + server.URL}, "", "")
+	if err != nil {
+		t.Fatalf("could not load remote signer: %v", err)
+	}
+	if web3Signer.Address() != address {
+		t.Fatalf("unexpected address: %s", web3Signer.Address().Hex())
+	}
+
+	safeAddress := common.HexToAddress("0x5afe00000000000000000000000000000000cafe")
+	chainID := big.NewInt(11155111)
+	txData := safetx.TransactionData{
+		To:             safeAddress.Hex(),
+		Value:          "0",
+		Data:           "694e80c30000000000000000000000000000000000000000000000000000000000000002",
+		GasPrice:       "0",
+		GasToken:       safetx.NativeTokenAddress,
+		RefundReceiver: safetx.NativeTokenAddress,
+		Nonce:          3,
+	}
+	safeTxHash, err := safetx.CalculateSafeTxHash(safeAddress, txData, chainID)
+	if err != nil {
+		t.Fatalf("could not calculate SafeTxHash: %v", err)
+	}
+
+	signature, err := web3Signer.SignTypedData(safetx.TypedData(safeAddress, txData, chainID))
+	if err != nil {
+		t.Fatalf("could not sign SafeTx: %v", err)
+	}
+	expected, err := signer.NewKeySigner(key).SignDigest(safeTxHash)
+	if err != nil {
+		t.Fatalf("could not sign SafeTxHash locally: %v", err)
+	}
+	if !bytes.Equal(signature, expected) {
+		t.Fatalf("remote signature %x does not match local signature %x", signature, expected)
+	}
+
+	payloads := remote.Payloads()
+	if len(payloads) != 1 || !bytes.HasPrefix(payloads[0], []byte{0x19, 0x01}) || crypto.Keccak256Hash(payloads[0]) != safeTxHash {
+		t.Fatalf("remote signer was not asked to sign the EIP-712 encoding of the SafeTx: %x", payloads)
+	}
+}
+
+func TestWeb3SignerSignsDelegateTypedData(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	server := signertest.NewWeb3SignerAPI(key).StartHTTP()
+	defer server.Close()
+
+	web3Signer, err := signer.NewWeb3Signer(server.URL, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("could not create remote signer: %v", err)
+	}
+
+	signature, err := web3Signer.SignTypedData(testTypedData())
+	if err != nil {
+		t.Fatalf("could not sign typed data: %v", err)
+	}
+	expected, err := signer.NewKeySigner(key).SignTypedData(testTypedData())
+	if err != nil {
+		t.Fatalf("could not sign typed data locally: %v", err)
+	}
+	if !bytes.Equal(signature, expected) {
+		t.Fatalf("remote signature %x does not match local signature %x", signature, expected)
+	}
+}
+
+func TestWeb3SignerSelectsKey(t *testing.T) {
+	firstKey, _ := crypto.GenerateKey()
+	secondKey, _ := crypto.GenerateKey()
+	server := signertest.NewWeb3SignerAPI(firstKey, secondKey).StartHTTP()
+	defer server.Close()
+
+	if _, err := signer.NewWeb3Signer(server.URL, common.Address{}); err == nil || !strings.Contains(err.Error(), "--signer-address") {
+		t.Fatalf("expected an error asking for --signer-address, got %v", err)
+	}
+
+	secondAddress := crypto.PubkeyToAddress(secondKey.PublicKey)
+	web3Signer, err := signer.LoadWithOptions(signer.Options{Spec: "web3signer:" + server.URL, Address: secondAddress.Hex()}, "", "")
+	if err != nil {
+		t.Fatalf("could not load remote signer: %v", err)
+	}
+	if web3Signer.Address() != secondAddress {
+		t.Fatalf("selected %s instead of %s", web3Signer.Address().Hex(), secondAddress.Hex())
+	}
+
+	if _, err := signer.NewWeb3Signer(server.URL, common.HexToAddress("0x000000000000000000000000000000000000dEaD")); err == nil || !strings.Contains(err.Error(), "does not hold a key") {
+		t.Fatalf("expected an unknown key error, got %v", err)
+	}
+}
+
+func TestWeb3SignerRefusesDigests(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	server := signertest.NewWeb3SignerAPI(key).StartHTTP()
+	defer server.Close()
+
+	web3Signer, err := signer.NewWeb3Signer(server.URL, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("could not create remote signer: %v", err)
+	}
+	if _, err := web3Signer.SignDigest(common.Hash{1}); !errors.Is(err, signer.ErrDigestSigningUnsupported) {
+		t.Fatalf("expected ErrDigestSigningUnsupported, got %v", err)
+	}
+}
+
+func TestWeb3SignerDenied(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	remote := signertest.NewWeb3SignerAPI(key)
+	server := remote.StartHTTP()
+	defer server.Close()
+
+	web3Signer, err := signer.NewWeb3Signer(server.URL, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("could not create remote signer: %v", err)
+	}
+
+	remote.Deny(true)
+	if _, err := web3Signer.SignTypedData(testTypedData()); err == nil || !strings.Contains(err.Error(), "status code 403") {
+		t.Fatalf("expected the remote signer to deny the request, got %v", err)
+	}
+}
+
+func TestWeb3SignerTransactOpts(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	server := signertest.NewWeb3SignerAPI(key).StartHTTP()
+	defer server.Close()
+
+	web3Signer, err := signer.NewWeb3Signer(server.URL, address)
+	if err != nil {
+		t.Fatalf("could not create remote signer: %v", err)
+	}
+
+	chainID := big.NewInt(1337)
+	opts, err := web3Signer.TransactOpts(chainID)
+	if err != nil {
+		t.Fatalf("could not create transaction options: %v", err)
+	}
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}}
+	transactions := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1e9), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+		types.NewTx(&types.LegacyTx{Nonce: 2, GasPrice: big.NewInt(1e9), Gas: 100000, Data: []byte{0x60, 0x00}}),
+		types.NewTx(&types.AccessListTx{ChainID: chainID, Nonce: 3, GasPrice: big.NewInt(1e9), Gas: 30000, To: &to, AccessList: accessList}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 4, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(2e9), Gas: 50000, To: &to, Data: []byte{0xde, 0xad}, AccessList: accessList}),
+	}
+	for _, tx := range transactions {
+		signed, err := opts.Signer(address, tx)
+		if err != nil {
+			t.Fatalf("could not sign transaction of type %d: %v", tx.Type(), err)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil {
+			t.Fatalf("could not recover sender of transaction of type %d: %v", tx.Type(), err)
+		}
+		if sender != address {
+			t.Fatalf("transaction of type %d signed by %s instead of %s", tx.Type(), sender.Hex(), address.Hex())
+		}
+		if signed.Hash() == tx.Hash() {
+			t.Fatalf("transaction of type %d was not signed", tx.Type())
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Package simtest runs Safe contracts on go-ethereum's simulated backend, so that safes commands can be
+// tested end to end against a real EVM. The simulated chain serves its JSON-RPC API over IPC, and the
+// endpoint can be passed to commands as --rpc.
+package simtest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/bindings/SafeProxy"
+	"github.com/G7DAO/safes/bindings/SafeProxyFactory"
+)
+
+// ChainID is the chain ID of the simulated chain.
+var ChainID = big.NewInt(1337)
+
+// KeystorePassword is the password of the keystore files written by Chain.Keystore.
+const KeystorePassword = "simtest"
+
+// Chain is a simulated chain with a number of funded accounts.
+type Chain struct {
+	Backend *simulated.Backend
+	Client  simulated.Client
+	// Endpoint is the IPC endpoint of the chain's JSON-RPC API.
+	Endpoint string
+	// Keys are the private keys of the funded accounts.
+	Keys []*ecdsa.PrivateKey
+
+	dir       string
+	keystores map[int]string
+}
+
+// Deployment holds the addresses of the Safe contracts deployed by Chain.Deploy.
+type Deployment struct {
+	Safe    common.Address
+	SafeL2  common.Address
+	Factory common.Address
+}
+
+// Starts a simulated chain with the given number of funded accounts. The chain is shut down when the
+// test finishes.
+func New(t testing.TB, accounts int) *Chain {
+	t.Helper()
+
+	// Unix socket paths are limited to around 100 characters, which the test's temporary directory
+	// can exceed.
+	dir, err := os.MkdirTemp("", "simtest")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	balance := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	alloc := types.GenesisAlloc{}
+	keys := make([]*ecdsa.PrivateKey, accounts)
+	for i := range keys {
+		keys[i], err = crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("could not generate key: %v", err)
+		}
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.Account{Balance: balance}
+	}
+
+	endpoint := filepath.Join(dir, "sim.ipc")
+	backend := simulated.NewBackend(alloc, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		nodeConf.IPCPath = endpoint
+		// Allow pre-signed transactions without a chain ID, such as the one which deploys the
+		// deterministic deployment proxy.
+		nodeConf.AllowUnprotectedTxs = true
+	})
+	t.Cleanup(func() { backend.Close() })
+
+	return &Chain{
+		Backend:   backend,
+		Client:    backend.Client(),
+		Endpoint:  endpoint,
+		Keys:      keys,
+		dir:       dir,
+		keystores: map[int]string{},
+	}
+}
+
+// Returns the address of the funded account with the given index.
+func (c *Chain) Address(index int) common.Address {
+	return crypto.PubkeyToAddress(c.Keys[index].PublicKey)
+}
+
+// Returns transaction options which sign with the funded account with the given index.
+func (c *Chain) TransactOpts(t testing.TB, index int) *bind.TransactOpts {
+	t.Helper()
+	opts, err := bind.NewKeyedTransactorWithChainID(c.Keys[index], ChainID)
+	if err != nil {
+		t.Fatalf("could not create transaction options: %v", err)
+	}
+	return opts
+}
+
+// Writes the key of the funded account with the given index to a keystore file, encrypted with
+// KeystorePassword, and returns the path of the file.
+func (c *Chain) Keystore(t testing.TB, index int) string {
+	t.Helper()
+	if path, ok := c.keystores[index]; ok {
+		return path
+	}
+	ks := keystore.NewKeyStore(filepath.Join(c.dir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(c.Keys[index], KeystorePassword)
+	if err != nil {
+		t.Fatalf("could not write keystore file: %v", err)
+	}
+	c.keystores[index] = account.URL.Path
+	return account.URL.Path
+}
+
+// Mines a block with the pending transactions.
+func (c *Chain) Commit() common.Hash {
+	return c.Backend.Commit()
+}
+
+// Mines a block every interval until the test finishes, for commands which wait for their transactions
+// to be mined.
+func (c *Chain) AutoCommit(t testing.TB, interval time.Duration) {
+	t.Helper()
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				c.Commit()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		<-stopped
+	})
+}
+
+// Mines the pending transactions and returns the receipt of the given transaction, failing the test if
+// it was not mined or reverted.
+func (c *Chain) Receipt(t testing.TB, hash common.Hash) *types.Receipt {
+	t.Helper()
+	c.Commit()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := c.Client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		t.Fatalf("could not get receipt of transaction %s: %v", hash.Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction %s reverted", hash.Hex())
+	}
+	return receipt
+}
+
+// Deploys the Safe and SafeL2 singletons and the SafeProxyFactory from the funded account with index 0.
+func (c *Chain) Deploy(t testing.TB) Deployment {
+	t.Helper()
+	opts := c.TransactOpts(t, 0)
+
+	safeAddress, safeTx, _, err := Safe.DeploySafe(opts, c.Client)
+	if err != nil {
+		t.Fatalf("could not deploy Safe: %v", err)
+	}
+	safeL2Address, safeL2Tx, _, err := SafeL2.DeploySafeL2(opts, c.Client)
+	if err != nil {
+		t.Fatalf("could not deploy SafeL2: %v", err)
+	}
+	factoryAddress, factoryTx, _, err := SafeProxyFactory.DeploySafeProxyFactory(opts, c.Client)
+	if err != nil {
+		t.Fatalf("could not deploy SafeProxyFactory: %v", err)
+	}
+
+	for _, tx := range []*types.Transaction{safeTx, safeL2Tx, factoryTx} {
+		c.Receipt(t, tx.Hash())
+	}
+	return Deployment{Safe: safeAddress, SafeL2: safeL2Address, Factory: factoryAddress}
+}
+
+// Deploys a SafeProxy for the given singleton from the funded account with index 0. The proxy is not
+// set up.
+func (c *Chain) DeployProxy(t testing.TB, singleton common.Address) common.Address {
+	t.Helper()
+	proxyAddress, tx, _, err := SafeProxy.DeploySafeProxy(c.TransactOpts(t, 0), c.Client, singleton)
+	if err != nil {
+		t.Fatalf("could not deploy SafeProxy: %v", err)
+	}
+	c.Receipt(t, tx.Hash())
+	return proxyAddress
+}
+
+// Returns the logs emitted by the given contract in a receipt.
+func Logs(receipt *types.Receipt, contract common.Address) []types.Log {
+	var logs []types.Log
+	for _, log := range receipt.Logs {
+		if log.Address == contract {
+			logs = append(logs, *log)
+		}
+	}
+	return logs
+}
+
+// Deploys a SafeProxy for the given singleton and sets it up with the funded accounts with the given
+// indices as owners. Returns the address of the proxy.
+func (c *Chain) SetupSafe(t testing.TB, singleton common.Address, threshold int64, owners ...int) common.Address {
+	t.Helper()
+	proxyAddress := c.DeployProxy(t, singleton)
+
+	safe, err := Safe.NewSafe(proxyAddress, c.Client)
+	if err != nil {
+		t.Fatalf("could not bind Safe: %v", err)
+	}
+	ownerAddresses := make([]common.Address, len(owners))
+	for i, owner := range owners {
+		ownerAddresses[i] = c.Address(owner)
+	}
+	tx, err := safe.Setup(c.TransactOpts(t, 0), ownerAddresses, big.NewInt(threshold), common.Address{}, []byte{}, common.Address{}, common.Address{}, big.NewInt(0), common.Address{})
+	if err != nil {
+		t.Fatalf("could not set up Safe: %v", err)
+	}
+	c.Receipt(t, tx.Hash())
+	return proxyAddress
+}
+
+// Deploys a contract with the given runtime code from the funded account with index 0, and returns its
+// address. This is used to put small hand-assembled contracts on the chain.
+func (c *Chain) DeployCode(t testing.TB, runtime []byte) common.Address {
+	t.Helper()
+	// PUSH2 len DUP1 PUSH1 12 PUSH1 0 CODECOPY PUSH1 0 RETURN, followed by the runtime code.
+	initCode := append([]byte{0x61, byte(len(runtime) >> 8), byte(len(runtime)), 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3}, runtime...)
+	address, tx, _, err := bind.DeployContract(c.TransactOpts(t, 0), abi.ABI{}, initCode, c.Client)
+	if err != nil {
+		t.Fatalf("could not deploy contract: %v", err)
+	}
+	c.Receipt(t, tx.Hash())
+	return address
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/output"
+)
+
+func createVerifyCmd() *cobra.Command {
+	var (
+		safe       string
+		rpc        string
+		trustedRaw []string
+	)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check a Safe's proxy and singleton against known-good code",
+		Long: `Check a Safe's proxy and singleton against known-good code.
+
+The runtime code of the proxy must match the code deployed by SafeProxy (from the bindings, or from the
+proxy factories of the chain registry). The singleton is read from storage slot 0 and its code must match
+Safe or SafeL2 (from the bindings) or the singletons of the chain registry. If either check fails, the
+Safe is not verified and the command fails.
+
+Guards, modules and fallback handlers other than the chain registry's fallback handlers are reported as
+non-standard. Pass their addresses with --trusted to accept them.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if rpc == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			for _, address := range trustedRaw {
+				if !common.IsHexAddress(address) {
+					return fmt.Errorf("invalid trusted address: %s", address)
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := ethclient.Dial(rpc)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+
+			trusted := make([]common.Address, len(trustedRaw))
+			for i, address := range trustedRaw {
+				trusted[i] = common.HexToAddress(address)
+			}
+
+			result, err := VerifySafe(context.Background(), client, common.HexToAddress(safe), trusted)
+			if err != nil {
+				return err
+			}
+			if err := output.Print(cmd, result); err != nil {
+				return err
+			}
+			if !result.Verified {
+				cmd.SilenceUsage = true
+				return output.ReportedError{Err: fmt.Errorf("Safe %s failed verification", result.Safe)}
+			}
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe to verify")
+	verifyCmd.Flags().StringVar(&rpc, "rpc", "", "URL of the JSONRPC API to use")
+	verifyCmd.Flags().StringSliceVar(&trustedRaw, "trusted", nil, "Addresses of guards, modules and fallback handlers to accept as standard (comma-separated or repeated)")
+	verifyCmd.MarkFlagRequired("safe")
+
+	return verifyCmd
+}
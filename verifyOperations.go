@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/G7DAO/safes/bindings/Safe"
+	"github.com/G7DAO/safes/bindings/SafeL2"
+	"github.com/G7DAO/safes/bindings/SafeProxy"
+	"github.com/G7DAO/safes/bindings/SafeProxyFactory"
+	"github.com/G7DAO/safes/chains"
+	"github.com/G7DAO/safes/safetx"
+)
+
+// VerificationBackend is what verifying a Safe needs from the chain.
+type VerificationBackend interface {
+	MigrationBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// CodeCheck reports whether the code of a contract is known.
+type CodeCheck struct {
+	Address  string `json:"address"`
+	CodeHash string `json:"codeHash"`
+	Known    bool   `json:"known"`
+	// Match describes the known-good code which the contract's code matches.
+	Match string `json:"match,omitempty"`
+}
+
+func (c CodeCheck) describe(name string) string {
+	if c.Known {
+		return fmt.Sprintf("%s: %s (code hash %s, matches %s)\n", name, c.Address, c.CodeHash, c.Match)
+	}
+	return fmt.Sprintf("%s: %s (code hash %s, UNKNOWN)\n", name, c.Address, c.CodeHash)
+}
+
+// AddressCheck reports whether a guard, module or fallback handler of a Safe is a standard one.
+type AddressCheck struct {
+	Address  string `json:"address"`
+	Standard bool   `json:"standard"`
+}
+
+func (c AddressCheck) describe(name string) string {
+	if c.Standard {
+		return fmt.Sprintf("%s: %s\n", name, c.Address)
+	}
+	return fmt.Sprintf("%s: %s (NON-STANDARD)\n", name, c.Address)
+}
+
+// VerificationResult is the result of the safe verify command. A Safe is verified if its proxy and
+// singleton code are known. It is standard if, in addition, it has no guard, modules or fallback
+// handlers other than the standard (or trusted) ones.
+type VerificationResult struct {
+	Safe            string         `json:"safe"`
+	Verified        bool           `json:"verified"`
+	Standard        bool           `json:"standard"`
+	Version         string         `json:"version,omitempty"`
+	Proxy           CodeCheck      `json:"proxy"`
+	Singleton       CodeCheck      `json:"singleton"`
+	FallbackHandler AddressCheck   `json:"fallbackHandler"`
+	Guard           AddressCheck   `json:"guard"`
+	Modules         []AddressCheck `json:"modules"`
+	Problems        []string       `json:"problems,omitempty"`
+	Warnings        []string       `json:"warnings,omitempty"`
+}
+
+func (r VerificationResult) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Safe: %s\n", r.Safe)
+	if r.Version != "" {
+		fmt.Fprintf(&b, "Version: %s\n", r.Version)
+	}
+	b.WriteString(r.Proxy.describe("Proxy"))
+	b.WriteString(r.Singleton.describe("Singleton"))
+	b.WriteString(r.FallbackHandler.describe("Fallback handler"))
+	b.WriteString(r.Guard.describe("Guard"))
+	if len(r.Modules) == 0 {
+		b.WriteString("Modules: none\n")
+	}
+	for _, module := range r.Modules {
+		b.WriteString(module.describe("Module"))
+	}
+	for _, problem := range r.Problems {
+		fmt.Fprintf(&b, "Problem: %s\n", problem)
+	}
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&b, "Warning: %s\n", warning)
+	}
+	switch {
+	case !r.Verified:
+		b.WriteString("Result: NOT VERIFIED\n")
+	case !r.Standard:
+		b.WriteString("Result: verified, with non-standard configuration\n")
+	default:
+		b.WriteString("Result: verified\n")
+	}
+	return b.String()
+}
+
+// knownCode maps the hashes of known-good runtime code to descriptions of it.
+type knownCode map[common.Hash]string
+
+func (k knownCode) add(code []byte, description string) {
+	if len(code) == 0 {
+		return
+	}
+	hash := crypto.Keccak256Hash(code)
+	if _, ok := k[hash]; !ok {
+		k[hash] = description
+	}
+}
+
+func (k knownCode) check(address common.Address, code []byte) CodeCheck {
+	hash := crypto.Keccak256Hash(code)
+	match, ok := k[hash]
+	return CodeCheck{Address: address.Hex(), CodeHash: hash.Hex(), Known: ok && len(code) > 0, Match: match}
+}
+
+// Returns the registry's contracts for each Safe version on the chain, sorted by version.
+func registryContracts(chainID *big.Int) ([]string, map[string]chains.Contracts) {
+	registry := chains.Default()
+	var versions []string
+	contracts := map[string]chains.Contracts{}
+	for version := range registry.Versions {
+		versionContracts, err := registry.Contracts(chainID, version)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+		contracts[version] = versionContracts
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		a, errA := safetx.ParseVersion(versions[i])
+		b, errB := safetx.ParseVersion(versions[j])
+		if errA != nil || errB != nil {
+			return versions[i] < versions[j]
+		}
+		return !a.AtLeast(b.Major, b.Minor, b.Patch)
+	})
+	return versions, contracts
+}
+
+// Returns the known-good runtime code of Safe proxies for the given singleton: the code deployed by
+// SafeProxy from the bindings, and by the proxy factories of the chain registry.
+func knownProxyCode(ctx context.Context, backend VerificationBackend, chainID *big.Int, singleton common.Address) (knownCode, error) {
+	known := knownCode{}
+	addressType, err := abi.NewType("address", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create address type: %v", err)
+	}
+	constructorArguments, err := abi.Arguments{{Type: addressType}}.Pack(singleton)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode proxy constructor arguments: %v", err)
+	}
+
+	runtimeCode, err := expectedRuntimeCode(ctx, backend, append(common.FromHex(SafeProxy.SafeProxyBin), constructorArguments...))
+	if err != nil {
+		return nil, fmt.Errorf("SafeProxy: %v", err)
+	}
+	known.add(runtimeCode, "SafeProxy "+bootstrapVersion+" (bindings)")
+
+	versions, contracts := registryContracts(chainID)
+	for _, version := range versions {
+		factory := common.HexToAddress(contracts[version].ProxyFactory)
+		if code, err := backend.CodeAt(ctx, factory, nil); err != nil || len(code) == 0 {
+			continue
+		}
+		factoryCaller, err := SafeProxyFactory.NewSafeProxyFactoryCaller(factory, backend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SafeProxyFactory instance: %v", err)
+		}
+		creationCode, err := factoryCaller.ProxyCreationCode(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			continue
+		}
+		runtimeCode, err := expectedRuntimeCode(ctx, backend, append(creationCode, constructorArguments...))
+		if err != nil {
+			continue
+		}
+		known.add(runtimeCode, fmt.Sprintf("SafeProxy %s (proxy factory %s)", version, factory.Hex()))
+	}
+	return known, nil
+}
+
+// Returns the known-good runtime code of Safe singletons: the code deployed by Safe and SafeL2 from the
+// bindings, and the code at the singleton addresses of the chain registry. The registry's addresses are
+// those of deterministic deployments, so the code at them is the code of the canonical singletons.
+func knownSingletonCode(ctx context.Context, backend VerificationBackend, chainID *big.Int) (knownCode, error) {
+	known := knownCode{}
+	for _, singleton := range []struct {
+		name     string
+		initCode string
+	}{
+		{"Safe", Safe.SafeBin},
+		{"SafeL2", SafeL2.SafeL2Bin},
+	} {
+		runtimeCode, err := expectedRuntimeCode(ctx, backend, common.FromHex(singleton.initCode))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", singleton.name, err)
+		}
+		known.add(runtimeCode, singleton.name+" "+bootstrapVersion+" (bindings)")
+	}
+
+	versions, contracts := registryContracts(chainID)
+	for _, version := range versions {
+		for name, address := range map[string]string{"Safe": contracts[version].Singleton, "SafeL2": contracts[version].SingletonL2} {
+			if address == "" {
+				continue
+			}
+			code, err := backend.CodeAt(ctx, common.HexToAddress(address), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get code of %s: %v", address, err)
+			}
+			known.add(code, fmt.Sprintf("%s %s (%s)", name, version, address))
+		}
+	}
+	return known, nil
+}
+
+// Returns the modules enabled on a Safe.
+func safeModules(ctx context.Context, safe *Safe.SafeCaller) ([]common.Address, error) {
+	var modules []common.Address
+	start := safetx.SentinelAddress
+	for {
+		page, err := safe.GetModulesPaginated(&bind.CallOpts{Context: ctx}, start, big.NewInt(100))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get modules: %v", err)
+		}
+		modules = append(modules, page.Array...)
+		if page.Next == safetx.SentinelAddress || page.Next == (common.Address{}) || len(page.Array) == 0 {
+			return modules, nil
+		}
+		start = page.Next
+	}
+}
+
+// Verifies the Safe at safeAddress: checks that its proxy and singleton code are known, and that its
+// fallback handler, guard and modules are standard. Addresses in trusted are treated as standard.
+func VerifySafe(ctx context.Context, backend VerificationBackend, safeAddress common.Address, trusted []common.Address) (*VerificationResult, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	result := &VerificationResult{Safe: safeAddress.Hex(), Modules: []AddressCheck{}}
+
+	singleton, err := storageAddress(ctx, backend, safeAddress, safetx.SingletonStorageSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyCode, err := backend.CodeAt(ctx, safeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code of %s: %v", safeAddress.Hex(), err)
+	}
+	knownProxies := knownCode{}
+	if singleton == (common.Address{}) {
+		// SafeProxy refuses to be deployed without a singleton, so no known proxy has an empty slot 0.
+		result.Problems = append(result.Problems, "storage slot 0 holds no singleton")
+	} else {
+		knownProxies, err = knownProxyCode(ctx, backend, chainID, singleton)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Proxy = knownProxies.check(safeAddress, proxyCode)
+	if !result.Proxy.Known {
+		result.Problems = append(result.Problems, "the code of the proxy does not match any known SafeProxy")
+	}
+
+	singletonCode, err := backend.CodeAt(ctx, singleton, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code of %s: %v", singleton.Hex(), err)
+	}
+	knownSingletons, err := knownSingletonCode(ctx, backend, chainID)
+	if err != nil {
+		return nil, err
+	}
+	result.Singleton = knownSingletons.check(singleton, singletonCode)
+	if !result.Singleton.Known {
+		result.Problems = append(result.Problems, fmt.Sprintf("the singleton at storage slot 0 (%s) does not match any known Safe singleton", singleton.Hex()))
+	}
+
+	result.Verified = len(result.Problems) == 0
+	if !result.Verified {
+		// Without known code, the Safe's getters and storage slots cannot be trusted.
+		return result, nil
+	}
+
+	version, err := safetx.DetectVersion(ctx, backend, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	result.Version = version.String()
+
+	standard := map[common.Address]bool{}
+	for _, address := range trusted {
+		standard[address] = true
+	}
+
+	fallbackHandlers := map[common.Address]bool{{}: true}
+	_, contracts := registryContracts(chainID)
+	for _, versionContracts := range contracts {
+		if versionContracts.FallbackHandler != "" {
+			fallbackHandlers[common.HexToAddress(versionContracts.FallbackHandler)] = true
+		}
+	}
+	fallbackHandler, err := storageAddress(ctx, backend, safeAddress, safetx.FallbackHandlerStorageSlot)
+	if err != nil {
+		return nil, err
+	}
+	result.FallbackHandler = AddressCheck{Address: fallbackHandler.Hex(), Standard: fallbackHandlers[fallbackHandler] || standard[fallbackHandler]}
+	if !result.FallbackHandler.Standard {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("unknown fallback handler %s", fallbackHandler.Hex()))
+	}
+
+	guard, err := storageAddress(ctx, backend, safeAddress, safetx.GuardStorageSlot)
+	if err != nil {
+		return nil, err
+	}
+	result.Guard = AddressCheck{Address: guard.Hex(), Standard: guard == (common.Address{}) || standard[guard]}
+	if !result.Guard.Standard {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("unknown guard %s", guard.Hex()))
+	}
+
+	safeCaller, err := Safe.NewSafeCaller(safeAddress, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Safe instance: %v", err)
+	}
+	modules, err := safeModules(ctx, safeCaller)
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		check := AddressCheck{Address: module.Hex(), Standard: standard[module]}
+		result.Modules = append(result.Modules, check)
+		if !check.Standard {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unknown module %s", module.Hex()))
+		}
+	}
+
+	result.Standard = len(result.Warnings) == 0
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/G7DAO/safes/simtest"
+)
+
+// Runtime code which returns true for every call: PUSH1 1 PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN. It is a
+// valid guard, since it claims to support every interface.
+var yesContract = []byte{0x60, 0x01, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+
+func TestVerifySafe(t *testing.T) {
+	chain := simtest.New(t, 2)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.SafeL2, 1, 1)
+	verifyArgs := []string{"safe", "verify", "--rpc", chain.Endpoint, "--safe", safeAddress.Hex()}
+
+	var result VerificationResult
+	mustRunCLI(t, &result, verifyArgs...)
+	if !result.Verified || !result.Standard || result.Version != bootstrapVersion {
+		t.Fatalf("unexpected verification of a standard Safe: %+v", result)
+	}
+	if common.HexToAddress(result.Singleton.Address) != deployment.SafeL2 || !strings.HasPrefix(result.Singleton.Match, "SafeL2") {
+		t.Fatalf("unexpected singleton check: %+v", result.Singleton)
+	}
+
+	// A guard, a module and a fallback handler make the Safe non-standard, unless they are trusted.
+	guard := chain.DeployCode(t, yesContract)
+	module := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	fallbackHandler := chain.DeployCode(t, []byte{0x00})
+	h := newSafeHarness(t, chain, "singleton-l2", safeAddress)
+	h.mustExecTransaction(h.calldata("setGuard", guard), 1)
+	h.mustExecTransaction(h.calldata("enableModule", module), 1)
+	h.mustExecTransaction(h.calldata("setFallbackHandler", fallbackHandler), 1)
+
+	var configured VerificationResult
+	mustRunCLI(t, &configured, verifyArgs...)
+	if !configured.Verified || configured.Standard || len(configured.Warnings) != 3 {
+		t.Fatalf("unexpected verification of a non-standard Safe: %+v", configured)
+	}
+	if common.HexToAddress(configured.Guard.Address) != guard || len(configured.Modules) != 1 || common.HexToAddress(configured.Modules[0].Address) != module {
+		t.Fatalf("unexpected guard and modules: %+v, %+v", configured.Guard, configured.Modules)
+	}
+
+	var trusted VerificationResult
+	mustRunCLI(t, &trusted, append(verifyArgs, "--trusted", strings.Join([]string{guard.Hex(), module.Hex(), fallbackHandler.Hex()}, ","))...)
+	if !trusted.Verified || !trusted.Standard {
+		t.Fatalf("unexpected verification with trusted addresses: %+v", trusted)
+	}
+
+	// A singleton is not a proxy, and has no singleton of its own.
+	unverified, err := VerifySafe(context.Background(), chain.Client, deployment.Safe, nil)
+	if err != nil {
+		t.Fatalf("could not verify singleton: %v", err)
+	}
+	if unverified.Verified || unverified.Proxy.Known || unverified.Singleton.Known || len(unverified.Problems) != 3 {
+		t.Fatalf("unexpected verification of a singleton: %+v", unverified)
+	}
+	if err := runCLI(t, nil, "safe", "verify", "--rpc", chain.Endpoint, "--safe", deployment.Safe.Hex()); err == nil || !strings.Contains(err.Error(), "failed verification") {
+		t.Fatalf("expected verification to fail, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+
+	"github.com/G7DAO/safes/output"
+)
+
+func createWatchCmd() *cobra.Command {
+	var (
+		safe           string
+		rpcURL         string
+		webhooks       []string
+		events         []string
+		confirmations  uint64
+		pollInterval   time.Duration
+		poll           bool
+		checkpointPath string
+		fromBlock      uint64
+		chunkSize      uint64
+	)
+
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch a Safe and report events as they happen",
+		Long: `Watch a Safe and report events as they happen, until interrupted.
+
+By default, changes to the owners, threshold, modules and guards of the Safe and failed transactions are
+reported (choose others with --event). Events are printed and posted as JSON to every --webhook URL once
+--confirmations blocks follow them, and only if their block is still part of the chain then.
+
+Over websocket and IPC connections, events are received through subscriptions. Over HTTP (or with
+--poll), the node is polled for the logs of confirmed blocks every --poll-interval.
+
+With --checkpoint, the last block whose events were reported is recorded in a file, and a restarted watch
+resumes from the block after it, so that no events are missed. Events are delivered at least once: if a
+webhook fails, the watch stops and the events of the unrecorded blocks are delivered again on restart.
+Without a checkpoint, the watch starts at --from-block if given, or at the next confirmed block.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(safe) {
+				return fmt.Errorf("invalid Safe address: %s", safe)
+			}
+			if rpcURL == "" {
+				return fmt.Errorf("--rpc not specified (this should be a URL to an Ethereum JSONRPC API)")
+			}
+			if pollInterval <= 0 {
+				return fmt.Errorf("--poll-interval must be positive")
+			}
+			if chunkSize == 0 {
+				return fmt.Errorf("--chunk-size must be at least 1")
+			}
+			for _, name := range events {
+				if !watchableEvents[name] {
+					return fmt.Errorf("cannot watch %s (choose from %v)", name, WatchableEvents())
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			rpcClient, err := rpc.DialContext(ctx, rpcURL)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+			}
+			client := ethclient.NewClient(rpcClient)
+			defer client.Close()
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get chain ID: %v", err)
+			}
+
+			notifyWebhooks := WebhookNotifier(webhooks, 10*time.Second)
+			config := WatchConfig{
+				Safe:           common.HexToAddress(safe),
+				ChainID:        chainID,
+				Events:         events,
+				Confirmations:  confirmations,
+				PollInterval:   pollInterval,
+				ChunkSize:      chunkSize,
+				Poll:           poll,
+				CheckpointPath: checkpointPath,
+				Notify: func(notification SafeNotification) error {
+					if err := output.Print(cmd, notification); err != nil {
+						return err
+					}
+					return notifyWebhooks(notification)
+				},
+			}
+			if cmd.Flags().Changed("from-block") {
+				config.FromBlock = &fromBlock
+			}
+
+			return WatchSafe(ctx, client, config)
+		},
+	}
+
+	watchCmd.Flags().StringVar(&safe, "safe", "", "Address of the Safe")
+	watchCmd.Flags().StringVar(&rpcURL, "rpc", "", "URL of the JSONRPC API to use (websocket or IPC for subscriptions)")
+	watchCmd.Flags().StringArrayVar(&webhooks, "webhook", nil, "URL to post events to as JSON (repeat for several webhooks)")
+	watchCmd.Flags().StringSliceVar(&events, "event", DefaultWatchedEvents, "Events to report")
+	watchCmd.Flags().Uint64Var(&confirmations, "confirmations", 6, "Number of blocks which must follow an event before it is reported")
+	watchCmd.Flags().DurationVar(&pollInterval, "poll-interval", 15*time.Second, "How often to check for new blocks")
+	watchCmd.Flags().BoolVar(&poll, "poll", false, "Poll for logs even if the connection supports subscriptions")
+	watchCmd.Flags().StringVar(&checkpointPath, "checkpoint", "", "Path to a file recording the last reported block, to resume from")
+	watchCmd.Flags().Uint64Var(&fromBlock, "from-block", 0, "First block to report events from, if there is no checkpoint (default: the next confirmed block)")
+	watchCmd.Flags().Uint64Var(&chunkSize, "chunk-size", 2000, "Number of blocks to query for logs at a time")
+	watchCmd.MarkFlagRequired("safe")
+
+	return watchCmd
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/G7DAO/safes/output"
+)
+
+// The events which safe watch reports by default: changes to the owners, threshold, modules and guards
+// of the Safe, and failed transactions.
+var DefaultWatchedEvents = []string{
+	"AddedOwner",
+	"RemovedOwner",
+	"ChangedThreshold",
+	"EnabledModule",
+	"ChangedGuard",
+	"ChangedModuleGuard",
+	"ExecutionFailure",
+	"ExecutionFromModuleFailure",
+}
+
+// The events which safe watch can report.
+var watchableEvents = map[string]bool{
+	"AddedOwner":                 true,
+	"RemovedOwner":               true,
+	"ChangedThreshold":           true,
+	"EnabledModule":              true,
+	"DisabledModule":             true,
+	"ChangedGuard":               true,
+	"ChangedModuleGuard":         true,
+	"ChangedFallbackHandler":     true,
+	"ExecutionSuccess":           true,
+	"ExecutionFailure":           true,
+	"ExecutionFromModuleSuccess": true,
+	"ExecutionFromModuleFailure": true,
+	"SafeReceived":               true,
+}
+
+// Returns the names of the events which safe watch can report, sorted.
+func WatchableEvents() []string {
+	names := make([]string, 0, len(watchableEvents))
+	for name := range watchableEvents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WatchCheckpoint records the last block up to which safe watch has delivered the events of a Safe.
+type WatchCheckpoint struct {
+	ChainID   string `json:"chainId"`
+	Safe      string `json:"safe"`
+	LastBlock uint64 `json:"lastBlock"`
+}
+
+// Loads the checkpoint file at path, or returns nil if there is none.
+func LoadWatchCheckpoint(path string) (*WatchCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %v", err)
+	}
+	var checkpoint WatchCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file %s: %v", path, err)
+	}
+	return &checkpoint, nil
+}
+
+// Writes the checkpoint to path. The file is replaced atomically, so that it is never left half written.
+func (c WatchCheckpoint) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %v", err)
+	}
+	return nil
+}
+
+// SafeNotification is an event which safe watch reports.
+type SafeNotification struct {
+	ChainID string `json:"chainId"`
+	Safe    string `json:"safe"`
+	SafeEvent
+}
+
+func (n SafeNotification) Text() string {
+	return fmt.Sprintf("%s: %s\n", n.Safe, n.SafeEvent.Text())
+}
+
+// Returns a function which posts notifications as JSON to every webhook URL. Each delivery is attempted
+// up to three times.
+func WebhookNotifier(urls []string, timeout time.Duration) func(SafeNotification) error {
+	client := &http.Client{Timeout: timeout}
+	return func(notification SafeNotification) error {
+		body, err := json.Marshal(notification)
+		if err != nil {
+			return fmt.Errorf("failed to encode notification: %v", err)
+		}
+		for _, url := range urls {
+			var lastErr error
+			for attempt := 0; attempt < 3; attempt++ {
+				if attempt > 0 {
+					time.Sleep(time.Duration(attempt) * time.Second)
+				}
+				lastErr = postWebhook(client, url, body)
+				if lastErr == nil {
+					break
+				}
+			}
+			if lastErr != nil {
+				return fmt.Errorf("failed to notify %s: %v", url, lastErr)
+			}
+		}
+		return nil
+	}
+}
+
+func postWebhook(client *http.Client, url string, body []byte) error {
+	response, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
+
+// WatchConfig configures WatchSafe.
+type WatchConfig struct {
+	Safe    common.Address
+	ChainID *big.Int
+	// Events are the names of the events to report.
+	Events []string
+	// Confirmations is the number of blocks which must follow an event before it is reported.
+	Confirmations uint64
+	// PollInterval is how often the head of the chain is checked and newly confirmed blocks are queried for
+	// logs.
+	PollInterval time.Duration
+	ChunkSize    uint64
+	// Poll disables subscriptions, for nodes which do not support them.
+	Poll bool
+	// CheckpointPath is the path of the checkpoint file, if any.
+	CheckpointPath string
+	// FromBlock is the first block to report events from when there is no checkpoint. If it is nil, only
+	// events after the current head are reported.
+	FromBlock *uint64
+	// Notify is called with every event, in order. If it fails, watching stops without moving the
+	// checkpoint, so that the event is delivered again on restart (along with the events of the same
+	// range which were delivered before it).
+	Notify func(SafeNotification) error
+}
+
+// Watches a Safe for events until ctx is done. Events are reported once Confirmations blocks follow them,
+// if their block is still part of the chain, and the checkpoint is moved past them.
+//
+// Confirmed blocks are always queried for logs, so that no event is missed. Unless Poll is set, events
+// are also received through subscriptions (which need a websocket or IPC connection), which only let
+// them wait for confirmation before they are queried. Events which are removed from the chain by a
+// reorganization are dropped before they are reported.
+func WatchSafe(ctx context.Context, backend EventsBackend, config WatchConfig) error {
+	safeABI, err := safeEventsABI()
+	if err != nil {
+		return err
+	}
+	watched := map[string]bool{}
+	for _, name := range config.Events {
+		if !watchableEvents[name] {
+			return fmt.Errorf("cannot watch %s (choose from %s)", name, strings.Join(WatchableEvents(), ", "))
+		}
+		watched[name] = true
+	}
+
+	confirmedBlock := func(head uint64) uint64 {
+		if head < config.Confirmations {
+			return 0
+		}
+		return head - config.Confirmations
+	}
+
+	// The subscription is for all logs of the Safe, rather than for the events of the SafeL2 ABI: Safes
+	// before 1.4.0 emit the same events without indexed arguments, and nodes drop logs which have fewer
+	// topics than a query. It starts before the head is read, so that it receives the logs of every block
+	// after the head.
+	logs := make(chan types.Log, 64)
+	var subscription ethereum.Subscription
+	var subscriptionErrors <-chan error
+	unsubscribe := func() {
+		if subscription != nil {
+			subscription.Unsubscribe()
+		}
+		subscription, subscriptionErrors = nil, nil
+	}
+	defer unsubscribe()
+
+	if !config.Poll {
+		subscription, err = backend.SubscribeFilterLogs(ctx, ethereum.FilterQuery{Addresses: []common.Address{config.Safe}}, logs)
+		if err != nil {
+			output.Infoln("Subscriptions are not available (", err, "), polling for logs every", config.PollInterval)
+			subscription = nil
+		} else {
+			subscriptionErrors = subscription.Err()
+		}
+	}
+
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	// next is the first block whose events have not been reported.
+	next := confirmedBlock(head.Number.Uint64()) + 1
+	if config.CheckpointPath != "" {
+		checkpoint, err := LoadWatchCheckpoint(config.CheckpointPath)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			if checkpoint.ChainID != config.ChainID.String() || common.HexToAddress(checkpoint.Safe) != config.Safe {
+				return fmt.Errorf("checkpoint file %s is for Safe %s on chain %s", config.CheckpointPath, checkpoint.Safe, checkpoint.ChainID)
+			}
+			next = checkpoint.LastBlock + 1
+			output.Infoln("Resuming after block", checkpoint.LastBlock)
+		} else if config.FromBlock != nil {
+			next = *config.FromBlock
+		}
+	} else if config.FromBlock != nil {
+		next = *config.FromBlock
+	}
+
+	// Events waiting for confirmation, by block hash and log index. An event which is both received from
+	// the subscription and queried is only kept once.
+	pending := map[string]SafeEvent{}
+	logKey := func(log types.Log) string {
+		return fmt.Sprintf("%s/%d", log.BlockHash.Hex(), log.Index)
+	}
+	addEvents := func(events []SafeEvent, _ uint64) error {
+		for _, event := range events {
+			if watched[event.Event] {
+				pending[logKey(event.Log)] = event
+			}
+		}
+		return nil
+	}
+
+	flush := func() error {
+		head, err := backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block: %v", err)
+		}
+		confirmed := confirmedBlock(head.Number.Uint64())
+		if confirmed < next {
+			return nil
+		}
+		if err := ScanSafeEvents(ctx, backend, config.Safe, next, confirmed, config.ChunkSize, addEvents); err != nil {
+			return err
+		}
+
+		var ready []SafeEvent
+		for key, event := range pending {
+			if event.BlockNumber <= confirmed {
+				ready = append(ready, event)
+				delete(pending, key)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool {
+			if ready[i].BlockNumber != ready[j].BlockNumber {
+				return ready[i].BlockNumber < ready[j].BlockNumber
+			}
+			return ready[i].LogIndex < ready[j].LogIndex
+		})
+
+		headers := map[uint64]*types.Header{}
+		for _, event := range ready {
+			header, ok := headers[event.BlockNumber]
+			if !ok {
+				header, err = backend.HeaderByNumber(ctx, new(big.Int).SetUint64(event.BlockNumber))
+				if err != nil {
+					return fmt.Errorf("failed to get block %d: %v", event.BlockNumber, err)
+				}
+				headers[event.BlockNumber] = header
+			}
+			if header.Hash() != event.Log.BlockHash {
+				// The block of the event was replaced by a reorganization.
+				continue
+			}
+			event.Timestamp = header.Time
+			notification := SafeNotification{ChainID: config.ChainID.String(), Safe: config.Safe.Hex(), SafeEvent: event}
+			if err := config.Notify(notification); err != nil {
+				return err
+			}
+		}
+
+		next = confirmed + 1
+		return saveWatchCheckpoint(config, confirmed)
+	}
+
+	if err := flush(); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	ticker := time.NewTicker(config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case log := <-logs:
+			if log.Removed {
+				delete(pending, logKey(log))
+				continue
+			}
+			if log.BlockNumber < next {
+				continue
+			}
+			event, err := DecodeSafeEvent(safeABI, log)
+			if err != nil {
+				return err
+			}
+			if watched[event.Event] {
+				pending[logKey(log)] = event
+			}
+
+		case err := <-subscriptionErrors:
+			output.Infoln("Subscription failed (", err, "), polling for logs every", config.PollInterval)
+			unsubscribe()
+
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				if ctx.Err() != nil {
+					// The watch was interrupted during the flush.
+					return nil
+				}
+				return err
+			}
+		}
+	}
+}
+
+func saveWatchCheckpoint(config WatchConfig, lastBlock uint64) error {
+	if config.CheckpointPath == "" {
+		return nil
+	}
+	return WatchCheckpoint{ChainID: config.ChainID.String(), Safe: config.Safe.Hex(), LastBlock: lastBlock}.Save(config.CheckpointPath)
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/G7DAO/safes/simtest"
+)
+
+// Starts a webhook which forwards the notifications posted to it to the returned channel.
+func notificationWebhook(t *testing.T) (string, <-chan SafeNotification) {
+	notifications := make(chan SafeNotification, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification SafeNotification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Errorf("could not decode notification: %v", err)
+		}
+		notifications <- notification
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, notifications
+}
+
+// Runs WatchSafe until the returned function is called, which returns the error of WatchSafe.
+func startWatch(t *testing.T, chain *simtest.Chain, config WatchConfig) func() error {
+	return startWatchWith(t, chain.Client, config)
+}
+
+// Runs WatchSafe on the given backend until the returned function is called, which returns the error of
+// WatchSafe.
+func startWatchWith(t *testing.T, backend EventsBackend, config WatchConfig) func() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- WatchSafe(ctx, backend, config)
+	}()
+	stopped := false
+	stop := func() error {
+		if stopped {
+			return nil
+		}
+		stopped = true
+		cancel()
+		return <-done
+	}
+	t.Cleanup(func() { stop() })
+	return stop
+}
+
+func nextNotification(t *testing.T, notifications <-chan SafeNotification) SafeNotification {
+	t.Helper()
+	select {
+	case notification := <-notifications:
+		return notification
+	case <-time.After(10 * time.Second):
+		t.Fatalf("no notification was delivered")
+	}
+	return SafeNotification{}
+}
+
+func noNotification(t *testing.T, notifications <-chan SafeNotification) {
+	t.Helper()
+	select {
+	case notification := <-notifications:
+		t.Fatalf("unexpected notification: %+v", notification)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatchSafe(t *testing.T) {
+	for _, poll := range []bool{false, true} {
+		name := "subscriptions"
+		if poll {
+			name = "polling"
+		}
+		t.Run(name, func(t *testing.T) {
+			chain := simtest.New(t, accountCount)
+			deployment := chain.Deploy(t)
+			safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+			h := newSafeHarness(t, chain, "singleton", safeAddress)
+
+			url, notifications := notificationWebhook(t)
+			fromBlock := uint64(0)
+			config := WatchConfig{
+				Safe:           safeAddress,
+				ChainID:        simtest.ChainID,
+				Events:         DefaultWatchedEvents,
+				Confirmations:  2,
+				PollInterval:   20 * time.Millisecond,
+				ChunkSize:      100,
+				Poll:           poll,
+				CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+				FromBlock:      &fromBlock,
+				Notify:         WebhookNotifier([]string{url}, 5*time.Second),
+			}
+			stop := startWatch(t, chain, config)
+
+			// The owner is added in the last block, so it is not confirmed yet.
+			h.mustExecTransaction(h.calldata("addOwnerWithThreshold", chain.Address(owner2), big.NewInt(1)), owner1)
+			noNotification(t, notifications)
+			h.mustExecTransaction(h.calldata("changeThreshold", big.NewInt(2)), owner1)
+			chain.Commit()
+			chain.Commit()
+
+			added := nextNotification(t, notifications)
+			if added.Event != "AddedOwner" || added.Arguments["owner"] != chain.Address(owner2).Hex() || added.Safe != safeAddress.Hex() || added.Timestamp == 0 {
+				t.Fatalf("unexpected notification: %+v", added)
+			}
+			if changed := nextNotification(t, notifications); changed.Event != "ChangedThreshold" || changed.Arguments["threshold"] != "2" {
+				t.Fatalf("unexpected notification: %+v", changed)
+			}
+			noNotification(t, notifications)
+			if err := stop(); err != nil {
+				t.Fatalf("watch failed: %v", err)
+			}
+
+			checkpoint, err := LoadWatchCheckpoint(config.CheckpointPath)
+			if err != nil || checkpoint == nil {
+				t.Fatalf("could not load checkpoint: %v", err)
+			}
+			if checkpoint.Safe != safeAddress.Hex() || checkpoint.LastBlock < added.BlockNumber {
+				t.Fatalf("unexpected checkpoint %+v", checkpoint)
+			}
+
+			// Events emitted while the watch is stopped are delivered after a restart, and events which
+			// were delivered before are not.
+			h.mustExecTransaction(h.calldata("changeThreshold", big.NewInt(1)), owner1, owner2)
+			chain.Commit()
+			chain.Commit()
+			config.FromBlock = nil
+			stop = startWatch(t, chain, config)
+			if changed := nextNotification(t, notifications); changed.Event != "ChangedThreshold" || changed.Arguments["threshold"] != "1" {
+				t.Fatalf("unexpected notification after restart: %+v", changed)
+			}
+			noNotification(t, notifications)
+			if err := stop(); err != nil {
+				t.Fatalf("watch failed: %v", err)
+			}
+
+			// A checkpoint is only used for the Safe it was written for.
+			config.Safe = common.HexToAddress("0xdead")
+			if err := WatchSafe(context.Background(), chain.Client, config); err == nil {
+				t.Fatalf("expected a checkpoint of another Safe to be refused")
+			}
+		})
+	}
+}
+
+func TestWatchSafeLegacyEvents(t *testing.T) {
+	safeABI, err := safeEventsABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, poll := range []bool{false, true} {
+		name := "subscriptions"
+		if poll {
+			name = "polling"
+		}
+		t.Run(name, func(t *testing.T) {
+			chain := simtest.New(t, accountCount)
+			owner := chain.Address(owner2)
+
+			// A contract which emits AddedOwner as Safe 1.3.0 does, with the owner in the data rather than
+			// in a topic: PUSH32 owner PUSH1 0 MSTORE PUSH32 topic PUSH1 32 PUSH1 0 LOG1 STOP.
+			code := append([]byte{0x7f}, common.LeftPadBytes(owner.Bytes(), 32)...)
+			code = append(code, 0x60, 0x00, 0x52, 0x7f)
+			code = append(code, safeABI.Events["AddedOwner"].ID.Bytes()...)
+			code = append(code, 0x60, 0x20, 0x60, 0x00, 0xa1, 0x00)
+			legacySafe := chain.DeployCode(t, code)
+
+			url, notifications := notificationWebhook(t)
+			startWatch(t, chain, WatchConfig{
+				Safe:          legacySafe,
+				ChainID:       simtest.ChainID,
+				Events:        DefaultWatchedEvents,
+				Confirmations: 1,
+				PollInterval:  20 * time.Millisecond,
+				ChunkSize:     100,
+				Poll:          poll,
+				Notify:        WebhookNotifier([]string{url}, 5*time.Second),
+			})
+			// Only events after the head are reported, so the event is emitted once the watch has started.
+			noNotification(t, notifications)
+
+			tx, err := bind.NewBoundContract(legacySafe, abi.ABI{}, chain.Client, chain.Client, chain.Client).Transfer(chain.TransactOpts(t, owner1))
+			if err != nil {
+				t.Fatalf("could not emit event: %v", err)
+			}
+			chain.Receipt(t, tx.Hash())
+			chain.Commit()
+
+			added := nextNotification(t, notifications)
+			if added.Event != "AddedOwner" || added.Arguments["owner"] != owner.Hex() {
+				t.Fatalf("unexpected notification: %+v", added)
+			}
+		})
+	}
+}
+
+// lossyBackend is a backend whose log subscriptions never deliver any log, as when logs are emitted
+// before a subscription is live.
+type lossyBackend struct {
+	EventsBackend
+}
+
+func (b lossyBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, _ chan<- types.Log) (ethereum.Subscription, error) {
+	return b.EventsBackend.SubscribeFilterLogs(ctx, query, make(chan types.Log, 64))
+}
+
+func TestWatchSafeMissedLogs(t *testing.T) {
+	chain := simtest.New(t, accountCount)
+	deployment := chain.Deploy(t)
+	safeAddress := chain.SetupSafe(t, deployment.Safe, 1, owner1)
+	h := newSafeHarness(t, chain, "singleton", safeAddress)
+
+	url, notifications := notificationWebhook(t)
+	startWatchWith(t, lossyBackend{chain.Client}, WatchConfig{
+		Safe:          safeAddress,
+		ChainID:       simtest.ChainID,
+		Events:        DefaultWatchedEvents,
+		Confirmations: 1,
+		PollInterval:  20 * time.Millisecond,
+		ChunkSize:     100,
+		Notify:        WebhookNotifier([]string{url}, 5*time.Second),
+	})
+	noNotification(t, notifications)
+
+	// Confirmed blocks are queried even while the subscription is live, so the event is not lost.
+	h.mustExecTransaction(h.calldata("changeThreshold", big.NewInt(1)), owner1)
+	chain.Commit()
+	if changed := nextNotification(t, notifications); changed.Event != "ChangedThreshold" || changed.Arguments["threshold"] != "1" {
+		t.Fatalf("unexpected notification: %+v", changed)
+	}
+	noNotification(t, notifications)
+}